@@ -38,11 +38,36 @@ func SetUpDatabaseConnection() *gorm.DB {
 
 	if err := db.AutoMigrate(
 		&entity.Role{},
+		&entity.RolePermission{},
 		&entity.User{},
 		&entity.Event{},
 		&entity.Ticket{},
 		&entity.PE2RSVP{},
 		&entity.LinkShortener{},
+		&entity.Certificate{},
+		&entity.Survey{},
+		&entity.SurveyQuestion{},
+		&entity.SurveyInvitation{},
+		&entity.SurveyResponse{},
+		&entity.SurveyAnswer{},
+		&entity.PE2Review{},
+		&entity.PE2ReviewScore{},
+		&entity.Speaker{},
+		&entity.Talk{},
+		&entity.Session{},
+		&entity.Sponsor{},
+		&entity.SponsorPlacement{},
+		&entity.RefreshToken{},
+		&entity.RevokedToken{},
+		&entity.UserIdentity{},
+		&entity.AuditLog{},
+		&entity.LoginLockout{},
+		&entity.UserToken{},
+		&entity.UserMFA{},
+		&entity.RecoveryCode{},
+		&entity.APIKey{},
+		&entity.APIKeyPermission{},
+		&entity.APIKeyEvent{},
 	); err != nil {
 		panic(err)
 	}
@@ -3,18 +3,51 @@ package config
 import (
 	"fmt"
 	"log"
-	"os"
+	"sync"
 	"time"
 
 	"github.com/TEDxITS/website-backend-2024/constants"
 	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/utils/keyring"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 type JWTService interface {
-	GenerateToken(userId string, role string) string
+	GenerateToken(userId string, role string, tokenId string) string
 	ValidateToken(token string) (*jwt.Token, error)
-	GetPayloadInsideToken(token string) (string, string, error)
+	GetPayloadInsideToken(token string) (string, string, string, error)
+	IsTokenRevoked(tokenId string) (bool, error)
+	HasPermission(role string, roleId string, permission string) (bool, error)
+	GetJWKS() keyring.JWKSet
+	ValidateAPIKey(keyHash string) (entity.APIKey, error)
+	GetAccount(userId string) (entity.User, error)
+	ForgetAccount(userId string)
+}
+
+// implemented by the repository holding revoked token ids,
+// kept as an interface here since config can not import repository
+type TokenDenylist interface {
+	IsTokenRevoked(jti string) (bool, error)
+}
+
+// implemented by the role repository, looked up on every request
+// so a changed role takes effect without waiting for tokens to expire.
+// Keyed by id so renaming a role never hands its grants to another one.
+type PermissionStore interface {
+	HasPermission(roleId string, permission string) (bool, error)
+}
+
+// implemented by the user repository with the role loaded. A token outlives a
+// suspension, deletion or role change, so the account is read again on each request.
+type AccountStore interface {
+	GetAccount(userId string) (entity.User, error)
+}
+
+// implemented by the api key repository, integrations send a key instead of a token
+type APIKeyStore interface {
+	GetByHash(hash string) (entity.APIKey, error)
+	UpdateLastUsed(id string, at time.Time) error
 }
 
 type jwtCustomClaim struct {
@@ -23,39 +56,57 @@ type jwtCustomClaim struct {
 	jwt.RegisteredClaims
 }
 
-type jwtService struct {
-	secretKey string
-	issuer    string
+type cachedAccount struct {
+	user      entity.User
+	expiresAt time.Time
 }
 
-func NewJWTService() JWTService {
-	return &jwtService{
-		secretKey: getSecretKey(),
-		issuer:    "TEDxITS 2024",
-	}
+type jwtService struct {
+	keyring     *keyring.Keyring
+	issuer      string
+	denylist    TokenDenylist
+	permissions PermissionStore
+	apiKeys     APIKeyStore
+	accounts    AccountStore
+
+	accountsMu    sync.Mutex
+	accountsCache map[string]cachedAccount
 }
 
-func getSecretKey() string {
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey == "" {
-		secretKey = "Template"
+func NewJWTService(keyring *keyring.Keyring, denylist TokenDenylist, permissions PermissionStore, apiKeys APIKeyStore, accounts AccountStore) JWTService {
+	return &jwtService{
+		keyring:       keyring,
+		issuer:        "TEDxITS 2024",
+		denylist:      denylist,
+		permissions:   permissions,
+		apiKeys:       apiKeys,
+		accounts:      accounts,
+		accountsCache: make(map[string]cachedAccount),
 	}
-	return secretKey
 }
 
-func (j *jwtService) GenerateToken(userId string, role string) string {
+func (j *jwtService) GenerateToken(userId string, role string, tokenId string) string {
 	claims := jwtCustomClaim{
 		userId,
 		role,
 		jwt.RegisteredClaims{
+			ID:        tokenId,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute * constants.JWT_EXPIRE_TIME_IN_MINUTES)),
 			Issuer:    j.issuer,
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tx, err := token.SignedString([]byte(j.secretKey))
+	key, err := j.keyring.Active()
+	if err != nil {
+		log.Println(err)
+		return ""
+	}
+
+	// the kid tells verifiers which published key to check against
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	tx, err := token.SignedString(key.Private)
 	if err != nil {
 		log.Println(err)
 	}
@@ -64,25 +115,125 @@ func (j *jwtService) GenerateToken(userId string, role string) string {
 
 func (j *jwtService) ValidateToken(token string) (*jwt.Token, error) {
 	return jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := j.keyring.Get(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+
+		// the algorithm has to match the key, a token can not pick its own
+		if token.Method.Alg() != key.Method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
 		}
-		return []byte(j.secretKey), nil
+		return key.Public, nil
 	})
 }
 
-func (j *jwtService) GetPayloadInsideToken(token string) (string, string, error) {
+func (j *jwtService) GetPayloadInsideToken(token string) (string, string, string, error) {
 	t_Token, err := j.ValidateToken(token)
 	if err != nil {
-		return "", "", err
+		return "", "", "", err
 	}
 
 	if !t_Token.Valid {
-		return "", "", dto.ErrTokenInvalid
+		return "", "", "", dto.ErrTokenInvalid
 	}
 
 	claims := t_Token.Claims.(jwt.MapClaims)
 	id := fmt.Sprintf("%v", claims["user_id"])
 	role := fmt.Sprintf("%v", claims["role"])
-	return id, role, nil
+
+	// tokens issued before ids were introduced simply have none
+	tokenId, _ := claims["jti"].(string)
+	return id, role, tokenId, nil
+}
+
+func (j *jwtService) IsTokenRevoked(tokenId string) (bool, error) {
+	if j.denylist == nil || tokenId == "" {
+		return false, nil
+	}
+
+	return j.denylist.IsTokenRevoked(tokenId)
+}
+
+func (j *jwtService) HasPermission(role string, roleId string, permission string) (bool, error) {
+	if role == constants.ENUM_ROLE_ADMIN {
+		return true, nil
+	}
+
+	if j.permissions == nil || roleId == "" {
+		return false, nil
+	}
+
+	return j.permissions.HasPermission(roleId, permission)
+}
+
+func (j *jwtService) GetJWKS() keyring.JWKSet {
+	return j.keyring.JWKS()
+}
+
+func (j *jwtService) ValidateAPIKey(keyHash string) (entity.APIKey, error) {
+	if j.apiKeys == nil {
+		return entity.APIKey{}, dto.ErrAPIKeyInvalid
+	}
+
+	key, err := j.apiKeys.GetByHash(keyHash)
+	if err != nil {
+		return entity.APIKey{}, dto.ErrAPIKeyInvalid
+	}
+
+	if key.RevokedAt != nil {
+		return entity.APIKey{}, dto.ErrAPIKeyRevoked
+	}
+
+	now := time.Now()
+	if key.ExpiresAt != nil && now.After(*key.ExpiresAt) {
+		return entity.APIKey{}, dto.ErrAPIKeyExpired
+	}
+
+	// a failed write only makes the last use less accurate, the request still goes through
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > constants.API_KEY_LAST_USED_INTERVAL {
+		if err := j.apiKeys.UpdateLastUsed(key.ID.String(), now); err != nil {
+			log.Println(err)
+		}
+	}
+
+	return key, nil
+}
+
+// cached for a few seconds so every request does not hit the database,
+// changes made through this instance are applied at once by ForgetAccount
+func (j *jwtService) GetAccount(userId string) (entity.User, error) {
+	if j.accounts == nil {
+		return entity.User{}, nil
+	}
+
+	j.accountsMu.Lock()
+	cached, ok := j.accountsCache[userId]
+	j.accountsMu.Unlock()
+
+	user := cached.user
+	if !ok || time.Now().After(cached.expiresAt) {
+		var err error
+		user, err = j.accounts.GetAccount(userId)
+		if err != nil {
+			return entity.User{}, dto.ErrUserNotFound
+		}
+
+		j.accountsMu.Lock()
+		j.accountsCache[userId] = cachedAccount{user: user, expiresAt: time.Now().Add(constants.ACCOUNT_CACHE_TTL)}
+		j.accountsMu.Unlock()
+	}
+
+	if user.SuspendedAt != nil {
+		return entity.User{}, dto.ErrAccountSuspended
+	}
+
+	return user, nil
+}
+
+func (j *jwtService) ForgetAccount(userId string) {
+	j.accountsMu.Lock()
+	delete(j.accountsCache, userId)
+	j.accountsMu.Unlock()
 }
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/utils/keyring"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+func newTestKeyring(t *testing.T, ids ...string) *keyring.Keyring {
+	t.Helper()
+
+	ring := keyring.New()
+	for _, id := range ids {
+		if err := ring.Generate(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ring.SetActive(ids[len(ids)-1]); err != nil {
+		t.Fatal(err)
+	}
+	return ring
+}
+
+func TestTokenSurvivesKeyRotation(t *testing.T) {
+	ring := newTestKeyring(t, "old")
+	jwtService := NewJWTService(ring, nil, nil, nil, nil)
+	oldToken := jwtService.GenerateToken(uuid.NewString(), constants.ENUM_ROLE_USER, uuid.NewString())
+
+	// the new key is published first, then made active
+	if err := ring.Generate("new"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ring.SetActive("new"); err != nil {
+		t.Fatal(err)
+	}
+
+	newToken := jwtService.GenerateToken(uuid.NewString(), constants.ENUM_ROLE_USER, uuid.NewString())
+	parsed, err := jwtService.ValidateToken(newToken)
+	if err != nil || parsed.Header["kid"] != "new" {
+		t.Fatalf("new token: kid %v, err %v", parsed.Header["kid"], err)
+	}
+
+	if _, err := jwtService.ValidateToken(oldToken); err != nil {
+		t.Fatalf("token signed before the rotation was rejected: %v", err)
+	}
+
+	// once the old key is dropped, its tokens stop verifying
+	rotated := NewJWTService(newTestKeyring(t, "new"), nil, nil, nil, nil)
+	if _, err := rotated.ValidateToken(oldToken); err == nil {
+		t.Fatal("token of a removed key was accepted")
+	}
+}
+
+func TestValidateTokenRejectsOtherAlgorithm(t *testing.T) {
+	ring := newTestKeyring(t, "current")
+	jwtService := NewJWTService(ring, nil, nil, nil, nil)
+	key, _ := ring.Get("current")
+
+	// HMAC signed with the published key must not pass as the Ed25519 key
+	public, err := x509.MarshalPKIXPublicKey(key.Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"user_id": uuid.NewString()})
+	token.Header["kid"] = "current"
+	signed, err := token.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := jwtService.ValidateToken(signed); err == nil {
+		t.Fatal("token with a swapped algorithm was accepted")
+	}
+}
+
+func TestGetJWKSPublishesEveryKey(t *testing.T) {
+	jwtService := NewJWTService(newTestKeyring(t, "old", "new"), nil, nil, nil, nil)
+
+	set := jwtService.GetJWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("published %d keys, want 2", len(set.Keys))
+	}
+}
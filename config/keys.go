@@ -0,0 +1,71 @@
+package config
+
+import (
+	"log"
+	"os"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/utils/keyring"
+	_ "github.com/joho/godotenv/autoload"
+)
+
+// well known on purpose, so anything encrypted with it is worthless outside development
+const developmentAESKey = "de09583ac864be98a9c8fe9587c92ee2f1220bb58067bb280cd83def3c4fcb1b"
+
+const developmentKeyID = "development"
+
+// the AES key only encrypts data at rest and in links, it has nothing to do with tokens
+func SetUpAESKey() string {
+	key := os.Getenv("AES_KEY")
+	if key == "" || key == developmentAESKey {
+		if os.Getenv("ENV") == constants.ENUM_RUN_PRODUCTION {
+			log.Fatal("AES_KEY must be set to a generated key in production")
+		}
+
+		log.Println("AES_KEY not set, using the development key")
+		key = developmentAESKey
+	}
+
+	return key
+}
+
+// signing keys are read from the PEM files in JWT_KEYS_DIR. Outside production a
+// throwaway key is generated when none is configured, so tokens do not survive a restart.
+func SetUpJWTKeyring() *keyring.Keyring {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		if os.Getenv("ENV") == constants.ENUM_RUN_PRODUCTION {
+			log.Fatal("JWT_KEYS_DIR must be set in production")
+		}
+
+		log.Println("JWT_KEYS_DIR not set, generating a development signing key")
+		ring := keyring.New()
+		if err := ring.Generate(developmentKeyID); err != nil {
+			log.Fatalf("error generating signing key: %v", err)
+		}
+		if err := ring.SetActive(developmentKeyID); err != nil {
+			log.Fatalf("error generating signing key: %v", err)
+		}
+		return ring
+	}
+
+	ring, err := keyring.LoadDir(dir)
+	if err != nil {
+		log.Fatalf("error loading signing keys: %v", err)
+	}
+
+	// the active key can be left out as long as there is only one to choose from
+	active := os.Getenv("JWT_ACTIVE_KEY_ID")
+	if ids := ring.SigningIDs(); active == "" {
+		if len(ids) != 1 {
+			log.Fatalf("JWT_ACTIVE_KEY_ID must name one of the signing keys %v", ids)
+		}
+		active = ids[0]
+	}
+
+	if err := ring.SetActive(active); err != nil {
+		log.Fatalf("error selecting signing key: %v", err)
+	}
+
+	return ring
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+)
+
+// roles that can not sign in without two-factor, comma separated. An empty
+// value makes two-factor optional for everyone, unset keeps it for admins.
+func SetUpMFARequiredRoles() []string {
+	value, ok := os.LookupEnv("MFA_REQUIRED_ROLES")
+	if !ok {
+		return []string{constants.ENUM_ROLE_ADMIN}
+	}
+
+	var roles []string
+	for _, role := range strings.Split(value, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
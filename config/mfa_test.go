@@ -0,0 +1,36 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+)
+
+func TestSetUpMFARequiredRoles(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *string
+		want  []string
+	}{
+		{"unset keeps admins", nil, []string{constants.ENUM_ROLE_ADMIN}},
+		{"empty disables", new(string), nil},
+		{"list", func() *string { v := " admin, staff ,"; return &v }(), []string{"admin", "staff"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("MFA_REQUIRED_ROLES", "")
+			if tt.value == nil {
+				os.Unsetenv("MFA_REQUIRED_ROLES")
+			} else {
+				os.Setenv("MFA_REQUIRED_ROLES", *tt.value)
+			}
+
+			if got := SetUpMFARequiredRoles(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"os"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/utils/oidc"
+	_ "github.com/joho/godotenv/autoload"
+)
+
+// only providers with credentials are enabled. Outside production the
+// issuer can be pointed at a local mock server to try the flow offline.
+func SetUpOIDCProviders() map[string]oidc.Provider {
+	providers := make(map[string]oidc.Provider)
+
+	if clientID := os.Getenv("GOOGLE_CLIENT_ID"); clientID != "" {
+		issuer := constants.OIDC_GOOGLE_ISSUER
+		if override := os.Getenv("GOOGLE_OIDC_ISSUER"); override != "" && os.Getenv("ENV") != constants.ENUM_RUN_PRODUCTION {
+			issuer = override
+		}
+
+		providers[constants.ENUM_OIDC_PROVIDER_GOOGLE] = oidc.NewProvider(oidc.Config{
+			Name:         constants.ENUM_OIDC_PROVIDER_GOOGLE,
+			Issuer:       issuer,
+			ClientID:     clientID,
+			ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
+		})
+	}
+
+	return providers
+}
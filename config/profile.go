@@ -0,0 +1,23 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+)
+
+// tickets keep their own copy of the profile by default, so editing the
+// profile leaves past tickets as they were. Off makes tickets follow the profile.
+func SetUpTicketProfileSnapshot() bool {
+	snapshot, ok := os.LookupEnv("TICKET_PROFILE_SNAPSHOT")
+	if !ok {
+		return true
+	}
+
+	enabled, err := strconv.ParseBool(snapshot)
+	if err != nil {
+		log.Fatalf("error parsing TICKET_PROFILE_SNAPSHOT: %v", err)
+	}
+
+	return enabled
+}
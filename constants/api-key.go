@@ -0,0 +1,14 @@
+package constants
+
+import "time"
+
+const (
+	API_KEY_HEADER = "X-API-Key"
+
+	// makes a leaked key easy to recognise, the prefix length is what listings show
+	API_KEY_PREFIX        = "tedx_"
+	API_KEY_PREFIX_LENGTH = 12
+
+	// scanners call in on every ticket, the last use only needs to be roughly right
+	API_KEY_LAST_USED_INTERVAL = time.Minute
+)
@@ -0,0 +1,17 @@
+package constants
+
+const (
+	AUDIT_TARGET_USER    = "user"
+	AUDIT_TARGET_LOCKOUT = "lockout"
+	AUDIT_TARGET_API_KEY = "api_key"
+
+	ENUM_AUDIT_USER_ROLE_CHANGE  = "user.role_change"
+	ENUM_AUDIT_USER_SUSPEND      = "user.suspend"
+	ENUM_AUDIT_USER_REACTIVATE   = "user.reactivate"
+	ENUM_AUDIT_USER_DELETE       = "user.delete"
+	ENUM_AUDIT_USER_FORCE_VERIFY = "user.force_verify"
+	ENUM_AUDIT_USER_FORCE_RESET  = "user.force_reset_password"
+	ENUM_AUDIT_LOCKOUT_CLEAR     = "lockout.clear"
+	ENUM_AUDIT_API_KEY_CREATE    = "api_key.create"
+	ENUM_AUDIT_API_KEY_REVOKE    = "api_key.revoke"
+)
@@ -14,11 +14,44 @@ const (
 	ENUM_PAGINATION_LIMIT = 10
 	ENUM_PAGINATION_PAGE  = 1
 
+	EXPORT_BATCH_SIZE = 500
+
 	CTX_KEY_TOKEN     = "TOKEN"
+	CTX_KEY_TOKEN_ID  = "token_id"
 	CTX_KEY_USER_ID   = "user_id"
 	CTX_KEY_ROLE_NAME = "role"
-
-	JWT_EXPIRE_TIME_IN_MINUTES = 120
+	CTX_KEY_ROLE_ID   = "role_id"
+
+	CTX_KEY_API_KEY_ID          = "api_key_id"
+	CTX_KEY_API_KEY_PERMISSIONS = "api_key_permissions"
+	CTX_KEY_API_KEY_EVENTS      = "api_key_events"
+
+	// access tokens are kept short since clients can silently refresh them
+	JWT_EXPIRE_TIME_IN_MINUTES = 15
+
+	// how long a suspension or role change made on another instance can go unnoticed
+	ACCOUNT_CACHE_TTL                = 30 * time.Second
+	REFRESH_TOKEN_EXPIRE_TIME_IN_DAY = 30
+	TOKEN_PURGE_INTERVAL             = time.Hour
+
+	ENUM_OIDC_PROVIDER_GOOGLE = "google"
+	OIDC_GOOGLE_ISSUER        = "https://accounts.google.com"
+	OIDC_STATE_EXPIRE_TIME    = 10 * time.Minute
+	OIDC_STATE_COOKIE         = "oidc_state"
+
+	ENUM_TOKEN_PURPOSE_VERIFY_EMAIL   = "verify_email"
+	ENUM_TOKEN_PURPOSE_RESET_PASSWORD = "reset_password"
+	ENUM_TOKEN_PURPOSE_CHANGE_EMAIL   = "change_email"
+	ENUM_TOKEN_PURPOSE_MFA_LOGIN      = "mfa_login"
+	ENUM_TOKEN_PURPOSE_DELETE_ACCOUNT = "delete_account"
+	USER_TOKEN_EXPIRE_TIME            = 24 * time.Hour
+
+	// how long work started by a request may run after the response was sent
+	BACKGROUND_TASK_TIMEOUT = time.Minute
+
+	// what is left of an erased account, the domain can never receive mail
+	ANONYMISED_NAME         = "Deleted User"
+	ANONYMISED_EMAIL_DOMAIN = "@deleted.invalid"
 
 	WSOCKET_AUTH_TIME_LIMIT        = time.Second * time.Duration(10)
 	WSOCKET_TRANSACTION_TIME_LIMIT = (time.Minute * time.Duration(3)) + (time.Second * time.Duration(20))
@@ -0,0 +1,30 @@
+package constants
+
+type AgeBracket struct {
+	Label string
+	Min   int
+	// exclusive, zero means the bracket has no upper bound
+	Max int
+}
+
+const (
+	// any group smaller than this is folded into "other" so nobody can be singled out
+	DEMOGRAPHIC_MIN_CELL_SIZE = 5
+	DEMOGRAPHIC_TOP_N         = 10
+
+	DEMOGRAPHIC_LABEL_OTHER   = "other"
+	DEMOGRAPHIC_LABEL_UNKNOWN = "unknown"
+
+	ENUM_DEMOGRAPHIC_AGE        = "age"
+	ENUM_DEMOGRAPHIC_INSTITUTE  = "institute"
+	ENUM_DEMOGRAPHIC_DEPARTMENT = "department"
+	ENUM_DEMOGRAPHIC_BATCH      = "batch"
+)
+
+var DEMOGRAPHIC_AGE_BRACKETS = []AgeBracket{
+	{Label: "under 18", Min: 0, Max: 18},
+	{Label: "18-24", Min: 18, Max: 25},
+	{Label: "25-34", Min: 25, Max: 35},
+	{Label: "35-44", Min: 35, Max: 45},
+	{Label: "45+", Min: 45, Max: 0},
+}
@@ -3,6 +3,7 @@ package constants
 const (
 	PE2Name                     = "Pre-event 2"
 	PE3Name                     = "Pre-event 3"
+	MainEventName               = "TEDxITS 2024 Main Event"
 	MainEventEarlyBirdWithMerch = "Early Bird with merchandise bundle"
 	MainEventPreSaleWithMerch   = "Pre Sale with merchandise bundle"
 	MainEventNormalWithMerch    = "Normal with merchandise bundle"
@@ -18,6 +19,16 @@ const (
 	MainEventEarlyBirdNoMerchCapacity   = 13
 	MainEventPreSaleNoMerchCapacity     = 57
 	MainEventNormalNoMerchCapacity      = 53
+
+	// kept apart from the sale capacity so guests never take a paid seat.
+	// Pre-event 2 has none, its seats are given out through RSVPs.
+	MainEventEarlyBirdWithMerchCompCapacity = 5
+	MainEventPreSaleWithMerchCompCapacity   = 10
+	MainEventNormalWithMerchCompCapacity    = 20
+	MainEventEarlyBirdNoMerchCompCapacity   = 5
+	MainEventPreSaleNoMerchCompCapacity     = 10
+	MainEventNormalNoMerchCompCapacity      = 30
+	PreEvent3CompCapacity                   = 20
 )
 
 const (
@@ -30,3 +41,28 @@ const (
 	PreEvent2ID                   = "7de24efe-0aec-469a-bf0c-8fa8cae3ff3f"
 	PreEvent3ID                   = "d436ff9d-5956-48a5-acb1-1e96d94fc3c4"
 )
+
+const (
+	ENUM_GUEST_SPEAKER       = "speaker"
+	ENUM_GUEST_SPONSOR       = "sponsor"
+	ENUM_GUEST_MEDIA_PARTNER = "media_partner"
+	ENUM_GUEST_COMMITTEE     = "committee"
+)
+
+// every ticket tier of the main event shares one schedule
+var MainEventIDs = []string{
+	MainEventEarlyBirdNoMerchID,
+	MainEventPreSaleNoMerchID,
+	MainEventNormalNoMerchID,
+	MainEventEarlyBirdWithMerchID,
+	MainEventPreSaleWithMerchID,
+	MainEventNormalWithMerchID,
+}
+
+const (
+	ENUM_SESSION_TALK        = "talk"
+	ENUM_SESSION_PERFORMANCE = "performance"
+	ENUM_SESSION_CEREMONY    = "ceremony"
+	ENUM_SESSION_BREAK       = "break"
+	ENUM_SESSION_NETWORKING  = "networking"
+)
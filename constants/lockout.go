@@ -0,0 +1,20 @@
+package constants
+
+import "time"
+
+const (
+	ENUM_LOCKOUT_ACCOUNT = "account"
+	ENUM_LOCKOUT_IP      = "ip"
+
+	// failures within the window count towards a lock, older ones are forgotten
+	LOGIN_FAILURE_WINDOW       = 15 * time.Minute
+	LOGIN_MAX_ACCOUNT_FAILURES = 5
+	LOGIN_MAX_IP_FAILURES      = 20
+
+	// every consecutive lock doubles, starting at the base and capped at the max
+	LOGIN_LOCKOUT_BASE = 5 * time.Minute
+	LOGIN_LOCKOUT_MAX  = 24 * time.Hour
+
+	// a key that stayed clean this long after its last lock starts over at the base
+	LOGIN_LOCKOUT_RESET = 7 * 24 * time.Hour
+)
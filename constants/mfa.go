@@ -0,0 +1,11 @@
+package constants
+
+import "time"
+
+const (
+	MFA_ISSUER              = "TEDxITS"
+	MFA_RECOVERY_CODE_COUNT = 10
+
+	// the login challenge only has to survive opening the authenticator app
+	MFA_TOKEN_EXPIRE_TIME = 5 * time.Minute
+)
@@ -0,0 +1,40 @@
+package constants
+
+const (
+	PERMISSION_TICKET_READ     = "ticket.read"
+	PERMISSION_TICKET_CHECKIN  = "ticket.checkin"
+	PERMISSION_PAYMENT_CONFIRM = "payment.confirm"
+	PERMISSION_USER_READ       = "user.read"
+	PERMISSION_USER_MANAGE     = "user.manage"
+	PERMISSION_LINK_MANAGE     = "link.manage"
+	PERMISSION_ROLE_MANAGE     = "role.manage"
+	PERMISSION_API_KEY_MANAGE  = "api_key.manage"
+	PERMISSION_PE2_REVIEW      = "pe2.review"
+)
+
+// every permission a role can be granted, admin implicitly holds all of them
+var PERMISSIONS = []string{
+	PERMISSION_TICKET_READ,
+	PERMISSION_TICKET_CHECKIN,
+	PERMISSION_PAYMENT_CONFIRM,
+	PERMISSION_USER_READ,
+	PERMISSION_USER_MANAGE,
+	PERMISSION_LINK_MANAGE,
+	PERMISSION_ROLE_MANAGE,
+	PERMISSION_API_KEY_MANAGE,
+	PERMISSION_PE2_REVIEW,
+}
+
+// reviewers score essays blind, so their role can not also read the RSVPs that name the applicants
+var PE2_REVIEW_EXCLUDED_PERMISSIONS = []string{
+	PERMISSION_TICKET_READ,
+}
+
+// the subset an api key can be granted, managing accounts and access stays with people
+var API_KEY_PERMISSIONS = []string{
+	PERMISSION_TICKET_READ,
+	PERMISSION_TICKET_CHECKIN,
+	PERMISSION_PAYMENT_CONFIRM,
+	PERMISSION_USER_READ,
+	PERMISSION_LINK_MANAGE,
+}
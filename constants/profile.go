@@ -0,0 +1,9 @@
+package constants
+
+const (
+	PROFILE_HANDPHONE_MIN_DIGITS = 8
+	PROFILE_HANDPHONE_MAX_DIGITS = 15
+	PROFILE_STUDENT_ID_MAX       = 20
+	PROFILE_MIN_BIRTH_YEAR       = 1900
+	PROFILE_MIN_BATCH_YEAR       = 1960
+)
@@ -0,0 +1,25 @@
+package constants
+
+import "time"
+
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Period time.Duration
+}
+
+var (
+	// endpoints that send emails, counted per address so one inbox can not be flooded
+	RATE_LIMIT_EMAIL = RateLimitPolicy{Name: "email", Limit: 3, Period: time.Hour}
+
+	// the same endpoints per ip, so rotating addresses does not get around the limit
+	RATE_LIMIT_EMAIL_IP = RateLimitPolicy{Name: "email-ip", Limit: 10, Period: time.Hour}
+
+	RATE_LIMIT_REGISTER    = RateLimitPolicy{Name: "register", Limit: 5, Period: time.Hour}
+	RATE_LIMIT_RSVP        = RateLimitPolicy{Name: "rsvp", Limit: 5, Period: time.Hour}
+	RATE_LIMIT_LINK_CREATE = RateLimitPolicy{Name: "link-create", Limit: 20, Period: time.Hour}
+	RATE_LIMIT_DATA_EXPORT = RateLimitPolicy{Name: "data-export", Limit: 3, Period: time.Hour}
+
+	// six digit codes are only safe as long as guessing them stays slow
+	RATE_LIMIT_MFA = RateLimitPolicy{Name: "mfa", Limit: 10, Period: 15 * time.Minute}
+)
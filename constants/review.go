@@ -0,0 +1,44 @@
+package constants
+
+type RubricCriterion struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	MaxScore    int    `json:"max_score"`
+}
+
+const (
+	ENUM_PE2_STATUS_PENDING    = "pending"
+	ENUM_PE2_STATUS_ACCEPTED   = "accepted"
+	ENUM_PE2_STATUS_REJECTED   = "rejected"
+	ENUM_PE2_STATUS_WAITLISTED = "waitlisted"
+	ENUM_PE2_STATUS_WITHDRAWN  = "withdrawn"
+)
+
+// rubric used by reviewers to score pre-event 2 essays
+var PE2_ESSAY_RUBRIC = []RubricCriterion{
+	{
+		Key:         "relevance",
+		Name:        "Relevance",
+		Description: "How well the essay answers the question about Indonesia in the next 10 years",
+		MaxScore:    10,
+	},
+	{
+		Key:         "argument",
+		Name:        "Argument",
+		Description: "Clarity of reasoning and how well the opinion is supported",
+		MaxScore:    10,
+	},
+	{
+		Key:         "originality",
+		Name:        "Originality",
+		Description: "Fresh perspective and personal insight",
+		MaxScore:    10,
+	},
+	{
+		Key:         "language",
+		Name:        "Language",
+		Description: "Structure, grammar and readability",
+		MaxScore:    10,
+	},
+}
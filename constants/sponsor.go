@@ -0,0 +1,28 @@
+package constants
+
+const (
+	ENUM_SPONSOR_TIER_PLATINUM      = "platinum"
+	ENUM_SPONSOR_TIER_GOLD          = "gold"
+	ENUM_SPONSOR_TIER_MEDIA_PARTNER = "media_partner"
+
+	ENUM_SPONSOR_PLACEMENT_WEBSITE   = "website"
+	ENUM_SPONSOR_PLACEMENT_INSTAGRAM = "instagram"
+	ENUM_SPONSOR_PLACEMENT_EMAIL     = "email"
+	ENUM_SPONSOR_PLACEMENT_BOOKLET   = "booklet"
+	ENUM_SPONSOR_PLACEMENT_STAGE     = "stage"
+)
+
+// tiers in the order they are shown on the website
+var SPONSOR_TIERS = []string{
+	ENUM_SPONSOR_TIER_PLATINUM,
+	ENUM_SPONSOR_TIER_GOLD,
+	ENUM_SPONSOR_TIER_MEDIA_PARTNER,
+}
+
+var SPONSOR_PLACEMENTS = []string{
+	ENUM_SPONSOR_PLACEMENT_WEBSITE,
+	ENUM_SPONSOR_PLACEMENT_INSTAGRAM,
+	ENUM_SPONSOR_PLACEMENT_EMAIL,
+	ENUM_SPONSOR_PLACEMENT_BOOKLET,
+	ENUM_SPONSOR_PLACEMENT_STAGE,
+}
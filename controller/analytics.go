@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	AnalyticsController interface {
+		GetSalesRevenue(ctx *gin.Context)
+		GetSalesTimeline(ctx *gin.Context)
+		GetSellOut(ctx *gin.Context)
+		GetConversion(ctx *gin.Context)
+	}
+
+	analyticsController struct {
+		analyticsService service.AnalyticsService
+	}
+)
+
+func NewAnalyticsController(service service.AnalyticsService) AnalyticsController {
+	return &analyticsController{
+		analyticsService: service,
+	}
+}
+
+func (c *analyticsController) GetSalesRevenue(ctx *gin.Context) {
+	var req dto.AnalyticsQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.analyticsService.GetSalesRevenue(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_ANALYTICS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_ANALYTICS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *analyticsController) GetSalesTimeline(ctx *gin.Context) {
+	var req dto.AnalyticsQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.analyticsService.GetSalesTimeline(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_ANALYTICS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_ANALYTICS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *analyticsController) GetSellOut(ctx *gin.Context) {
+	var req dto.AnalyticsQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.analyticsService.GetSellOut(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_ANALYTICS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_ANALYTICS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *analyticsController) GetConversion(ctx *gin.Context) {
+	var req dto.AnalyticsQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.analyticsService.GetConversion(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_ANALYTICS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_ANALYTICS, result)
+	ctx.JSON(http.StatusOK, res)
+}
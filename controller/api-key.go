@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	APIKeyController interface {
+		GetAll(ctx *gin.Context)
+		Create(ctx *gin.Context)
+		Revoke(ctx *gin.Context)
+	}
+
+	apiKeyController struct {
+		apiKeyService service.APIKeyService
+	}
+)
+
+func NewAPIKeyController(service service.APIKeyService) APIKeyController {
+	return &apiKeyController{
+		apiKeyService: service,
+	}
+}
+
+func (c *apiKeyController) GetAll(ctx *gin.Context) {
+	result, err := c.apiKeyService.GetAll(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_API_KEY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_API_KEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *apiKeyController) Create(ctx *gin.Context) {
+	var req dto.APIKeyRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	result, err := c.apiKeyService.Create(ctx.Request.Context(), actorID, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_API_KEY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_API_KEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *apiKeyController) Revoke(ctx *gin.Context) {
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	if err := c.apiKeyService.Revoke(ctx.Request.Context(), actorID, ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REVOKE_API_KEY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REVOKE_API_KEY, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	CertificateController interface {
+		GetMyCertificates(ctx *gin.Context)
+		DownloadCertificate(ctx *gin.Context)
+		BulkGenerate(ctx *gin.Context)
+		VerifyCertificate(ctx *gin.Context)
+	}
+
+	certificateController struct {
+		certificateService service.CertificateService
+	}
+)
+
+func NewCertificateController(service service.CertificateService) CertificateController {
+	return &certificateController{
+		certificateService: service,
+	}
+}
+
+func (c *certificateController) GetMyCertificates(ctx *gin.Context) {
+	result, err := c.certificateService.GetMyCertificates(ctx.Request.Context(), ctx.GetString(constants.CTX_KEY_USER_ID))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CERTIFICATE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_CERTIFICATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *certificateController) DownloadCertificate(ctx *gin.Context) {
+	code := ctx.Param("code")
+	userID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	userRole := ctx.GetString(constants.CTX_KEY_ROLE_NAME)
+
+	file, err := c.certificateService.DownloadCertificate(ctx.Request.Context(), code, userID, userRole)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_CERTIFICATE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename="+code+".pdf")
+	ctx.Data(http.StatusOK, dto.ENUM_FILE_TYPE_PDF, file)
+}
+
+func (c *certificateController) BulkGenerate(ctx *gin.Context) {
+	var req dto.CertificateBulkRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	file, err := c.certificateService.BulkGenerate(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GENERATE_CERTIFICATE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename=certificates-"+req.EventID+".zip")
+	ctx.Data(http.StatusOK, dto.ENUM_FILE_TYPE_ZIP, file)
+}
+
+func (c *certificateController) VerifyCertificate(ctx *gin.Context) {
+	result, err := c.certificateService.VerifyCertificate(ctx.Request.Context(), ctx.Param("code"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_CERTIFICATE, err.Error(), nil)
+		ctx.JSON(http.StatusNotFound, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_VERIFY_CERTIFICATE, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ComplimentaryController interface {
+		IssueCompTicket(ctx *gin.Context)
+		ImportCompTickets(ctx *gin.Context)
+	}
+
+	complimentaryController struct {
+		complimentaryService service.ComplimentaryService
+	}
+)
+
+func NewComplimentaryController(service service.ComplimentaryService) ComplimentaryController {
+	return &complimentaryController{
+		complimentaryService: service,
+	}
+}
+
+func (c *complimentaryController) IssueCompTicket(ctx *gin.Context) {
+	var req dto.CompTicketRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.complimentaryService.IssueCompTicket(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ISSUE_COMP_TICKET, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ISSUE_COMP_TICKET, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *complimentaryController) ImportCompTickets(ctx *gin.Context) {
+	var req dto.CompTicketImportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.complimentaryService.ImportCompTickets(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_IMPORT_COMP_TICKET, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_IMPORT_COMP_TICKET, result)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	DemographicController interface {
+		GetDemographicReport(ctx *gin.Context)
+		ExportDemographicReport(ctx *gin.Context)
+	}
+
+	demographicController struct {
+		demographicService service.DemographicService
+	}
+)
+
+func NewDemographicController(service service.DemographicService) DemographicController {
+	return &demographicController{
+		demographicService: service,
+	}
+}
+
+func (c *demographicController) GetDemographicReport(ctx *gin.Context) {
+	var req dto.DemographicQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.demographicService.GetDemographicReport(ctx.Request.Context(), ctx.Param("event_id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DEMOGRAPHIC_REPORT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_DEMOGRAPHIC_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *demographicController) ExportDemographicReport(ctx *gin.Context) {
+	var req dto.DemographicQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	w := &exportResponseWriter{
+		ctx:      ctx,
+		filename: "demographics-" + time.Now().Format("20060102150405") + "." + utils.ENUM_EXPORT_FORMAT_CSV,
+		format:   utils.ENUM_EXPORT_FORMAT_CSV,
+	}
+
+	if err := c.demographicService.ExportDemographicReport(ctx.Request.Context(), ctx.Param("event_id"), req, w); err != nil {
+		if w.started {
+			ctx.Abort()
+			return
+		}
+
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DEMOGRAPHIC_REPORT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if !w.started {
+		w.start()
+	}
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ExportController interface {
+		ExportMainEvent(ctx *gin.Context)
+		ExportPreEvent3(ctx *gin.Context)
+		ExportPE2RSVP(ctx *gin.Context)
+		ExportUser(ctx *gin.Context)
+	}
+
+	exportController struct {
+		exportService service.ExportService
+	}
+
+	// defers the download headers until the first byte, so a request that is
+	// rejected before streaming starts can still be answered with JSON
+	exportResponseWriter struct {
+		ctx      *gin.Context
+		filename string
+		format   string
+		started  bool
+	}
+)
+
+func NewExportController(service service.ExportService) ExportController {
+	return &exportController{
+		exportService: service,
+	}
+}
+
+func (c *exportController) ExportMainEvent(ctx *gin.Context) {
+	c.export(ctx, "main-event", c.exportService.ExportMainEvent)
+}
+
+func (c *exportController) ExportPreEvent3(ctx *gin.Context) {
+	c.export(ctx, "pre-event-3", c.exportService.ExportPreEvent3)
+}
+
+func (c *exportController) ExportPE2RSVP(ctx *gin.Context) {
+	c.export(ctx, "pre-event-2", c.exportService.ExportPE2RSVP)
+}
+
+func (c *exportController) ExportUser(ctx *gin.Context) {
+	c.export(ctx, "users", c.exportService.ExportUser)
+}
+
+func (c *exportController) export(ctx *gin.Context, name string, fn func(context.Context, dto.ExportQuery, io.Writer) error) {
+	var req dto.ExportQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if req.Format == "" {
+		req.Format = utils.ENUM_EXPORT_FORMAT_CSV
+	}
+
+	w := &exportResponseWriter{
+		ctx:      ctx,
+		filename: name + "-" + time.Now().Format("20060102150405") + "." + req.Format,
+		format:   req.Format,
+	}
+
+	if err := fn(ctx.Request.Context(), req, w); err != nil {
+		if w.started {
+			// the file is already partially sent, all we can do is cut it off
+			ctx.Abort()
+			return
+		}
+
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_EXPORT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if !w.started {
+		w.start()
+	}
+}
+
+func (w *exportResponseWriter) start() {
+	contentType := utils.EXPORT_CONTENT_TYPE_CSV
+	if w.format == utils.ENUM_EXPORT_FORMAT_XLSX {
+		contentType = utils.EXPORT_CONTENT_TYPE_XLSX
+	}
+
+	w.ctx.Header("Content-Type", contentType)
+	w.ctx.Header("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+	w.ctx.Status(http.StatusOK)
+	w.started = true
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	if !w.started {
+		w.start()
+	}
+
+	n, err := w.ctx.Writer.Write(p)
+	w.ctx.Writer.Flush()
+	return n, err
+}
@@ -60,10 +60,15 @@ func (c *mainEventController) ConfirmPayment(ctx *gin.Context) {
 		return
 	}
 
-	err := c.mainEventService.ConfirmPayment(ctx.Request.Context(), req)
+	err := c.mainEventService.ConfirmPayment(ctx.Request.Context(), req, ctx.GetStringSlice(constants.CTX_KEY_API_KEY_EVENTS))
 	if err != nil {
+		status := http.StatusBadRequest
+		if err == dto.ErrAPIKeyEventNotAllowed {
+			status = http.StatusForbidden
+		}
+
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CONFIRM_PAYMENT, err.Error(), nil)
-		ctx.JSON(http.StatusBadRequest, res)
+		ctx.JSON(status, res)
 		return
 	}
 
@@ -79,10 +84,15 @@ func (c *mainEventController) CheckIn(ctx *gin.Context) {
 		return
 	}
 
-	err := c.mainEventService.CheckIn(ctx.Request.Context(), req)
+	err := c.mainEventService.CheckIn(ctx.Request.Context(), req, ctx.GetStringSlice(constants.CTX_KEY_API_KEY_EVENTS))
 	if err != nil {
+		status := http.StatusBadRequest
+		if err == dto.ErrAPIKeyEventNotAllowed {
+			status = http.StatusForbidden
+		}
+
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CHECK_IN, err.Error(), nil)
-		ctx.JSON(http.StatusBadRequest, res)
+		ctx.JSON(status, res)
 		return
 	}
 
@@ -110,7 +120,7 @@ func (c *mainEventController) GetMainEventPaginated(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.mainEventService.GetMainEventPaginated(ctx.Request.Context(), req)
+	result, err := c.mainEventService.GetMainEventPaginated(ctx.Request.Context(), req, ctx.GetStringSlice(constants.CTX_KEY_API_KEY_EVENTS))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TICKET, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -129,10 +139,15 @@ func (c *mainEventController) GetMainEventPaginated(ctx *gin.Context) {
 func (c *mainEventController) GetMainEventDetail(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	result, err := c.mainEventService.GetMainEventDetail(ctx.Request.Context(), id)
+	result, err := c.mainEventService.GetMainEventDetail(ctx.Request.Context(), id, ctx.GetStringSlice(constants.CTX_KEY_API_KEY_EVENTS))
 	if err != nil {
+		status := http.StatusBadRequest
+		if err == dto.ErrAPIKeyEventNotAllowed {
+			status = http.StatusForbidden
+		}
+
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TICKET, err.Error(), nil)
-		ctx.JSON(http.StatusBadRequest, res)
+		ctx.JSON(status, res)
 		return
 	}
 
@@ -141,7 +156,7 @@ func (c *mainEventController) GetMainEventDetail(ctx *gin.Context) {
 }
 
 func (c *mainEventController) GetMainEventCounter(ctx *gin.Context) {
-	result, err := c.mainEventService.GetMainEventCounter(ctx.Request.Context())
+	result, err := c.mainEventService.GetMainEventCounter(ctx.Request.Context(), ctx.GetStringSlice(constants.CTX_KEY_API_KEY_EVENTS))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TICKET, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
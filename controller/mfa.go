@@ -0,0 +1,241 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	MFAController interface {
+		Verify(ctx *gin.Context)
+		Setup(ctx *gin.Context)
+		ConfirmSetup(ctx *gin.Context)
+		GetStatus(ctx *gin.Context)
+		Enroll(ctx *gin.Context)
+		Activate(ctx *gin.Context)
+		Disable(ctx *gin.Context)
+		RegenerateRecoveryCodes(ctx *gin.Context)
+	}
+
+	mfaController struct {
+		mfaService  service.MFAService
+		authService service.AuthService
+	}
+)
+
+func NewMFAController(ms service.MFAService, as service.AuthService) MFAController {
+	return &mfaController{
+		mfaService:  ms,
+		authService: as,
+	}
+}
+
+func (c *mfaController) Verify(ctx *gin.Context) {
+	var req dto.MFAVerifyRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	user, err := c.mfaService.VerifyLogin(ctx.Request.Context(), req, ctx.ClientIP())
+	if err != nil {
+		status := http.StatusUnauthorized
+		if err == dto.ErrLoginLocked {
+			status = http.StatusTooManyRequests
+		}
+
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_MFA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	result, err := c.authService.IssueTokens(ctx.Request.Context(), user, ctx.Request.UserAgent())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_MFA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGIN, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *mfaController) Setup(ctx *gin.Context) {
+	var req dto.MFATokenRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.mfaService.SetupLogin(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ENROLL_MFA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ENROLL_MFA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// finishes the login as well, the recovery codes are returned next to the tokens
+func (c *mfaController) ConfirmSetup(ctx *gin.Context) {
+	var req dto.MFAVerifyRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	user, codes, err := c.mfaService.ConfirmSetupLogin(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ACTIVATE_MFA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+		return
+	}
+
+	result, err := c.authService.IssueTokens(ctx.Request.Context(), user, ctx.Request.UserAgent())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ACTIVATE_MFA, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ACTIVATE_MFA, struct {
+		entity.Authorization
+		dto.MFARecoveryCodesResponse
+	}{result, codes})
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *mfaController) GetStatus(ctx *gin.Context) {
+	userId := ctx.MustGet(constants.CTX_KEY_USER_ID).(string)
+
+	result, err := c.mfaService.GetStatus(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_MFA_STATUS, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_MFA_STATUS, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *mfaController) Enroll(ctx *gin.Context) {
+	userId := ctx.MustGet(constants.CTX_KEY_USER_ID).(string)
+
+	result, err := c.mfaService.Enroll(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ENROLL_MFA, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ENROLL_MFA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *mfaController) Activate(ctx *gin.Context) {
+	var req dto.MFACodeRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	userId := ctx.MustGet(constants.CTX_KEY_USER_ID).(string)
+	result, err := c.mfaService.Activate(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ACTIVATE_MFA, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ACTIVATE_MFA, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *mfaController) Disable(ctx *gin.Context) {
+	var req dto.MFACodeRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	userId := ctx.MustGet(constants.CTX_KEY_USER_ID).(string)
+	if err := c.mfaService.Disable(ctx.Request.Context(), userId, req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DISABLE_MFA, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DISABLE_MFA, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *mfaController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	var req dto.MFACodeRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	userId := ctx.MustGet(constants.CTX_KEY_USER_ID).(string)
+	result, err := c.mfaService.RegenerateRecoveryCodes(ctx.Request.Context(), userId, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GENERATE_RECOVERY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GENERATE_RECOVERY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+// every way of signing in ends here, an account behind two-factor
+// gets a challenge to answer instead of tokens
+func startSession(ctx *gin.Context, authService service.AuthService, mfaService service.MFAService, user entity.User, failedMessage string) {
+	challenge, err := mfaService.Challenge(ctx.Request.Context(), user)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_MFA_CHALLENGE, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusInternalServerError, res)
+		return
+	}
+
+	if challenge.MFAToken != "" {
+		message := dto.MESSAGE_SUCCESS_MFA_REQUIRED
+		if challenge.EnrollmentRequired {
+			message = dto.MESSAGE_SUCCESS_MFA_SETUP_NEEDED
+		}
+
+		res := utils.BuildResponseSuccess(message, challenge)
+		ctx.JSON(http.StatusOK, res)
+		return
+	}
+
+	result, err := authService.IssueTokens(ctx.Request.Context(), user, ctx.Request.UserAgent())
+	if err != nil {
+		status := http.StatusInternalServerError
+		if err == dto.ErrAccountSuspended {
+			status = http.StatusUnauthorized
+		}
+
+		res := utils.BuildResponseFailed(failedMessage, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGIN, result)
+	ctx.JSON(http.StatusOK, res)
+}
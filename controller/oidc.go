@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	OIDCController interface {
+		GetAuthURL(ctx *gin.Context)
+		Callback(ctx *gin.Context)
+	}
+
+	oidcController struct {
+		oidcService service.OIDCService
+		authService service.AuthService
+		mfaService  service.MFAService
+	}
+)
+
+func NewOIDCController(os service.OIDCService, as service.AuthService, ms service.MFAService) OIDCController {
+	return &oidcController{
+		oidcService: os,
+		authService: as,
+		mfaService:  ms,
+	}
+}
+
+func (c *oidcController) GetAuthURL(ctx *gin.Context) {
+	result, err := c.oidcService.GetAuthURL(ctx.Request.Context(), ctx.Param("provider"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_OIDC_URL, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	// the frontend lives on another site, so the cookie has to be SameSite=None
+	ctx.SetSameSite(http.SameSiteNoneMode)
+	ctx.SetCookie(constants.OIDC_STATE_COOKIE, result.Binding, int(constants.OIDC_STATE_EXPIRE_TIME.Seconds()), "/api/user/oauth", "", true, true)
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_OIDC_URL, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *oidcController) Callback(ctx *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	// a state is good for one attempt, the cookie goes either way
+	binding, _ := ctx.Cookie(constants.OIDC_STATE_COOKIE)
+	ctx.SetSameSite(http.SameSiteNoneMode)
+	ctx.SetCookie(constants.OIDC_STATE_COOKIE, "", -1, "/api/user/oauth", "", true, true)
+
+	user, err := c.oidcService.Callback(ctx.Request.Context(), ctx.Param("provider"), req, binding)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_OIDC_LOGIN, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, res)
+		return
+	}
+
+	startSession(ctx, c.authService, c.mfaService, user, dto.MESSAGE_FAILED_OIDC_LOGIN)
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	PersonalDataController interface {
+		Export(ctx *gin.Context)
+		RequestDeletion(ctx *gin.Context)
+		ConfirmDeletion(ctx *gin.Context)
+	}
+
+	personalDataController struct {
+		personalDataService service.PersonalDataService
+	}
+)
+
+func NewPersonalDataController(pds service.PersonalDataService) PersonalDataController {
+	return &personalDataController{
+		personalDataService: pds,
+	}
+}
+
+func (c *personalDataController) Export(ctx *gin.Context) {
+	userId := ctx.MustGet(constants.CTX_KEY_USER_ID).(string)
+
+	archive, err := c.personalDataService.ExportPersonalData(ctx.Request.Context(), userId)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_EXPORT_PERSONAL_DATA, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Header("Content-Disposition", "attachment; filename=\""+dto.PERSONAL_DATA_EXPORT_FILENAME+"\"")
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/zip", archive)
+}
+
+func (c *personalDataController) RequestDeletion(ctx *gin.Context) {
+	userId := ctx.MustGet(constants.CTX_KEY_USER_ID).(string)
+
+	if err := c.personalDataService.RequestAccountDeletion(ctx.Request.Context(), userId); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REQUEST_DELETION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REQUEST_DELETION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *personalDataController) ConfirmDeletion(ctx *gin.Context) {
+	var req dto.AccountDeletionConfirmRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	if err := c.personalDataService.ConfirmAccountDeletion(ctx.Request.Context(), req.Token); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CONFIRM_DELETION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CONFIRM_DELETION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	PreEvent2ReviewController interface {
+		GetRubric(ctx *gin.Context)
+		AssignReviewers(ctx *gin.Context)
+		GetMyReviews(ctx *gin.Context)
+		SubmitReview(ctx *gin.Context)
+		GetRSVPReviews(ctx *gin.Context)
+		GetReviewSummary(ctx *gin.Context)
+		DecideRSVP(ctx *gin.Context)
+		GetEssaySimilarities(ctx *gin.Context)
+	}
+
+	preEvent2ReviewController struct {
+		reviewService service.PreEvent2ReviewService
+	}
+)
+
+func NewPreEvent2ReviewController(service service.PreEvent2ReviewService) PreEvent2ReviewController {
+	return &preEvent2ReviewController{
+		reviewService: service,
+	}
+}
+
+func (c *preEvent2ReviewController) GetRubric(ctx *gin.Context) {
+	result := c.reviewService.GetRubric(ctx.Request.Context())
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REVIEW, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2ReviewController) AssignReviewers(ctx *gin.Context) {
+	var req dto.PE2ReviewAssignRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.reviewService.AssignReviewers(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_ASSIGN_REVIEWER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_ASSIGN_REVIEWER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2ReviewController) GetMyReviews(ctx *gin.Context) {
+	result, err := c.reviewService.GetMyReviews(ctx.Request.Context(), ctx.GetString(constants.CTX_KEY_USER_ID))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REVIEW, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REVIEW, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2ReviewController) SubmitReview(ctx *gin.Context) {
+	var req dto.PE2ReviewSubmitRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.reviewService.SubmitReview(ctx.Request.Context(), ctx.Param("id"), ctx.GetString(constants.CTX_KEY_USER_ID), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SUBMIT_REVIEW, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SUBMIT_REVIEW, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2ReviewController) GetRSVPReviews(ctx *gin.Context) {
+	result, err := c.reviewService.GetRSVPReviews(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REVIEW, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_REVIEW, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2ReviewController) GetReviewSummary(ctx *gin.Context) {
+	var req dto.PaginationQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.reviewService.GetReviewSummary(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_REVIEW, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.Response{
+		Status:  true,
+		Message: dto.MESSAGE_SUCCESS_GET_REVIEW,
+		Data:    result.Data,
+		Meta:    result.PaginationMetadata,
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2ReviewController) DecideRSVP(ctx *gin.Context) {
+	var req dto.PE2RSVPDecisionRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.reviewService.DecideRSVP(ctx.Request.Context(), ctx.Param("id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DECIDE_RSVP, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DECIDE_RSVP, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2ReviewController) GetEssaySimilarities(ctx *gin.Context) {
+	var req dto.PE2EssaySimilarityQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.reviewService.GetEssaySimilarities(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SIMILARITY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SIMILARITY, result)
+	ctx.JSON(http.StatusOK, res)
+}
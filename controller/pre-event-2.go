@@ -3,6 +3,7 @@ package controller
 import (
 	"net/http"
 
+	"github.com/TEDxITS/website-backend-2024/constants"
 	"github.com/TEDxITS/website-backend-2024/dto"
 	"github.com/TEDxITS/website-backend-2024/service"
 	"github.com/TEDxITS/website-backend-2024/utils"
@@ -17,6 +18,11 @@ type (
 		GetPE2RSVPDetail(ctx *gin.Context)
 		GetPE2RSVPCounter(ctx *gin.Context)
 		GetPE2RSVPStatus(ctx *gin.Context)
+		CheckInPE2RSVP(ctx *gin.Context)
+		GetMyPE2RSVP(ctx *gin.Context)
+		UpdateMyPE2RSVP(ctx *gin.Context)
+		WithdrawMyPE2RSVP(ctx *gin.Context)
+		ResendPE2RSVPLink(ctx *gin.Context)
 	}
 
 	preEvent2Controller struct {
@@ -38,7 +44,7 @@ func (c *preEvent2Controller) CreatePE2RSVP(ctx *gin.Context) {
 		return
 	}
 
-	result, err := c.preevent2Service.CreatePE2RSVP(ctx.Request.Context(), req)
+	result, err := c.preevent2Service.CreatePE2RSVP(ctx.Request.Context(), req, ctx.GetString(constants.CTX_KEY_USER_ID))
 	if err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_TICKET, err.Error(), nil)
 		ctx.JSON(http.StatusBadRequest, res)
@@ -116,3 +122,85 @@ func (c *preEvent2Controller) GetPE2RSVPStatus(ctx *gin.Context) {
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_TICKET, status)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *preEvent2Controller) CheckInPE2RSVP(ctx *gin.Context) {
+	var req dto.PE2RSVPCheckInRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	err := c.preevent2Service.CheckInPE2RSVP(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CHECK_IN, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CHECK_IN, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2Controller) GetMyPE2RSVP(ctx *gin.Context) {
+	token := ctx.Query("token")
+
+	result, err := c.preevent2Service.GetMyPE2RSVP(ctx.Request.Context(), token)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_TICKET, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_TICKET, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2Controller) UpdateMyPE2RSVP(ctx *gin.Context) {
+	token := ctx.Query("token")
+
+	var req dto.PE2RSVPUpdateRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.preevent2Service.UpdateMyPE2RSVP(ctx.Request.Context(), token, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_MANAGE_RSVP, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_RSVP, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2Controller) WithdrawMyPE2RSVP(ctx *gin.Context) {
+	token := ctx.Query("token")
+
+	result, err := c.preevent2Service.WithdrawMyPE2RSVP(ctx.Request.Context(), token)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_MANAGE_RSVP, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_WITHDRAW_RSVP, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *preEvent2Controller) ResendPE2RSVPLink(ctx *gin.Context) {
+	var req dto.PE2RSVPResendLinkRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	c.preevent2Service.ResendPE2RSVPLink(ctx.Request.Context(), req)
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_RESEND_RSVP_LINK, nil)
+	ctx.JSON(http.StatusOK, res)
+}
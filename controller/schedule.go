@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	ScheduleController interface {
+		CreateSession(ctx *gin.Context)
+		UpdateSession(ctx *gin.Context)
+		DeleteSession(ctx *gin.Context)
+		GetSchedule(ctx *gin.Context)
+		GetEventCalendar(ctx *gin.Context)
+		CreateCalendarFeed(ctx *gin.Context)
+		GetPersonalCalendar(ctx *gin.Context)
+	}
+
+	scheduleController struct {
+		scheduleService service.ScheduleService
+	}
+)
+
+func NewScheduleController(service service.ScheduleService) ScheduleController {
+	return &scheduleController{
+		scheduleService: service,
+	}
+}
+
+func (c *scheduleController) CreateSession(ctx *gin.Context) {
+	var req dto.SessionRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.scheduleService.CreateSession(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_SESSION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_SESSION, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *scheduleController) UpdateSession(ctx *gin.Context) {
+	var req dto.SessionRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.scheduleService.UpdateSession(ctx.Request.Context(), ctx.Param("id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_SESSION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_SESSION, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *scheduleController) DeleteSession(ctx *gin.Context) {
+	if err := c.scheduleService.DeleteSession(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_SESSION, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_SESSION, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *scheduleController) GetSchedule(ctx *gin.Context) {
+	result, err := c.scheduleService.GetSchedule(ctx.Request.Context(), ctx.Param("event_id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SCHEDULE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SCHEDULE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *scheduleController) GetEventCalendar(ctx *gin.Context) {
+	result, err := c.scheduleService.GetEventCalendar(ctx.Request.Context(), ctx.Param("event_id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SCHEDULE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Header("Content-Disposition", `inline; filename="schedule.ics"`)
+	ctx.Data(http.StatusOK, utils.ICS_CONTENT_TYPE, result)
+}
+
+func (c *scheduleController) CreateCalendarFeed(ctx *gin.Context) {
+	result, err := c.scheduleService.CreateCalendarFeed(ctx.Request.Context(), ctx.GetString(constants.CTX_KEY_USER_ID))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_CALENDAR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_CALENDAR, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *scheduleController) GetPersonalCalendar(ctx *gin.Context) {
+	result, err := c.scheduleService.GetPersonalCalendar(ctx.Request.Context(), ctx.Param("token"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SCHEDULE, err.Error(), nil)
+		ctx.JSON(http.StatusNotFound, res)
+		return
+	}
+
+	ctx.Header("Content-Disposition", `inline; filename="my-schedule.ics"`)
+	ctx.Data(http.StatusOK, utils.ICS_CONTENT_TYPE, result)
+}
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SpeakerController interface {
+		CreateSpeaker(ctx *gin.Context)
+		UpdateSpeaker(ctx *gin.Context)
+		DeleteSpeaker(ctx *gin.Context)
+		GetAllSpeaker(ctx *gin.Context)
+		GetSpeakerDetail(ctx *gin.Context)
+		GetPublishedSpeakers(ctx *gin.Context)
+		GetPublishedSpeakerDetail(ctx *gin.Context)
+		CreateTalk(ctx *gin.Context)
+		UpdateTalk(ctx *gin.Context)
+		DeleteTalk(ctx *gin.Context)
+	}
+
+	speakerController struct {
+		speakerService service.SpeakerService
+	}
+)
+
+func NewSpeakerController(service service.SpeakerService) SpeakerController {
+	return &speakerController{
+		speakerService: service,
+	}
+}
+
+func (c *speakerController) CreateSpeaker(ctx *gin.Context) {
+	var req dto.SpeakerRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.speakerService.CreateSpeaker(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_SPEAKER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_SPEAKER, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *speakerController) UpdateSpeaker(ctx *gin.Context) {
+	var req dto.SpeakerRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.speakerService.UpdateSpeaker(ctx.Request.Context(), ctx.Param("id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_SPEAKER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_SPEAKER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *speakerController) DeleteSpeaker(ctx *gin.Context) {
+	if err := c.speakerService.DeleteSpeaker(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_SPEAKER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_SPEAKER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *speakerController) GetAllSpeaker(ctx *gin.Context) {
+	result, err := c.speakerService.GetAllSpeaker(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPEAKER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPEAKER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *speakerController) GetSpeakerDetail(ctx *gin.Context) {
+	result, err := c.speakerService.GetSpeakerDetail(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPEAKER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPEAKER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *speakerController) GetPublishedSpeakers(ctx *gin.Context) {
+	result, err := c.speakerService.GetPublishedSpeakers(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPEAKER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPEAKER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *speakerController) GetPublishedSpeakerDetail(ctx *gin.Context) {
+	result, err := c.speakerService.GetPublishedSpeakerDetail(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPEAKER, err.Error(), nil)
+		ctx.JSON(http.StatusNotFound, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPEAKER, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *speakerController) CreateTalk(ctx *gin.Context) {
+	var req dto.TalkRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.speakerService.CreateTalk(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_TALK, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_TALK, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *speakerController) UpdateTalk(ctx *gin.Context) {
+	var req dto.TalkRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.speakerService.UpdateTalk(ctx.Request.Context(), ctx.Param("id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_TALK, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_TALK, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *speakerController) DeleteTalk(ctx *gin.Context) {
+	if err := c.speakerService.DeleteTalk(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_TALK, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_TALK, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SponsorController interface {
+		CreateSponsor(ctx *gin.Context)
+		UpdateSponsor(ctx *gin.Context)
+		DeleteSponsor(ctx *gin.Context)
+		GetAllSponsor(ctx *gin.Context)
+		GetSponsorDetail(ctx *gin.Context)
+		GetPublicSponsors(ctx *gin.Context)
+		GetPublicSponsorDetail(ctx *gin.Context)
+		CreatePlacement(ctx *gin.Context)
+		GetSponsorReport(ctx *gin.Context)
+		GetAllSponsorReport(ctx *gin.Context)
+	}
+
+	sponsorController struct {
+		sponsorService service.SponsorService
+	}
+)
+
+func NewSponsorController(service service.SponsorService) SponsorController {
+	return &sponsorController{
+		sponsorService: service,
+	}
+}
+
+func (c *sponsorController) CreateSponsor(ctx *gin.Context) {
+	var req dto.SponsorRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.sponsorService.CreateSponsor(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_SPONSOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_SPONSOR, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *sponsorController) UpdateSponsor(ctx *gin.Context) {
+	var req dto.SponsorRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.sponsorService.UpdateSponsor(ctx.Request.Context(), ctx.Param("id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UPDATE_SPONSOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UPDATE_SPONSOR, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sponsorController) DeleteSponsor(ctx *gin.Context) {
+	if err := c.sponsorService.DeleteSponsor(ctx.Request.Context(), ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_SPONSOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_SPONSOR, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sponsorController) GetAllSponsor(ctx *gin.Context) {
+	var req dto.SponsorQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.sponsorService.GetAllSponsor(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPONSOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPONSOR, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sponsorController) GetSponsorDetail(ctx *gin.Context) {
+	result, err := c.sponsorService.GetSponsorDetail(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPONSOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPONSOR, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sponsorController) GetPublicSponsors(ctx *gin.Context) {
+	var req dto.SponsorQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.sponsorService.GetPublicSponsors(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPONSOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPONSOR, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sponsorController) GetPublicSponsorDetail(ctx *gin.Context) {
+	result, err := c.sponsorService.GetPublicSponsorDetail(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPONSOR, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPONSOR, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sponsorController) CreatePlacement(ctx *gin.Context) {
+	var req dto.SponsorPlacementRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.sponsorService.CreatePlacement(ctx.Request.Context(), ctx.Param("id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_SPONSOR_PLACEMENT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_SPONSOR_PLACEMENT, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *sponsorController) GetSponsorReport(ctx *gin.Context) {
+	result, err := c.sponsorService.GetSponsorReport(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPONSOR_REPORT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPONSOR_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *sponsorController) GetAllSponsorReport(ctx *gin.Context) {
+	result, err := c.sponsorService.GetAllSponsorReport(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SPONSOR_REPORT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SPONSOR_REPORT, result)
+	ctx.JSON(http.StatusOK, res)
+}
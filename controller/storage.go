@@ -12,6 +12,8 @@ import (
 type (
 	StorageController interface {
 		GetMainEventPaymentFile(c *gin.Context)
+		GetSpeakerPhoto(c *gin.Context)
+		GetSponsorLogo(c *gin.Context)
 	}
 
 	storageController struct {
@@ -37,3 +39,29 @@ func (c *storageController) GetMainEventPaymentFile(ctx *gin.Context) {
 
 	ctx.Data(http.StatusOK, "application/octet-stream", file)
 }
+
+func (c *storageController) GetSpeakerPhoto(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	file, err := c.storageService.GetSpeakerPhoto(id)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_FILE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Data(http.StatusOK, http.DetectContentType(file), file)
+}
+
+func (c *storageController) GetSponsorLogo(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	file, err := c.storageService.GetSponsorLogo(id)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_FILE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	ctx.Data(http.StatusOK, http.DetectContentType(file), file)
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	SurveyController interface {
+		Create(ctx *gin.Context)
+		GetAll(ctx *gin.Context)
+		GetDetail(ctx *gin.Context)
+		Invite(ctx *gin.Context)
+		GetResult(ctx *gin.Context)
+		GetByToken(ctx *gin.Context)
+		Submit(ctx *gin.Context)
+	}
+
+	surveyController struct {
+		surveyService service.SurveyService
+	}
+)
+
+func NewSurveyController(service service.SurveyService) SurveyController {
+	return &surveyController{
+		surveyService: service,
+	}
+}
+
+func (c *surveyController) Create(ctx *gin.Context) {
+	var req dto.SurveyRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.surveyService.CreateSurvey(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CREATE_SURVEY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CREATE_SURVEY, result)
+	ctx.JSON(http.StatusCreated, res)
+}
+
+func (c *surveyController) GetAll(ctx *gin.Context) {
+	result, err := c.surveyService.GetAllSurvey(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SURVEY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SURVEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *surveyController) GetDetail(ctx *gin.Context) {
+	result, err := c.surveyService.GetSurveyDetail(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SURVEY, err.Error(), nil)
+		ctx.JSON(http.StatusNotFound, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SURVEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *surveyController) Invite(ctx *gin.Context) {
+	result, err := c.surveyService.InviteAttendees(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SEND_SURVEY_INVITE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SEND_SURVEY_INVITE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *surveyController) GetResult(ctx *gin.Context) {
+	result, err := c.surveyService.GetSurveyResult(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SURVEY_RESULT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SURVEY_RESULT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *surveyController) GetByToken(ctx *gin.Context) {
+	token := ctx.Query("token")
+
+	result, err := c.surveyService.GetSurveyByToken(ctx.Request.Context(), token)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_SURVEY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_SURVEY, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *surveyController) Submit(ctx *gin.Context) {
+	token := ctx.Query("token")
+
+	var req dto.SurveySubmitRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	err := c.surveyService.SubmitSurvey(ctx.Request.Context(), token, req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SUBMIT_SURVEY, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SUBMIT_SURVEY, nil)
+	ctx.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,165 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	UserManagementController interface {
+		ChangeRole(ctx *gin.Context)
+		Suspend(ctx *gin.Context)
+		Reactivate(ctx *gin.Context)
+		Delete(ctx *gin.Context)
+		ForceVerify(ctx *gin.Context)
+		ForceResetPassword(ctx *gin.Context)
+		GetAuditLogs(ctx *gin.Context)
+		GetLockouts(ctx *gin.Context)
+		ClearLockout(ctx *gin.Context)
+	}
+
+	userManagementController struct {
+		userManagementService service.UserManagementService
+	}
+)
+
+func NewUserManagementController(service service.UserManagementService) UserManagementController {
+	return &userManagementController{
+		userManagementService: service,
+	}
+}
+
+func (c *userManagementController) ChangeRole(ctx *gin.Context) {
+	var req dto.UserRoleRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	result, err := c.userManagementService.ChangeRole(ctx.Request.Context(), actorID, ctx.Param("id"), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CHANGE_USER_ROLE, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CHANGE_USER_ROLE, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userManagementController) Suspend(ctx *gin.Context) {
+	var req dto.UserSuspendRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	if err := c.userManagementService.Suspend(ctx.Request.Context(), actorID, ctx.Param("id"), req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_SUSPEND_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SUSPEND_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userManagementController) Reactivate(ctx *gin.Context) {
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	if err := c.userManagementService.Reactivate(ctx.Request.Context(), actorID, ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REACTIVATE_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REACTIVATE_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userManagementController) Delete(ctx *gin.Context) {
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	if err := c.userManagementService.Delete(ctx.Request.Context(), actorID, ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_DELETE_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_DELETE_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userManagementController) ForceVerify(ctx *gin.Context) {
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	if err := c.userManagementService.ForceVerify(ctx.Request.Context(), actorID, ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_FORCE_VERIFY_USER, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FORCE_VERIFY_USER, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userManagementController) ForceResetPassword(ctx *gin.Context) {
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	if err := c.userManagementService.ForceResetPassword(ctx.Request.Context(), actorID, ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_FORCE_RESET_PASSWORD, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_FORCE_RESET_PASSWORD, nil)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userManagementController) GetAuditLogs(ctx *gin.Context) {
+	var req dto.AuditLogQuery
+	if err := ctx.ShouldBind(&req); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
+		return
+	}
+
+	result, err := c.userManagementService.GetAuditLogs(ctx.Request.Context(), req)
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_AUDIT_LOG, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_AUDIT_LOG, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userManagementController) GetLockouts(ctx *gin.Context) {
+	result, err := c.userManagementService.GetLockouts(ctx.Request.Context())
+	if err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_LOCKOUT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_GET_LOCKOUT, result)
+	ctx.JSON(http.StatusOK, res)
+}
+
+func (c *userManagementController) ClearLockout(ctx *gin.Context) {
+	actorID := ctx.GetString(constants.CTX_KEY_USER_ID)
+	if err := c.userManagementService.ClearLockout(ctx.Request.Context(), actorID, ctx.Param("id")); err != nil {
+		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CLEAR_LOCKOUT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, res)
+		return
+	}
+
+	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CLEAR_LOCKOUT, nil)
+	ctx.JSON(http.StatusOK, res)
+}
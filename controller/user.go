@@ -3,10 +3,8 @@ package controller
 import (
 	"net/http"
 
-	"github.com/TEDxITS/website-backend-2024/config"
 	"github.com/TEDxITS/website-backend-2024/constants"
 	"github.com/TEDxITS/website-backend-2024/dto"
-	"github.com/TEDxITS/website-backend-2024/entity"
 	"github.com/TEDxITS/website-backend-2024/service"
 	"github.com/TEDxITS/website-backend-2024/utils"
 	"github.com/gin-gonic/gin"
@@ -23,18 +21,27 @@ type (
 		ResendVerifyEmail(ctx *gin.Context)
 		ResetPassword(ctx *gin.Context)
 		SendResetPasswordEmail(ctx *gin.Context)
+		RefreshToken(ctx *gin.Context)
+		Logout(ctx *gin.Context)
+		LogoutAll(ctx *gin.Context)
+		UnlockAccount(ctx *gin.Context)
+		ChangePassword(ctx *gin.Context)
+		ChangeEmail(ctx *gin.Context)
+		ConfirmEmail(ctx *gin.Context)
 	}
 
 	userController struct {
-		jwtService  config.JWTService
 		userService service.UserService
+		authService service.AuthService
+		mfaService  service.MFAService
 	}
 )
 
-func NewUserController(us service.UserService, jwt config.JWTService) UserController {
+func NewUserController(us service.UserService, as service.AuthService, ms service.MFAService) UserController {
 	return &userController{
-		jwtService:  jwt,
 		userService: us,
+		authService: as,
+		mfaService:  ms,
 	}
 }
 
@@ -106,25 +113,70 @@ func (c *userController) Login(ctx *gin.Context) {
 		return
 	}
 
-	user, err := c.userService.VerifyLogin(ctx.Request.Context(), req.Email, req.Password)
+	user, err := c.userService.VerifyLogin(ctx.Request.Context(), req.Email, req.Password, ctx.ClientIP())
 	if err != nil {
+		status := http.StatusUnauthorized
+		if err == dto.ErrLoginLocked {
+			status = http.StatusTooManyRequests
+		}
+
 		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOGIN, err.Error(), nil)
+		ctx.AbortWithStatusJSON(status, response)
+		return
+	}
+
+	startSession(ctx, c.authService, c.mfaService, user, dto.MESSAGE_FAILED_LOGIN)
+}
+
+func (c *userController) RefreshToken(ctx *gin.Context) {
+	var req dto.RefreshTokenRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	result, err := c.authService.RefreshTokens(ctx.Request.Context(), req.RefreshToken, ctx.Request.UserAgent())
+	if err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_REFRESH_TOKEN, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
 		return
 	}
 
-	token := c.jwtService.GenerateToken(user.ID.String(), user.Role.Name)
-	userResponse := entity.Authorization{
-		Token: token,
-		Role:  user.Role.Name,
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_REFRESH_TOKEN, result)
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *userController) Logout(ctx *gin.Context) {
+	userId := ctx.GetString(constants.CTX_KEY_USER_ID)
+	tokenId := ctx.GetString(constants.CTX_KEY_TOKEN_ID)
+
+	if err := c.authService.Logout(ctx.Request.Context(), userId, tokenId); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOGOUT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
 	}
 
-	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGIN, userResponse)
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGOUT, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *userController) LogoutAll(ctx *gin.Context) {
+	userId := ctx.GetString(constants.CTX_KEY_USER_ID)
+	tokenId := ctx.GetString(constants.CTX_KEY_TOKEN_ID)
+
+	if err := c.authService.LogoutAll(ctx.Request.Context(), userId, tokenId); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_LOGOUT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_LOGOUT_ALL, nil)
 	ctx.JSON(http.StatusOK, response)
 }
 
 func (c *userController) Update(ctx *gin.Context) {
-	var req dto.UserRequest
+	var req dto.UserUpdateRequest
 	if err := ctx.ShouldBind(&req); err != nil {
 		res := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
 		ctx.AbortWithStatusJSON(http.StatusBadRequest, res)
@@ -222,3 +274,85 @@ func (c *userController) SendResetPasswordEmail(ctx *gin.Context) {
 	res := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_SEND_RESET_PASSWORD_EMAIL, nil)
 	ctx.JSON(http.StatusOK, res)
 }
+
+func (c *userController) UnlockAccount(ctx *gin.Context) {
+	var req dto.UnlockAccountRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if err := c.userService.UnlockAccount(ctx.Request.Context(), req.Token); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_UNLOCK_ACCOUNT, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_UNLOCK_ACCOUNT, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+// every session is revoked, the caller gets a fresh pair so only they stay signed in
+func (c *userController) ChangePassword(ctx *gin.Context) {
+	var req dto.UserChangePasswordRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	userId := ctx.GetString(constants.CTX_KEY_USER_ID)
+	user, err := c.userService.ChangePassword(ctx.Request.Context(), userId, req)
+	if err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CHANGE_PASSWORD, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	result, err := c.authService.IssueTokens(ctx.Request.Context(), user, ctx.Request.UserAgent())
+	if err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CHANGE_PASSWORD, err.Error(), nil)
+		ctx.JSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CHANGE_PASSWORD, result)
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *userController) ChangeEmail(ctx *gin.Context) {
+	var req dto.UserChangeEmailRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if err := c.userService.RequestEmailChange(ctx.Request.Context(), ctx.GetString(constants.CTX_KEY_USER_ID), req); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CHANGE_EMAIL, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CHANGE_EMAIL, nil)
+	ctx.JSON(http.StatusOK, response)
+}
+
+func (c *userController) ConfirmEmail(ctx *gin.Context) {
+	var req dto.UserConfirmEmailRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_GET_DATA_FROM_BODY, err.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if err := c.userService.ConfirmEmailChange(ctx.Request.Context(), req.Token); err != nil {
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_CONFIRM_EMAIL, err.Error(), nil)
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	response := utils.BuildResponseSuccess(dto.MESSAGE_SUCCESS_CONFIRM_EMAIL, nil)
+	ctx.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/gin-gonic/gin"
+)
+
+type (
+	WellKnownController interface {
+		JWKS(ctx *gin.Context)
+	}
+
+	wellKnownController struct {
+		jwtService config.JWTService
+	}
+)
+
+func NewWellKnownController(jwtService config.JWTService) WellKnownController {
+	return &wellKnownController{
+		jwtService: jwtService,
+	}
+}
+
+// served as a plain key set rather than the usual response envelope,
+// other services read it with standard JWT libraries
+func (c *wellKnownController) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.jwtService.GetJWKS())
+}
@@ -0,0 +1,163 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_ANALYTICS          = "failed get analytics"
+	MESSAGE_FAILED_GET_DEMOGRAPHIC_REPORT = "failed get demographic report"
+
+	// Success
+	MESSAGE_SUCCESS_GET_ANALYTICS          = "success get analytics"
+	MESSAGE_SUCCESS_GET_DEMOGRAPHIC_REPORT = "success get demographic report"
+
+	ENUM_ANALYTICS_INTERVAL_HOUR = "hour"
+	ENUM_ANALYTICS_INTERVAL_DAY  = "day"
+)
+
+var (
+	ErrAnalyticsIntervalInvalid = errors.New("interval should be either hour or day")
+	ErrAnalyticsRangeInvalid    = errors.New("end date should not be before start date")
+	ErrDemographicStatusInvalid = errors.New("status should be one of pending, accepted, rejected or waitlisted")
+)
+
+type (
+	// dates are inclusive on both ends, tier takes one or more event ids
+	AnalyticsQuery struct {
+		Start    *time.Time `form:"start" time_format:"2006-01-02"`
+		End      *time.Time `form:"end" time_format:"2006-01-02"`
+		Tier     []string   `form:"tier"`
+		Interval string     `form:"interval"`
+	}
+
+	SalesRevenueResponse struct {
+		Tiers []TierRevenueResponse `json:"tiers"`
+		Total SalesRevenueTotal     `json:"total"`
+	}
+
+	TierRevenueResponse struct {
+		EventID          string `json:"event_id"`
+		Name             string `json:"name"`
+		Price            int    `json:"price"`
+		Sold             int64  `json:"sold"`
+		Confirmed        int64  `json:"confirmed"`
+		Pending          int64  `json:"pending"`
+		Complimentary    int64  `json:"complimentary"`
+		ConfirmedRevenue int64  `json:"confirmed_revenue"`
+		PendingRevenue   int64  `json:"pending_revenue"`
+	}
+
+	SalesRevenueTotal struct {
+		Sold             int64 `json:"sold"`
+		Confirmed        int64 `json:"confirmed"`
+		Pending          int64 `json:"pending"`
+		Complimentary    int64 `json:"complimentary"`
+		ConfirmedRevenue int64 `json:"confirmed_revenue"`
+		PendingRevenue   int64 `json:"pending_revenue"`
+	}
+
+	SalesTimelineResponse struct {
+		Interval string                `json:"interval"`
+		Buckets  []SalesTimelineBucket `json:"buckets"`
+	}
+
+	SalesTimelineBucket struct {
+		Time          time.Time `json:"time"`
+		EventID       string    `json:"event_id"`
+		Name          string    `json:"name"`
+		Registrations int64     `json:"registrations"`
+		Confirmed     int64     `json:"confirmed"`
+		Revenue       int64     `json:"revenue"`
+	}
+
+	SellOutResponse struct {
+		EventID        string     `json:"event_id"`
+		Name           string     `json:"name"`
+		Capacity       int        `json:"capacity"`
+		Registers      int        `json:"registers"`
+		SalesStart     time.Time  `json:"sales_start"`
+		SoldOut        bool       `json:"sold_out"`
+		SoldOutAt      *time.Time `json:"sold_out_at"`
+		SellOutSeconds *int64     `json:"sell_out_seconds"`
+	}
+
+	ConversionResponse struct {
+		RegisteredUsers int64   `json:"registered_users"`
+		VerifiedUsers   int64   `json:"verified_users"`
+		Buyers          int64   `json:"buyers"`
+		ConfirmedBuyers int64   `json:"confirmed_buyers"`
+		BuyerRate       float64 `json:"buyer_rate"`
+		ConfirmedRate   float64 `json:"confirmed_rate"`
+	}
+)
+
+type (
+	DemographicQuery struct {
+		Status string `form:"status"`
+	}
+
+	DemographicReportResponse struct {
+		EventID     string            `json:"event_id"`
+		Name        string            `json:"name"`
+		Total       int64             `json:"total"`
+		MinCellSize int               `json:"min_cell_size"`
+		Suppressed  bool              `json:"suppressed"`
+		AgeBrackets []DemographicCell `json:"age_brackets,omitempty"`
+		Institutes  []DemographicCell `json:"institutes,omitempty"`
+		Departments []DemographicCell `json:"departments,omitempty"`
+		Batches     []DemographicCell `json:"batches,omitempty"`
+	}
+
+	DemographicCell struct {
+		Label string  `json:"label"`
+		Count int64   `json:"count"`
+		Share float64 `json:"share"`
+	}
+)
+
+// rows the analytics queries are scanned into
+type (
+	TierRevenueRow struct {
+		EventID          string
+		Name             string
+		Price            int
+		Sold             int64
+		Confirmed        int64
+		Complimentary    int64
+		ConfirmedRevenue int64
+		PendingRevenue   int64
+	}
+
+	RegistrationBucketRow struct {
+		Bucket        time.Time
+		EventID       string
+		Name          string
+		Registrations int64
+		Confirmed     int64
+		Revenue       int64
+	}
+
+	SellOutRow struct {
+		EventID   string
+		Name      string
+		Capacity  int
+		Registers int
+		StartDate time.Time
+		SoldOutAt *time.Time
+	}
+
+	ConversionRow struct {
+		RegisteredUsers int64
+		VerifiedUsers   int64
+		Buyers          int64
+		ConfirmedBuyers int64
+	}
+
+	DemographicRow struct {
+		Label string
+		Count int64
+	}
+)
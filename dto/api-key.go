@@ -0,0 +1,57 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_API_KEY    = "failed get api key"
+	MESSAGE_FAILED_CREATE_API_KEY = "failed create api key"
+	MESSAGE_FAILED_REVOKE_API_KEY = "failed revoke api key"
+	MESSAGE_FAILED_VERIFY_API_KEY = "failed verify api key"
+
+	// Success
+	MESSAGE_SUCCESS_GET_API_KEY    = "success get api key"
+	MESSAGE_SUCCESS_CREATE_API_KEY = "success create api key. Store the key now, it will not be shown again"
+	MESSAGE_SUCCESS_REVOKE_API_KEY = "success revoke api key"
+)
+
+var (
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrAPIKeyInvalid           = errors.New("api key invalid")
+	ErrAPIKeyExpired           = errors.New("api key expired")
+	ErrAPIKeyRevoked           = errors.New("api key revoked")
+	ErrAPIKeyExpiryInvalid     = errors.New("api key expiry must be in the future")
+	ErrAPIKeyPermissionInvalid = errors.New("permission \"%v\" can not be granted to an api key")
+	ErrAPIKeyEventNotAllowed   = errors.New("api key is not allowed for this event")
+	ErrAPIKeyPermissionNotHeld = errors.New("can not grant \"%v\" without holding it")
+)
+
+type (
+	APIKeyRequest struct {
+		Name        string     `json:"name" form:"name" binding:"required"`
+		Permissions []string   `json:"permissions" form:"permissions" binding:"required,min=1"`
+		EventIDs    []string   `json:"event_ids" form:"event_ids"`
+		ExpiresAt   *time.Time `json:"expires_at" form:"expires_at"`
+	}
+
+	APIKeyResponse struct {
+		ID          string     `json:"id"`
+		Name        string     `json:"name"`
+		Prefix      string     `json:"prefix"`
+		Permissions []string   `json:"permissions"`
+		EventIDs    []string   `json:"event_ids"`
+		CreatedBy   string     `json:"created_by,omitempty"`
+		ExpiresAt   *time.Time `json:"expires_at"`
+		LastUsedAt  *time.Time `json:"last_used_at"`
+		RevokedAt   *time.Time `json:"revoked_at"`
+		CreatedAt   time.Time  `json:"created_at"`
+	}
+
+	APIKeyCreateResponse struct {
+		APIKeyResponse
+		Key string `json:"key"`
+	}
+)
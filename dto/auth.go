@@ -0,0 +1,27 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_REFRESH_TOKEN = "failed refresh token"
+	MESSAGE_FAILED_LOGOUT        = "failed logout"
+
+	// Success
+	MESSAGE_SUCCESS_REFRESH_TOKEN = "success refresh token"
+	MESSAGE_SUCCESS_LOGOUT        = "success logout"
+	MESSAGE_SUCCESS_LOGOUT_ALL    = "success logout from all devices"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("refresh token invalid")
+	ErrRefreshTokenExpired = errors.New("refresh token expired")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used, please log in again")
+	ErrGenerateToken       = errors.New("failed to generate token")
+)
+
+type (
+	RefreshTokenRequest struct {
+		RefreshToken string `json:"refresh_token" form:"refresh_token" binding:"required"`
+	}
+)
@@ -0,0 +1,54 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_CERTIFICATE      = "failed get certificate"
+	MESSAGE_FAILED_GENERATE_CERTIFICATE = "failed generate certificate"
+	MESSAGE_FAILED_VERIFY_CERTIFICATE   = "failed verify certificate"
+
+	// Success
+	MESSAGE_SUCCESS_GET_CERTIFICATE      = "success get certificate"
+	MESSAGE_SUCCESS_GENERATE_CERTIFICATE = "success generate certificate"
+	MESSAGE_SUCCESS_VERIFY_CERTIFICATE   = "success verify certificate"
+
+	ENUM_FILE_TYPE_PDF = "application/pdf"
+	ENUM_FILE_TYPE_ZIP = "application/zip"
+)
+
+var (
+	ErrCertificateNotFound      = errors.New("certificate not found")
+	ErrCertificateNotEligible   = errors.New("certificate is only available for checked in attendees")
+	ErrGenerateCertificate      = errors.New("failed to generate certificate")
+	ErrCertificateAccessDenied  = errors.New("certificate does not belong to this account")
+	ErrNoCertificateToGenerate  = errors.New("no checked in attendees for this event")
+	ErrFailedToArchiveCertFiles = errors.New("failed to archive certificate files")
+)
+
+type (
+	CertificateBulkRequest struct {
+		EventID string `json:"event_id" form:"event_id" binding:"required"`
+	}
+
+	CertificateResponse struct {
+		ID        string    `json:"id"`
+		Code      string    `json:"code"`
+		Name      string    `json:"name"`
+		EventName string    `json:"event_name"`
+		EventDate time.Time `json:"event_date"`
+		IssuedAt  time.Time `json:"issued_at"`
+	}
+
+	CertificateVerifyResponse struct {
+		Valid     bool      `json:"valid"`
+		Code      string    `json:"code"`
+		Name      string    `json:"name"`
+		EventName string    `json:"event_name"`
+		EventDate time.Time `json:"event_date"`
+		IssuedAt  time.Time `json:"issued_at"`
+	}
+)
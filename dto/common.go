@@ -5,10 +5,13 @@ import "errors"
 const (
 	MESSAGE_FAILED_GET_DATA_FROM_BODY = "failed get data from body"
 	MESSAGE_FAILED_VERIFY_TOKEN       = "failed to verify JWT token"
+	MESSAGE_FAILED_RATE_LIMIT         = "too many requests"
 )
 
 var (
 	ErrTokenInvalid  = errors.New("token invalid")
 	ErrTokenExpired  = errors.New("token expired")
 	ErrTokenNotFound = errors.New("token not found")
+	ErrTokenRevoked  = errors.New("token revoked")
+	ErrRateLimited   = errors.New("rate limit exceeded, try again later")
 )
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"errors"
+	"mime/multipart"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_ISSUE_COMP_TICKET  = "failed issue complimentary ticket"
+	MESSAGE_FAILED_IMPORT_COMP_TICKET = "failed import complimentary tickets"
+
+	// Success
+	MESSAGE_SUCCESS_ISSUE_COMP_TICKET  = "success issue complimentary ticket"
+	MESSAGE_SUCCESS_IMPORT_COMP_TICKET = "success import complimentary tickets"
+)
+
+var (
+	ErrCompQuotaFull           = errors.New("complimentary quota of this event is full")
+	ErrGuestCategoryInvalid    = errors.New("guest category must be speaker, sponsor, media_partner or committee")
+	ErrGuestAlreadyHasTicket   = errors.New("guest already has a ticket for this event")
+	ErrCompImportHeaderInvalid = errors.New("csv must have name, email and category columns")
+	ErrCompImportFileInvalid   = errors.New("file must be a csv")
+)
+
+type (
+	CompTicketRequest struct {
+		Name      string `json:"name" form:"name" binding:"required"`
+		Email     string `json:"email" form:"email" binding:"required"`
+		Category  string `json:"category" form:"category" binding:"required"`
+		EventID   string `json:"event_id" form:"event_id" binding:"required"`
+		Handphone string `json:"handphone" form:"handphone"`
+	}
+
+	CompTicketImportRequest struct {
+		EventID string                `json:"event_id" form:"event_id" binding:"required"`
+		File    *multipart.FileHeader `json:"file" form:"file" binding:"required"`
+	}
+
+	CompTicketResponse struct {
+		TicketID    string `json:"ticket_id"`
+		UserID      string `json:"user_id"`
+		Name        string `json:"name"`
+		Email       string `json:"email"`
+		Category    string `json:"category"`
+		EventID     string `json:"event_id"`
+		NewAccount  bool   `json:"new_account"`
+		EmailSent   bool   `json:"email_sent"`
+		EmailQueued bool   `json:"email_queued,omitempty"`
+	}
+
+	CompTicketImportResponse struct {
+		Issued  int                     `json:"issued"`
+		Failed  int                     `json:"failed"`
+		Tickets []CompTicketResponse    `json:"tickets"`
+		Errors  []CompTicketImportError `json:"errors"`
+	}
+
+	CompTicketImportError struct {
+		Row   int    `json:"row"`
+		Email string `json:"email"`
+		Error string `json:"error"`
+	}
+)
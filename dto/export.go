@@ -0,0 +1,21 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_EXPORT = "failed export data"
+)
+
+var (
+	ErrExportFormatInvalid = errors.New("format must be csv or xlsx")
+	ErrExportColumnInvalid = errors.New("unknown export column")
+)
+
+type (
+	ExportQuery struct {
+		Format  string `json:"format" form:"format"`
+		Columns string `json:"columns" form:"columns"`
+		Search  string `json:"search" form:"search"`
+	}
+)
@@ -3,6 +3,9 @@ package dto
 import "errors"
 
 const (
+	// frontend page that resolves an alias and redirects the visitor
+	SHORT_LINK_PATH = "/links/"
+
 	MESSAGE_FAILED_CREATE_LINK_SHORTENER = "failed create link shortener"
 	MESSAGE_FAILED_GET_LINK_SHORTENER    = "failed get link shortener"
 
@@ -24,9 +27,10 @@ type (
 	}
 
 	LinkShortenerResponse struct {
-		ID    string `json:"id,omitempty"`
-		Alias string `json:"alias"`
-		Link  string `json:"link"`
+		ID     string `json:"id,omitempty"`
+		Alias  string `json:"alias"`
+		Link   string `json:"link"`
+		Clicks int64  `json:"clicks,omitempty"`
 	}
 
 	LinkShortenerPaginationResponse struct {
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_UNLOCK_ACCOUNT = "failed unlock account"
+	MESSAGE_FAILED_GET_LOCKOUT    = "failed get lockout"
+	MESSAGE_FAILED_CLEAR_LOCKOUT  = "failed clear lockout"
+
+	// Success
+	MESSAGE_SUCCESS_UNLOCK_ACCOUNT = "success unlock account"
+	MESSAGE_SUCCESS_GET_LOCKOUT    = "success get lockout"
+	MESSAGE_SUCCESS_CLEAR_LOCKOUT  = "success clear lockout"
+)
+
+var (
+	ErrLoginLocked        = errors.New("too many failed login attempts, try again later")
+	ErrUnlockTokenInvalid = errors.New("unlock link is invalid or already used")
+	ErrLockoutNotFound    = errors.New("lockout not found")
+)
+
+type (
+	UnlockAccountRequest struct {
+		Token string `json:"token" form:"token" binding:"required"`
+	}
+
+	LockoutResponse struct {
+		ID           string     `json:"id"`
+		Kind         string     `json:"kind"`
+		Key          string     `json:"key"`
+		LockCount    int        `json:"lock_count"`
+		LastFailedAt time.Time  `json:"last_failed_at"`
+		LockedUntil  *time.Time `json:"locked_until"`
+	}
+)
@@ -33,6 +33,7 @@ var (
 	ErrMismatchData             = errors.New("mismatch data")
 	ErrOpeningPaymentFile       = errors.New("failed to open payment file")
 	ErrFailedToDownloadFile     = errors.New("failed to download file")
+	ErrFailedToDeleteFile       = errors.New("failed to delete file")
 	ErrMaxFileSize5MB           = errors.New("max file size is 5MB")
 	ErrFileMustBeImage          = errors.New("file must be an image (jpg/jpeg/png)")
 	ErrFileNotFound             = errors.New("file not found")
@@ -106,10 +107,11 @@ type (
 		CheckedIns        int64 `json:"checked_ins" form:"checked_ins"`
 	}
 
+	// handphone and birthdate left out are taken from the profile
 	MainEventRegister struct {
 		EventID     string                `json:"event_id" form:"event_id" binding:"required"`
-		Handphone   string                `json:"handphone" form:"handphone" binding:"required"`
-		Birthdate   time.Time             `json:"birthdate" form:"birthdate" binding:"required"`
+		Handphone   string                `json:"handphone" form:"handphone"`
+		Birthdate   time.Time             `json:"birthdate" form:"birthdate"`
 		PaymentFile *multipart.FileHeader `json:"payment_file" form:"payment_file" binding:"required"`
 	}
 )
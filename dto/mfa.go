@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_VERIFY_MFA        = "failed verify two-factor code"
+	MESSAGE_FAILED_GET_MFA_STATUS    = "failed get two-factor status"
+	MESSAGE_FAILED_ENROLL_MFA        = "failed enroll two-factor"
+	MESSAGE_FAILED_ACTIVATE_MFA      = "failed activate two-factor"
+	MESSAGE_FAILED_DISABLE_MFA       = "failed disable two-factor"
+	MESSAGE_FAILED_GENERATE_RECOVERY = "failed generate recovery codes"
+	MESSAGE_FAILED_MFA_CHALLENGE     = "failed start two-factor challenge"
+
+	// Success
+	MESSAGE_SUCCESS_MFA_REQUIRED      = "two-factor code required"
+	MESSAGE_SUCCESS_MFA_SETUP_NEEDED  = "two-factor enrollment required"
+	MESSAGE_SUCCESS_VERIFY_MFA        = "success verify two-factor code"
+	MESSAGE_SUCCESS_GET_MFA_STATUS    = "success get two-factor status"
+	MESSAGE_SUCCESS_ENROLL_MFA        = "success enroll two-factor. Confirm with a code from the authenticator app"
+	MESSAGE_SUCCESS_ACTIVATE_MFA      = "success activate two-factor"
+	MESSAGE_SUCCESS_DISABLE_MFA       = "success disable two-factor"
+	MESSAGE_SUCCESS_GENERATE_RECOVERY = "success generate recovery codes"
+)
+
+var (
+	ErrMFATokenInvalid     = errors.New("two-factor session invalid or expired")
+	ErrMFACodeInvalid      = errors.New("two-factor code invalid")
+	ErrMFAAlreadyEnabled   = errors.New("two-factor already enabled")
+	ErrMFANotEnabled       = errors.New("two-factor not enabled")
+	ErrMFANotEnrolled      = errors.New("two-factor enrollment not started")
+	ErrMFARequiredByRole   = errors.New("two-factor is required for this role")
+	ErrMFAEnrollmentNeeded = errors.New("two-factor enrollment required for this role, sign in again to set it up")
+)
+
+type (
+	MFAChallengeResponse struct {
+		MFARequired        bool      `json:"mfa_required"`
+		EnrollmentRequired bool      `json:"enrollment_required"`
+		MFAToken           string    `json:"mfa_token"`
+		ExpiresAt          time.Time `json:"expires_at"`
+	}
+
+	MFAVerifyRequest struct {
+		MFAToken string `json:"mfa_token" form:"mfa_token" binding:"required"`
+		Code     string `json:"code" form:"code" binding:"required"`
+	}
+
+	MFATokenRequest struct {
+		MFAToken string `json:"mfa_token" form:"mfa_token" binding:"required"`
+	}
+
+	MFACodeRequest struct {
+		Code string `json:"code" form:"code" binding:"required"`
+	}
+
+	MFAStatusResponse struct {
+		Enabled           bool       `json:"enabled"`
+		Required          bool       `json:"required"`
+		EnabledAt         *time.Time `json:"enabled_at"`
+		RecoveryCodesLeft int64      `json:"recovery_codes_left"`
+	}
+
+	// the uri is what the frontend renders as the QR code
+	MFAEnrollResponse struct {
+		Secret string `json:"secret"`
+		URI    string `json:"uri"`
+	}
+
+	// recovery codes are shown once, only their hashes are kept
+	MFARecoveryCodesResponse struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+)
@@ -0,0 +1,33 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_OIDC_URL = "failed get sign in url"
+	MESSAGE_FAILED_OIDC_LOGIN   = "failed sign in with provider"
+
+	// Success
+	MESSAGE_SUCCESS_GET_OIDC_URL = "success get sign in url"
+	MESSAGE_SUCCESS_OIDC_LOGIN   = "success sign in with provider"
+)
+
+var (
+	ErrOIDCProviderNotFound   = errors.New("sign in provider not supported")
+	ErrOIDCStateInvalid       = errors.New("sign in state invalid or expired")
+	ErrOIDCEmailNotVerified   = errors.New("email is not verified by the provider")
+	ErrOIDCLogin              = errors.New("failed to sign in with provider")
+	ErrOIDCAccountNotVerified = errors.New("an account with this email is not verified yet, verify it or reset its password first")
+)
+
+type (
+	OIDCCallbackRequest struct {
+		Code  string `json:"code" form:"code" binding:"required"`
+		State string `json:"state" form:"state" binding:"required"`
+	}
+
+	OIDCAuthURLResponse struct {
+		URL     string `json:"url"`
+		Binding string `json:"-"`
+	}
+)
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_EXPORT_PERSONAL_DATA = "failed export personal data"
+	MESSAGE_FAILED_REQUEST_DELETION     = "failed request account deletion"
+	MESSAGE_FAILED_CONFIRM_DELETION     = "failed delete account"
+
+	// Success
+	MESSAGE_SUCCESS_REQUEST_DELETION = "success request account deletion. Please confirm through the link sent to your email"
+	MESSAGE_SUCCESS_CONFIRM_DELETION = "success delete account"
+
+	PERSONAL_DATA_EXPORT_FILENAME = "tedxits-personal-data.zip"
+)
+
+var (
+	ErrExportPersonalData      = errors.New("failed to export personal data")
+	ErrAdminNotAllowedDeletion = errors.New("admin accounts can not be deleted, ask another admin to change the role first")
+	ErrGenerateDeletionEmail   = errors.New("failed to generate account deletion email")
+	ErrDeleteAccount           = errors.New("failed to delete account")
+)
+
+type (
+	AccountDeletionConfirmRequest struct {
+		Token string `json:"token" form:"token" binding:"required"`
+	}
+
+	// written as data.json in the export archive, files are referenced by their path inside it
+	PersonalDataExport struct {
+		ExportedAt        time.Time                      `json:"exported_at"`
+		Account           PersonalDataAccount            `json:"account"`
+		Identities        []PersonalDataIdentity         `json:"identities"`
+		Tickets           []PersonalDataTicket           `json:"tickets"`
+		PE2RSVPs          []PersonalDataPE2RSVP          `json:"pe2_rsvps"`
+		Certificates      []PersonalDataCertificate      `json:"certificates"`
+		SurveyInvitations []PersonalDataSurveyInvitation `json:"survey_invitations"`
+	}
+
+	PersonalDataAccount struct {
+		ID          string      `json:"id"`
+		Name        string      `json:"name"`
+		Email       string      `json:"email"`
+		Role        string      `json:"role"`
+		Verified    bool        `json:"verified"`
+		MFAEnabled  bool        `json:"mfa_enabled"`
+		Profile     UserProfile `json:"profile"`
+		SuspendedAt *time.Time  `json:"suspended_at"`
+		CreatedAt   time.Time   `json:"created_at"`
+	}
+
+	PersonalDataIdentity struct {
+		Provider  string    `json:"provider"`
+		Email     string    `json:"email"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	PersonalDataTicket struct {
+		TicketID         string    `json:"ticket_id"`
+		EventID          string    `json:"event_id"`
+		EventName        string    `json:"event_name"`
+		Handphone        string    `json:"handphone"`
+		Birthdate        time.Time `json:"birthdate"`
+		Seat             string    `json:"seat"`
+		PaymentFile      string    `json:"payment_file"`
+		PaymentConfirmed bool      `json:"payment_confirmed"`
+		CheckedIn        bool      `json:"checked_in"`
+		Complimentary    bool      `json:"complimentary"`
+		CreatedAt        time.Time `json:"created_at"`
+	}
+
+	PersonalDataPE2RSVP struct {
+		ID                   string     `json:"id"`
+		Name                 string     `json:"name"`
+		Email                string     `json:"email"`
+		Institute            string     `json:"institute"`
+		Department           string     `json:"department"`
+		StudentID            string     `json:"student_id"`
+		Batch                string     `json:"batch"`
+		WillingToCome        bool       `json:"willing_to_come"`
+		WillingToBeContacted bool       `json:"willing_to_be_contacted"`
+		Attended             bool       `json:"attended"`
+		Essay                string     `json:"essay"`
+		Status               string     `json:"status"`
+		WithdrawnAt          *time.Time `json:"withdrawn_at"`
+	}
+
+	PersonalDataCertificate struct {
+		Code      string    `json:"code"`
+		Name      string    `json:"name"`
+		EventName string    `json:"event_name"`
+		EventDate time.Time `json:"event_date"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	PersonalDataSurveyInvitation struct {
+		Survey      string     `json:"survey"`
+		EventID     string     `json:"event_id"`
+		SentAt      *time.Time `json:"sent_at"`
+		CompletedAt *time.Time `json:"completed_at"`
+	}
+)
@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_ASSIGN_REVIEWER = "failed assign reviewer"
+	MESSAGE_FAILED_GET_REVIEW      = "failed get review"
+	MESSAGE_FAILED_SUBMIT_REVIEW   = "failed submit review"
+	MESSAGE_FAILED_DECIDE_RSVP     = "failed decide RSVP"
+	MESSAGE_FAILED_GET_SIMILARITY  = "failed get essay similarity"
+
+	// Success
+	MESSAGE_SUCCESS_ASSIGN_REVIEWER = "success assign reviewer"
+	MESSAGE_SUCCESS_GET_REVIEW      = "success get review"
+	MESSAGE_SUCCESS_SUBMIT_REVIEW   = "success submit review"
+	MESSAGE_SUCCESS_DECIDE_RSVP     = "success decide RSVP"
+	MESSAGE_SUCCESS_GET_SIMILARITY  = "success get essay similarity"
+
+	PE2_ESSAY_SIMILARITY_THRESHOLD = 0.3
+)
+
+var (
+	ErrReviewerNotFound        = errors.New("reviewer not found")
+	ErrReviewerNotAllowed      = errors.New("reviewer role must hold pe2.review and can not be admin")
+	ErrNoRSVPToAssign          = errors.New("no RSVP to assign")
+	ErrReviewNotFound          = errors.New("review not found")
+	ErrReviewNotAssignedToYou  = errors.New("review is not assigned to you")
+	ErrReviewCriterionMissing  = errors.New("every rubric criterion must be scored")
+	ErrReviewScoreOutOfRange   = errors.New("score is out of the rubric range")
+	ErrReviewCriterionInvalid  = errors.New("unknown rubric criterion")
+	ErrPE2RSVPStatusInvalid    = errors.New("status must be accepted, rejected or waitlisted")
+	ErrPE2RSVPNotAccepted      = errors.New("attendee is not accepted")
+	ErrGenerateDecisionEmail   = errors.New("failed to generate decision email")
+	ErrPE2RSVPAlreadyDecidedAs = errors.New("RSVP already has this status")
+	ErrSimilarityThreshold     = errors.New("threshold must be between 0 and 1")
+)
+
+type (
+	PE2ReviewAssignRequest struct {
+		ReviewerIDs    []string `json:"reviewer_ids" form:"reviewer_ids" binding:"required"`
+		RSVPIDs        []string `json:"rsvp_ids" form:"rsvp_ids"`
+		ReviewsPerRSVP int      `json:"reviews_per_rsvp" form:"reviews_per_rsvp"`
+	}
+
+	PE2ReviewAssignResponse struct {
+		Assigned int64 `json:"assigned"`
+	}
+
+	PE2ReviewSubmitRequest struct {
+		Scores  map[string]int `json:"scores" form:"scores" binding:"required"`
+		Comment string         `json:"comment" form:"comment"`
+	}
+
+	PE2ReviewResponse struct {
+		ID          string         `json:"id"`
+		RSVPID      string         `json:"rsvp_id"`
+		Applicant   string         `json:"applicant,omitempty"`
+		Name        string         `json:"name,omitempty"`
+		Institute   string         `json:"institute,omitempty"`
+		Department  string         `json:"department,omitempty"`
+		Batch       string         `json:"batch,omitempty"`
+		Essay       string         `json:"essay,omitempty"`
+		ReviewerID  string         `json:"reviewer_id,omitempty"`
+		Reviewer    string         `json:"reviewer,omitempty"`
+		Scores      map[string]int `json:"scores"`
+		TotalScore  int            `json:"total_score"`
+		Comment     string         `json:"comment"`
+		SubmittedAt *time.Time     `json:"submitted_at"`
+	}
+
+	PE2ReviewSummaryData struct {
+		ID           uuid.UUID `json:"id"`
+		Name         string    `json:"name"`
+		Institute    string    `json:"institute"`
+		Status       string    `json:"status"`
+		Assigned     int64     `json:"assigned"`
+		Reviewed     int64     `json:"reviewed"`
+		AverageScore float64   `json:"average_score"`
+	}
+
+	PE2ReviewSummaryPaginationResponse struct {
+		Data []PE2ReviewSummaryData `json:"data"`
+		PaginationMetadata
+	}
+
+	PE2RSVPDecisionRequest struct {
+		Status string `json:"status" form:"status" binding:"required"`
+	}
+
+	PE2EssaySimilarityQuery struct {
+		Threshold float64 `json:"threshold" form:"threshold"`
+	}
+
+	PE2EssaySimilarityResponse struct {
+		Similarity float64                 `json:"similarity"`
+		First      PE2EssaySimilarityEssay `json:"first"`
+		Second     PE2EssaySimilarityEssay `json:"second"`
+	}
+
+	PE2EssaySimilarityEssay struct {
+		RSVPID    string            `json:"rsvp_id"`
+		Name      string            `json:"name"`
+		Institute string            `json:"institute"`
+		Status    string            `json:"status"`
+		Segments  []PE2EssaySegment `json:"segments"`
+	}
+
+	PE2EssaySegment struct {
+		Text    string `json:"text"`
+		Overlap bool   `json:"overlap"`
+	}
+)
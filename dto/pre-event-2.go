@@ -2,6 +2,7 @@ package dto
 
 import (
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,6 +13,11 @@ const (
 
 	// Success
 	MESSAGE_SUCCESS_EVENT = "success get event"
+
+	MESSAGE_FAILED_MANAGE_RSVP       = "failed manage RSVP"
+	MESSAGE_SUCCESS_UPDATE_RSVP      = "success update RSVP"
+	MESSAGE_SUCCESS_WITHDRAW_RSVP    = "success withdraw RSVP"
+	MESSAGE_SUCCESS_RESEND_RSVP_LINK = "if the email is registered, a new link has been sent"
 )
 
 var (
@@ -21,12 +27,29 @@ var (
 	ErrPE2RSVPFull            = errors.New("pre event 2 RSVP is full")
 	ErrPE2RSVPEmailRegistered = errors.New("email already registered")
 	ErrTicketNotFound         = errors.New("ticket not found")
+	ErrPE2RSVPNotWillingCome  = errors.New("attendee did not RSVP to come")
+	ErrPE2RSVPLinkInvalid     = errors.New("RSVP link is invalid or expired")
+	ErrPE2RSVPWithdrawn       = errors.New("RSVP has been withdrawn")
+	ErrPE2RSVPEssayLocked     = errors.New("essay can no longer be changed once the RSVP has been decided")
 )
 
 type (
+	// signed in users may leave out what their profile already holds
 	PE2RSVPRequest struct {
+		Name       string `json:"name" form:"name"`
+		Email      string `json:"email" form:"email"`
+		Institute  string `json:"institute" form:"institute"`
+		Department string `json:"department" form:"department"`
+		StudentID  string `json:"student_id" form:"student_id"`
+		Batch      string `json:"batch" form:"batch"`
+
+		WillingToCome        bool   `json:"willing_to_come" form:"willing_to_come" binding:"boolean"`
+		WillingToBeContacted bool   `json:"willing_to_be_contacted" form:"willing_to_be_contacted" binding:"boolean"`
+		Essay                string `json:"essay" form:"essay" binding:"required"`
+	}
+
+	PE2RSVPUpdateRequest struct {
 		Name       string `json:"name" form:"name" binding:"required"`
-		Email      string `json:"email" form:"email" binding:"required"`
 		Institute  string `json:"institute" form:"institute" binding:"required"`
 		Department string `json:"department" form:"department"`
 		StudentID  string `json:"student_id" form:"student_id"`
@@ -37,6 +60,14 @@ type (
 		Essay                string `json:"essay" form:"essay" binding:"required"`
 	}
 
+	PE2RSVPResendLinkRequest struct {
+		Email string `json:"email" form:"email" binding:"required"`
+	}
+
+	PE2RSVPCheckInRequest struct {
+		ID string `json:"id" form:"id" binding:"required"`
+	}
+
 	PE2RSVPResponse struct {
 		ID         uuid.UUID `json:"id" form:"id"`
 		Name       string    `json:"name" form:"name"`
@@ -48,12 +79,19 @@ type (
 
 		WillingToCome        bool   `json:"willing_to_come" form:"willing_to_come"`
 		WillingToBeContacted bool   `json:"willing_to_be_contacted" form:"willing_to_be_contacted"`
+		Attended             bool   `json:"attended" form:"attended"`
 		Essay                string `json:"essay" form:"essay"`
+
+		Status             string     `json:"status" form:"status"`
+		DecidedAt          *time.Time `json:"decided_at" form:"decided_at"`
+		DecisionNotifiedAt *time.Time `json:"decision_notified_at" form:"decision_notified_at"`
+		WithdrawnAt        *time.Time `json:"withdrawn_at" form:"withdrawn_at"`
 	}
 
 	PE2RSVPCounter struct {
-		Total   int64 `json:"total" form:"total"`
-		Attends int64 `json:"attends" form:"attends"`
+		Total    int64 `json:"total" form:"total"`
+		Attends  int64 `json:"attends" form:"attends"`
+		Accepted int64 `json:"accepted" form:"accepted"`
 	}
 
 	PE2RSVPPaginationData struct {
@@ -63,6 +101,7 @@ type (
 		Batch                string    `json:"batch" form:"batch"`
 		WillingToCome        bool      `json:"willing_to_come" form:"willing_to_come"`
 		WillingToBeContacted bool      `json:"willing_to_be_contacted" form:"willing_to_be_contacted"`
+		Status               string    `json:"status" form:"status"`
 	}
 
 	PE2RSVPPaginationResponse struct {
@@ -17,9 +17,10 @@ var (
 )
 
 type (
+	// handphone and birthdate left out are taken from the profile
 	PE3RSVPRegister struct {
-		Handphone   string                `json:"handphone" form:"handphone" binding:"required"`
-		Birthdate   time.Time             `json:"birthdate" form:"birthdate" binding:"required"`
+		Handphone   string                `json:"handphone" form:"handphone"`
+		Birthdate   time.Time             `json:"birthdate" form:"birthdate"`
 		PaymentFile *multipart.FileHeader `json:"payment_file" form:"payment_file" binding:"required"`
 	}
 
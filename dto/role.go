@@ -0,0 +1,42 @@
+package dto
+
+import "errors"
+
+const (
+	// Failed
+	MESSAGE_FAILED_GET_ROLE    = "failed get role"
+	MESSAGE_FAILED_CREATE_ROLE = "failed create role"
+	MESSAGE_FAILED_UPDATE_ROLE = "failed update role"
+	MESSAGE_FAILED_DELETE_ROLE = "failed delete role"
+
+	// Success
+	MESSAGE_SUCCESS_GET_ROLE        = "success get role"
+	MESSAGE_SUCCESS_CREATE_ROLE     = "success create role"
+	MESSAGE_SUCCESS_UPDATE_ROLE     = "success update role"
+	MESSAGE_SUCCESS_DELETE_ROLE     = "success delete role"
+	MESSAGE_SUCCESS_GET_PERMISSIONS = "success get permissions"
+)
+
+var (
+	ErrPermissionNotAllowed = errors.New("denied access without \"%v\" permission")
+	ErrRoleNotFound         = errors.New("role not found")
+	ErrRoleNameTaken        = errors.New("role name already exist")
+	ErrRoleBuiltIn          = errors.New("built-in roles can not be changed")
+	ErrRoleInUse            = errors.New("role is still assigned to users")
+	ErrPermissionInvalid    = errors.New("permission \"%v\" does not exist")
+	ErrPermissionConflict   = errors.New("permission \"%v\" can not be combined with \"%v\"")
+)
+
+type (
+	RoleRequest struct {
+		Name        string   `json:"name" form:"name" binding:"required"`
+		Permissions []string `json:"permissions" form:"permissions"`
+	}
+
+	RoleResponse struct {
+		ID          string   `json:"id"`
+		Name        string   `json:"name"`
+		Permissions []string `json:"permissions"`
+		BuiltIn     bool     `json:"built_in"`
+	}
+)
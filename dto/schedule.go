@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_CREATE_SESSION  = "failed create session"
+	MESSAGE_FAILED_UPDATE_SESSION  = "failed update session"
+	MESSAGE_FAILED_DELETE_SESSION  = "failed delete session"
+	MESSAGE_FAILED_GET_SCHEDULE    = "failed get schedule"
+	MESSAGE_FAILED_CREATE_CALENDAR = "failed create calendar link"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_SESSION  = "success create session"
+	MESSAGE_SUCCESS_UPDATE_SESSION  = "success update session"
+	MESSAGE_SUCCESS_DELETE_SESSION  = "success delete session"
+	MESSAGE_SUCCESS_GET_SCHEDULE    = "success get schedule"
+	MESSAGE_SUCCESS_CREATE_CALENDAR = "success create calendar link"
+)
+
+var (
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrSessionTypeInvalid  = errors.New("session type must be talk, performance, ceremony, break or networking")
+	ErrSessionTimeInvalid  = errors.New("session must end after it starts")
+	ErrCalendarFeedInvalid = errors.New("calendar feed link is invalid")
+)
+
+type (
+	SessionRequest struct {
+		EventID     string    `json:"event_id" form:"event_id" binding:"required"`
+		SpeakerID   string    `json:"speaker_id" form:"speaker_id"`
+		Title       string    `json:"title" form:"title" binding:"required"`
+		Description string    `json:"description" form:"description"`
+		Type        string    `json:"type" form:"type" binding:"required"`
+		Stage       string    `json:"stage" form:"stage"`
+		StartTime   time.Time `json:"start_time" form:"start_time" binding:"required"`
+		EndTime     time.Time `json:"end_time" form:"end_time" binding:"required"`
+	}
+
+	SessionResponse struct {
+		ID          string          `json:"id"`
+		EventID     string          `json:"event_id"`
+		EventName   string          `json:"event_name"`
+		Title       string          `json:"title"`
+		Description string          `json:"description"`
+		Type        string          `json:"type"`
+		Stage       string          `json:"stage"`
+		StartTime   time.Time       `json:"start_time"`
+		EndTime     time.Time       `json:"end_time"`
+		Speaker     *SessionSpeaker `json:"speaker,omitempty"`
+	}
+
+	SessionSpeaker struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Headline string `json:"headline"`
+		Photo    string `json:"photo"`
+	}
+
+	CalendarFeedResponse struct {
+		URL string `json:"url"`
+	}
+)
@@ -0,0 +1,81 @@
+package dto
+
+import (
+	"errors"
+	"mime/multipart"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_CREATE_SPEAKER = "failed create speaker"
+	MESSAGE_FAILED_GET_SPEAKER    = "failed get speaker"
+	MESSAGE_FAILED_UPDATE_SPEAKER = "failed update speaker"
+	MESSAGE_FAILED_DELETE_SPEAKER = "failed delete speaker"
+	MESSAGE_FAILED_CREATE_TALK    = "failed create talk"
+	MESSAGE_FAILED_UPDATE_TALK    = "failed update talk"
+	MESSAGE_FAILED_DELETE_TALK    = "failed delete talk"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_SPEAKER = "success create speaker"
+	MESSAGE_SUCCESS_GET_SPEAKER    = "success get speaker"
+	MESSAGE_SUCCESS_UPDATE_SPEAKER = "success update speaker"
+	MESSAGE_SUCCESS_DELETE_SPEAKER = "success delete speaker"
+	MESSAGE_SUCCESS_CREATE_TALK    = "success create talk"
+	MESSAGE_SUCCESS_UPDATE_TALK    = "success update talk"
+	MESSAGE_SUCCESS_DELETE_TALK    = "success delete talk"
+)
+
+var (
+	ErrSpeakerNotFound           = errors.New("speaker not found")
+	ErrTalkNotFound              = errors.New("talk not found")
+	ErrFailedToStoreSpeakerPhoto = errors.New("failed to store speaker photo")
+)
+
+type (
+	SpeakerRequest struct {
+		Name      string                `json:"name" form:"name" binding:"required"`
+		Headline  string                `json:"headline" form:"headline"`
+		Bio       string                `json:"bio" form:"bio"`
+		Position  int                   `json:"position" form:"position"`
+		Instagram string                `json:"instagram" form:"instagram"`
+		LinkedIn  string                `json:"linkedin" form:"linkedin"`
+		Twitter   string                `json:"twitter" form:"twitter"`
+		Website   string                `json:"website" form:"website"`
+		PublishAt *time.Time            `json:"publish_at" form:"publish_at" time_format:"2006-01-02T15:04:05Z07:00"`
+		Photo     *multipart.FileHeader `json:"photo" form:"photo"`
+	}
+
+	TalkRequest struct {
+		SpeakerID string `json:"speaker_id" form:"speaker_id" binding:"required"`
+		Title     string `json:"title" form:"title" binding:"required"`
+		Abstract  string `json:"abstract" form:"abstract"`
+	}
+
+	SpeakerResponse struct {
+		ID        string         `json:"id"`
+		Name      string         `json:"name"`
+		Headline  string         `json:"headline"`
+		Bio       string         `json:"bio"`
+		Photo     string         `json:"photo"`
+		Position  int            `json:"position"`
+		Socials   SpeakerSocials `json:"socials"`
+		PublishAt *time.Time     `json:"publish_at,omitempty"`
+		Published bool           `json:"published"`
+		Talks     []TalkResponse `json:"talks"`
+	}
+
+	SpeakerSocials struct {
+		Instagram string `json:"instagram,omitempty"`
+		LinkedIn  string `json:"linkedin,omitempty"`
+		Twitter   string `json:"twitter,omitempty"`
+		Website   string `json:"website,omitempty"`
+	}
+
+	TalkResponse struct {
+		ID        string `json:"id"`
+		SpeakerID string `json:"speaker_id"`
+		Title     string `json:"title"`
+		Abstract  string `json:"abstract"`
+	}
+)
@@ -0,0 +1,87 @@
+package dto
+
+import (
+	"errors"
+	"mime/multipart"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_CREATE_SPONSOR           = "failed create sponsor"
+	MESSAGE_FAILED_GET_SPONSOR              = "failed get sponsor"
+	MESSAGE_FAILED_UPDATE_SPONSOR           = "failed update sponsor"
+	MESSAGE_FAILED_DELETE_SPONSOR           = "failed delete sponsor"
+	MESSAGE_FAILED_CREATE_SPONSOR_PLACEMENT = "failed create sponsor placement"
+	MESSAGE_FAILED_GET_SPONSOR_REPORT       = "failed get sponsor report"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_SPONSOR           = "success create sponsor"
+	MESSAGE_SUCCESS_GET_SPONSOR              = "success get sponsor"
+	MESSAGE_SUCCESS_UPDATE_SPONSOR           = "success update sponsor"
+	MESSAGE_SUCCESS_DELETE_SPONSOR           = "success delete sponsor"
+	MESSAGE_SUCCESS_CREATE_SPONSOR_PLACEMENT = "success create sponsor placement"
+	MESSAGE_SUCCESS_GET_SPONSOR_REPORT       = "success get sponsor report"
+)
+
+var (
+	ErrSponsorNotFound           = errors.New("sponsor not found")
+	ErrSponsorTierInvalid        = errors.New("tier should be one of platinum, gold or media_partner")
+	ErrSponsorPlacementInvalid   = errors.New("placement should be one of website, instagram, email, booklet or stage")
+	ErrSponsorPlacementExist     = errors.New("sponsor already has a link for this placement")
+	ErrFailedToStoreSponsorLogo  = errors.New("failed to store sponsor logo")
+	ErrCreateSponsorTrackingLink = errors.New("failed to create sponsor tracking link")
+)
+
+type (
+	SponsorRequest struct {
+		Name         string                `json:"name" form:"name" binding:"required"`
+		Tier         string                `json:"tier" form:"tier" binding:"required"`
+		Description  string                `json:"description" form:"description"`
+		DisplayOrder int                   `json:"display_order" form:"display_order"`
+		Website      string                `json:"website" form:"website" binding:"required"`
+		Instagram    string                `json:"instagram" form:"instagram"`
+		Logo         *multipart.FileHeader `json:"logo" form:"logo"`
+	}
+
+	SponsorPlacementRequest struct {
+		Placement string `json:"placement" form:"placement" binding:"required"`
+	}
+
+	SponsorQuery struct {
+		Tier string `json:"tier" form:"tier"`
+	}
+
+	SponsorResponse struct {
+		ID           string                     `json:"id"`
+		Name         string                     `json:"name"`
+		Tier         string                     `json:"tier"`
+		Description  string                     `json:"description"`
+		Logo         string                     `json:"logo"`
+		DisplayOrder int                        `json:"display_order"`
+		Link         string                     `json:"link"`
+		Instagram    string                     `json:"instagram,omitempty"`
+		Website      string                     `json:"website,omitempty"`
+		Placements   []SponsorPlacementResponse `json:"placements,omitempty"`
+	}
+
+	SponsorTierResponse struct {
+		Tier     string            `json:"tier"`
+		Sponsors []SponsorResponse `json:"sponsors"`
+	}
+
+	SponsorPlacementResponse struct {
+		ID        string `json:"id"`
+		Placement string `json:"placement"`
+		Alias     string `json:"alias"`
+		URL       string `json:"url"`
+		Clicks    int64  `json:"clicks"`
+	}
+
+	SponsorReportResponse struct {
+		ID          string                     `json:"id"`
+		Name        string                     `json:"name"`
+		Tier        string                     `json:"tier"`
+		TotalClicks int64                      `json:"total_clicks"`
+		Placements  []SponsorPlacementResponse `json:"placements"`
+	}
+)
@@ -6,10 +6,14 @@ const (
 	MESSAGE_SUCCESS_GET_FILE = "success get file"
 
 	ENUM_STORAGE_FOLDER_MAIN_EVENT = "main-event"
+	ENUM_STORAGE_FOLDER_SPEAKER    = "speaker"
+	ENUM_STORAGE_FOLDER_SPONSOR    = "sponsor"
 	ENUM_FILE_TYPE_JPEG            = "image/jpeg"
 	ENUM_FILE_TYPE_PNG             = "image/png"
 
 	STORAGE_ENDPOINT_MAIN_EVENT = "/storage/main-event/"
+	STORAGE_ENDPOINT_SPEAKER    = "/storage/speaker/"
+	STORAGE_ENDPOINT_SPONSOR    = "/storage/sponsor/"
 
 	MB = 1 << 20
 )
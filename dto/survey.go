@@ -0,0 +1,139 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_CREATE_SURVEY      = "failed create survey"
+	MESSAGE_FAILED_GET_SURVEY         = "failed get survey"
+	MESSAGE_FAILED_SEND_SURVEY_INVITE = "failed send survey invitation"
+	MESSAGE_FAILED_SUBMIT_SURVEY      = "failed submit survey"
+	MESSAGE_FAILED_GET_SURVEY_RESULT  = "failed get survey result"
+
+	// Success
+	MESSAGE_SUCCESS_CREATE_SURVEY      = "success create survey"
+	MESSAGE_SUCCESS_GET_SURVEY         = "success get survey"
+	MESSAGE_SUCCESS_SEND_SURVEY_INVITE = "success send survey invitation"
+	MESSAGE_SUCCESS_SUBMIT_SURVEY      = "success submit survey"
+	MESSAGE_SUCCESS_GET_SURVEY_RESULT  = "success get survey result"
+
+	ENUM_SURVEY_QUESTION_RATING = "rating"
+	ENUM_SURVEY_QUESTION_NPS    = "nps"
+	ENUM_SURVEY_QUESTION_TEXT   = "text"
+
+	SURVEY_DEFAULT_RATING_SCALE = 5
+	SURVEY_NPS_SCALE            = 10
+)
+
+var (
+	ErrSurveyNotFound            = errors.New("survey not found")
+	ErrSurveyNoEvent             = errors.New("survey must be linked to at least one event")
+	ErrSurveyNoQuestion          = errors.New("survey must have at least one question")
+	ErrSurveyQuestionTypeInvalid = errors.New("question type must be rating, nps or text")
+	ErrSurveyInvitationNotFound  = errors.New("survey invitation not found")
+	ErrSurveyAlreadyCompleted    = errors.New("survey already completed")
+	ErrSurveyClosed              = errors.New("survey is closed")
+	ErrSurveyAnswerMissing       = errors.New("required question is not answered")
+	ErrSurveyAnswerOutOfScale    = errors.New("answer score is out of scale")
+	ErrSurveyNoAttendee          = errors.New("no checked in attendees left to invite")
+	ErrSurveyNotCompleted        = errors.New("please complete the event feedback survey first")
+)
+
+type (
+	SurveyRequest struct {
+		Title                  string                  `json:"title" form:"title" binding:"required"`
+		Description            string                  `json:"description" form:"description"`
+		EventIDs               []string                `json:"event_ids" form:"event_ids" binding:"required"`
+		RequiredForCertificate bool                    `json:"required_for_certificate" form:"required_for_certificate"`
+		ClosedAt               time.Time               `json:"closed_at" form:"closed_at"`
+		Questions              []SurveyQuestionRequest `json:"questions" form:"questions" binding:"required,dive"`
+	}
+
+	SurveyQuestionRequest struct {
+		Type     string `json:"type" form:"type" binding:"required"`
+		Prompt   string `json:"prompt" form:"prompt" binding:"required"`
+		Scale    int    `json:"scale" form:"scale"`
+		Required bool   `json:"required" form:"required"`
+	}
+
+	SurveyDetailResponse struct {
+		ID                     string                   `json:"id"`
+		Title                  string                   `json:"title"`
+		Description            string                   `json:"description"`
+		RequiredForCertificate bool                     `json:"required_for_certificate"`
+		ClosedAt               time.Time                `json:"closed_at"`
+		Events                 []EventResponse          `json:"events,omitempty"`
+		Questions              []SurveyQuestionResponse `json:"questions"`
+	}
+
+	SurveyQuestionResponse struct {
+		ID       string `json:"id"`
+		Position int    `json:"position"`
+		Type     string `json:"type"`
+		Prompt   string `json:"prompt"`
+		Scale    int    `json:"scale"`
+		Required bool   `json:"required"`
+	}
+
+	SurveyInviteResponse struct {
+		Invited int `json:"invited"`
+	}
+
+	SurveySubmitRequest struct {
+		Answers []SurveyAnswerRequest `json:"answers" form:"answers" binding:"required,dive"`
+	}
+
+	SurveyAnswerRequest struct {
+		QuestionID string `json:"question_id" form:"question_id" binding:"required"`
+		Score      *int   `json:"score" form:"score"`
+		Text       string `json:"text" form:"text"`
+	}
+
+	SurveyResultResponse struct {
+		SurveyID  string                 `json:"survey_id"`
+		Invited   int64                  `json:"invited"`
+		Completed int64                  `json:"completed"`
+		Questions []SurveyQuestionResult `json:"questions"`
+		PerTier   []SurveyTierResult     `json:"per_tier"`
+	}
+
+	SurveyTierResult struct {
+		EventID   string                 `json:"event_id"`
+		EventName string                 `json:"event_name"`
+		Responses int64                  `json:"responses"`
+		Questions []SurveyQuestionResult `json:"questions"`
+	}
+
+	SurveyQuestionResult struct {
+		QuestionID   string           `json:"question_id"`
+		Prompt       string           `json:"prompt"`
+		Type         string           `json:"type"`
+		Answers      int64            `json:"answers"`
+		Average      float64          `json:"average,omitempty"`
+		Distribution map[int]int64    `json:"distribution,omitempty"`
+		NPS          *SurveyNPSResult `json:"nps,omitempty"`
+	}
+
+	SurveyNPSResult struct {
+		Promoters  int64   `json:"promoters"`
+		Passives   int64   `json:"passives"`
+		Detractors int64   `json:"detractors"`
+		Score      float64 `json:"score"`
+	}
+
+	// raw aggregation rows, grouped with SQL instead of loading every answer
+	SurveyAnswerAggregate struct {
+		QuestionID string
+		EventID    string
+		Score      *int
+		Total      int64
+	}
+
+	SurveyResponseCount struct {
+		EventID string
+		Total   int64
+	}
+)
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// Failed
+	MESSAGE_FAILED_CHANGE_USER_ROLE     = "failed change user role"
+	MESSAGE_FAILED_SUSPEND_USER         = "failed suspend user"
+	MESSAGE_FAILED_REACTIVATE_USER      = "failed reactivate user"
+	MESSAGE_FAILED_DELETE_USER          = "failed delete user"
+	MESSAGE_FAILED_FORCE_VERIFY_USER    = "failed force verify user"
+	MESSAGE_FAILED_FORCE_RESET_PASSWORD = "failed force reset password"
+	MESSAGE_FAILED_GET_AUDIT_LOG        = "failed get audit log"
+
+	// Success
+	MESSAGE_SUCCESS_CHANGE_USER_ROLE     = "success change user role"
+	MESSAGE_SUCCESS_SUSPEND_USER         = "success suspend user"
+	MESSAGE_SUCCESS_REACTIVATE_USER      = "success reactivate user"
+	MESSAGE_SUCCESS_DELETE_USER          = "success delete user"
+	MESSAGE_SUCCESS_FORCE_VERIFY_USER    = "success force verify user"
+	MESSAGE_SUCCESS_FORCE_RESET_PASSWORD = "success force reset password"
+	MESSAGE_SUCCESS_GET_AUDIT_LOG        = "success get audit log"
+)
+
+var (
+	ErrManageSelf            = errors.New("can not perform this action on your own account")
+	ErrManageAdminNotAllowed = errors.New("only admins can grant the admin role or act on admin accounts")
+	ErrUserAlreadySuspended  = errors.New("user already suspended")
+	ErrUserNotSuspended      = errors.New("user is not suspended")
+	ErrUserAlreadyHasRole    = errors.New("user already has this role")
+	ErrAuditLog              = errors.New("failed to write audit log")
+)
+
+type (
+	UserRoleRequest struct {
+		RoleID string `json:"role_id" form:"role_id" binding:"required"`
+	}
+
+	UserSuspendRequest struct {
+		Reason string `json:"reason" form:"reason" binding:"required"`
+	}
+
+	AuditLogQuery struct {
+		TargetID string `form:"target_id"`
+		Page     int    `form:"page"`
+		PerPage  int    `form:"per_page"`
+	}
+
+	AuditLogResponse struct {
+		ID         string    `json:"id"`
+		ActorID    string    `json:"actor_id"`
+		ActorName  string    `json:"actor_name"`
+		Action     string    `json:"action"`
+		TargetType string    `json:"target_type"`
+		TargetID   string    `json:"target_id"`
+		Detail     string    `json:"detail"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	AuditLogPaginationResponse struct {
+		Data []AuditLogResponse `json:"data"`
+		PaginationMetadata
+	}
+)
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"errors"
+	"time"
 )
 
 const (
@@ -14,6 +15,9 @@ const (
 	MESSAGE_FAILED_RESEND_VERIFY_EMAIL       = "failed resend verify email"
 	MESSAGE_FAILED_RESET_PASSWORD            = "failed reset password"
 	MESSAGE_FAILED_SEND_RESET_PASSWORD_EMAIL = "failed send reset password email"
+	MESSAGE_FAILED_CHANGE_PASSWORD           = "failed change password"
+	MESSAGE_FAILED_CHANGE_EMAIL              = "failed change email"
+	MESSAGE_FAILED_CONFIRM_EMAIL             = "failed confirm email"
 
 	// Success
 	MESSAGE_SUCCESS_REGISTER_USER             = "success create user. Please verify your email to activate your account"
@@ -24,6 +28,9 @@ const (
 	MESSAGE_SUCCESS_RESEND_VERIFY_EMAIL       = "success resend verify email"
 	MESSAGE_SUCCESS_RESET_PASSWORD            = "success reset password"
 	MESSAGE_SUCCESS_SEND_RESET_PASSWORD_EMAIL = "success send reset password email"
+	MESSAGE_SUCCESS_CHANGE_PASSWORD           = "success change password"
+	MESSAGE_SUCCESS_CHANGE_EMAIL              = "success change email. Please confirm through the link sent to the new address"
+	MESSAGE_SUCCESS_CONFIRM_EMAIL             = "success confirm email"
 )
 
 var (
@@ -35,6 +42,7 @@ var (
 	ErrUserNotFound                 = errors.New("user not found")
 	ErrCredentialsNotMatched        = errors.New("credentials not matched")
 	ErrAccountNotVerified           = errors.New("account not verified")
+	ErrAccountSuspended             = errors.New("account suspended")
 	ErrEmailFormatInvalid           = errors.New("email format invalid")
 	ErrAccountAlreadyVerified       = errors.New("account already verified")
 	ErrGenerateVerificationEmail    = errors.New("failed to generate verification email")
@@ -45,6 +53,20 @@ var (
 	ErrHashPassword                 = errors.New("failed to hash password")
 	ErrGenerateResetPasswordEmail   = errors.New("failed to generate reset password email")
 	ErrAdminNotAllowedResetPassword = errors.New("admin not allowed to reset password")
+	ErrPasswordNotMatched           = errors.New("current password not matched")
+	ErrSameEmail                    = errors.New("new email is the same as the current one")
+	ErrGenerateChangeEmail          = errors.New("failed to generate change email")
+
+	// profile, also returned when a registration can not be completed from it
+	ErrNameRequired      = errors.New("name is required")
+	ErrEmailRequired     = errors.New("email is required")
+	ErrInstituteRequired = errors.New("institute is required")
+	ErrHandphoneRequired = errors.New("handphone is required, fill it in or save it to your profile")
+	ErrHandphoneInvalid  = errors.New("handphone must be 8 to 15 digits")
+	ErrBirthdateRequired = errors.New("birthdate is required, fill it in or save it to your profile")
+	ErrBirthdateInvalid  = errors.New("birthdate is invalid")
+	ErrStudentIDInvalid  = errors.New("student id must be letters and digits only, at most 20 characters")
+	ErrBatchInvalid      = errors.New("batch must be the year of enrollment")
 )
 
 type (
@@ -54,18 +76,55 @@ type (
 		Password string `json:"password" form:"password" binding:"required"`
 	}
 
+	// profile fields left out keep their value, an empty one is cleared
+	UserUpdateRequest struct {
+		Name       string     `json:"name" form:"name" binding:"required"`
+		Handphone  *string    `json:"handphone" form:"handphone"`
+		Birthdate  *time.Time `json:"birthdate" form:"birthdate"`
+		Institute  *string    `json:"institute" form:"institute"`
+		Department *string    `json:"department" form:"department"`
+		StudentID  *string    `json:"student_id" form:"student_id"`
+		Batch      *string    `json:"batch" form:"batch"`
+	}
+
+	UserProfile struct {
+		Handphone  string     `json:"handphone"`
+		Birthdate  *time.Time `json:"birthdate"`
+		Institute  string     `json:"institute"`
+		Department string     `json:"department"`
+		StudentID  string     `json:"student_id"`
+		Batch      string     `json:"batch"`
+	}
+
+	UserChangePasswordRequest struct {
+		CurrentPassword string `json:"current_password" form:"current_password" binding:"required"`
+		NewPassword     string `json:"new_password" form:"new_password" binding:"required"`
+	}
+
+	UserChangeEmailRequest struct {
+		Email    string `json:"email" form:"email" binding:"required"`
+		Password string `json:"password" form:"password" binding:"required"`
+	}
+
+	UserConfirmEmailRequest struct {
+		Token string `json:"token" form:"token" binding:"required"`
+	}
+
 	UserLoginRequest struct {
 		Email    string `json:"email" form:"email" binding:"required"`
 		Password string `json:"password" form:"password" binding:"required"`
 	}
 
 	UserResponse struct {
-		ID         string `json:"id"`
-		Name       string `json:"name"`
-		Email      string `json:"email"`
-		RoleID     string `json:"role_id,omitempty"`
-		Role       string `json:"role,omitempty"`
-		IsVerified bool   `json:"is_verified"`
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Email       string `json:"email"`
+		RoleID      string `json:"role_id,omitempty"`
+		Role        string `json:"role,omitempty"`
+		IsVerified  bool   `json:"is_verified"`
+		IsSuspended bool   `json:"is_suspended"`
+
+		Profile *UserProfile `json:"profile,omitempty"`
 	}
 
 	UserPaginationResponse struct {
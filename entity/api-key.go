@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// only the hash of a key is stored, the key itself is shown once when it is created
+type APIKey struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name        string     `json:"name"`
+	Prefix      string     `json:"prefix"`
+	KeyHash     string     `json:"-" gorm:"uniqueIndex"`
+	CreatedByID uuid.UUID  `json:"created_by_id" gorm:"type:uuid"`
+	ExpiresAt   *time.Time `json:"expires_at" gorm:"type:timestamp without time zone"`
+	LastUsedAt  *time.Time `json:"last_used_at" gorm:"type:timestamp without time zone"`
+	RevokedAt   *time.Time `json:"revoked_at" gorm:"type:timestamp without time zone"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"type:timestamp without time zone"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamp without time zone"`
+
+	Permissions []APIKeyPermission `json:"permissions,omitempty" gorm:"foreignKey:APIKeyID;constraint:OnDelete:CASCADE"`
+	Events      []APIKeyEvent      `json:"events,omitempty" gorm:"foreignKey:APIKeyID;constraint:OnDelete:CASCADE"`
+	CreatedBy   *User              `json:"created_by,omitempty" gorm:"foreignKey:CreatedByID"`
+}
+
+type APIKeyPermission struct {
+	APIKeyID   uuid.UUID `json:"api_key_id" gorm:"type:uuid;primaryKey"`
+	Permission string    `json:"permission" gorm:"primaryKey"`
+}
+
+// a key without events may be used for every event
+type APIKeyEvent struct {
+	APIKeyID uuid.UUID `json:"api_key_id" gorm:"type:uuid;primaryKey"`
+	EventID  uuid.UUID `json:"event_id" gorm:"type:uuid;primaryKey"`
+}
@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// append only record of privileged actions, never soft deleted. The detail is
+// only ever blanked, when it names an account that is being anonymised.
+type AuditLog struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	ActorID    uuid.UUID `json:"actor_id" gorm:"type:uuid;index"`
+	Action     string    `json:"action" gorm:"index"`
+	TargetType string    `json:"target_type"`
+	TargetID   string    `json:"target_id" gorm:"index"`
+	Detail     string    `json:"detail"`
+	CreatedAt  time.Time `json:"created_at" gorm:"type:timestamp without time zone;index"`
+
+	Actor *User `json:"actor,omitempty" gorm:"foreignKey:ActorID"`
+}
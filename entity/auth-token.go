@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// every login starts a family, each refresh replaces the token inside it.
+	// Presenting a token that was already replaced revokes the whole family.
+	RefreshToken struct {
+		ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+		FamilyID  uuid.UUID  `json:"family_id" gorm:"type:uuid;index"`
+		TokenHash string     `json:"-" gorm:"uniqueIndex"`
+		AccessJTI string     `json:"-" gorm:"index"`
+		UserAgent string     `json:"user_agent"`
+		ExpiresAt time.Time  `json:"expires_at" gorm:"type:timestamp without time zone"`
+		RevokedAt *time.Time `json:"revoked_at" gorm:"type:timestamp without time zone"`
+
+		Timestamp
+	}
+
+	// access tokens revoked before their expiry, kept only until they would have expired anyway
+	RevokedToken struct {
+		JTI       string    `json:"jti" gorm:"primaryKey"`
+		ExpiresAt time.Time `json:"expires_at" gorm:"type:timestamp without time zone;index"`
+		CreatedAt time.Time `json:"created_at" gorm:"type:timestamp without time zone"`
+	}
+)
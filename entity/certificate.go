@@ -0,0 +1,22 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Certificate struct {
+	ID        uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Code      string    `json:"code" form:"code" gorm:"uniqueIndex"`
+	EventID   string    `json:"event_id" form:"event_id"`
+	UserID    string    `json:"user_id" form:"user_id" gorm:"default:null"`
+	TicketID  string    `json:"ticket_id" form:"ticket_id" gorm:"default:null"`
+	RSVPID    string    `json:"rsvp_id" form:"rsvp_id" gorm:"default:null"`
+	Email     string    `json:"email" form:"email"`
+	Name      string    `json:"name" form:"name"`
+	EventName string    `json:"event_name" form:"event_name"`
+	EventDate time.Time `json:"event_date" form:"event_date" gorm:"type:timestamp without time zone;default:null"`
+
+	Timestamp
+}
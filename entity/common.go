@@ -13,6 +13,9 @@ type Timestamp struct {
 }
 
 type Authorization struct {
-	Token string `json:"token"`
-	Role  string `json:"role"`
+	Token            string    `json:"token"`
+	Role             string    `json:"role"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
 }
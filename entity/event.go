@@ -15,6 +15,10 @@ type Event struct {
 	Capacity  int `json:"capacity,omitempty" form:"capacity"`
 	Registers int `json:"registers,omitempty" form:"registers"`
 
+	// complimentary tickets have their own quota and never take a public seat
+	CompCapacity  int `json:"comp_capacity,omitempty" form:"comp_capacity"`
+	CompRegisters int `json:"comp_registers,omitempty" form:"comp_registers"`
+
 	EventDate time.Time `json:"event_date" form:"event_date" gorm:"type:timestamp without time zone;default:null"`
 	StartDate time.Time `json:"start_date" form:"start_date" gorm:"type:timestamp without time zone;default:null"`
 	EndDate   time.Time `json:"end_date" form:"end_date" gorm:"type:timestamp without time zone;default:null"`
@@ -3,9 +3,10 @@ package entity
 import "github.com/google/uuid"
 
 type LinkShortener struct {
-	ID    uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()" `
-	Alias string    `json:"alias" form:"alias"`
-	Link  string    `json:"link" form:"link"`
+	ID     uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()" `
+	Alias  string    `json:"alias" form:"alias"`
+	Link   string    `json:"link" form:"link"`
+	Clicks int64     `json:"clicks" form:"clicks" gorm:"default:0"`
 
 	Timestamp
 }
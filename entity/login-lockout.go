@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// failed login attempts counted per email and per ip address, the key
+// holds the normalised value so unknown emails are tracked the same way
+type LoginLockout struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Key             string     `json:"key" gorm:"uniqueIndex"`
+	Kind            string     `json:"kind"`
+	Failures        int        `json:"failures"`
+	LockCount       int        `json:"lock_count"`
+	LastFailedAt    time.Time  `json:"last_failed_at" gorm:"type:timestamp without time zone"`
+	LockedUntil     *time.Time `json:"locked_until" gorm:"type:timestamp without time zone;index"`
+	UnlockTokenHash string     `json:"-" gorm:"index"`
+
+	CreatedAt time.Time `json:"created_at" gorm:"type:timestamp without time zone"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamp without time zone"`
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	PE2Review struct {
+		ID          uuid.UUID  `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		RSVPID      uuid.UUID  `json:"rsvp_id" form:"rsvp_id" gorm:"type:uuid;uniqueIndex:idx_pe2_review_assignment"`
+		ReviewerID  uuid.UUID  `json:"reviewer_id" form:"reviewer_id" gorm:"type:uuid;uniqueIndex:idx_pe2_review_assignment"`
+		TotalScore  int        `json:"total_score" form:"total_score"`
+		Comment     string     `json:"comment" form:"comment"`
+		SubmittedAt *time.Time `json:"submitted_at" form:"submitted_at" gorm:"type:timestamp without time zone"`
+
+		Scores   []PE2ReviewScore `json:"scores,omitempty" gorm:"foreignKey:ReviewID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		RSVP     *PE2RSVP         `json:"rsvp,omitempty" gorm:"foreignKey:RSVPID"`
+		Reviewer *User            `json:"reviewer,omitempty" gorm:"foreignKey:ReviewerID"`
+
+		Timestamp
+	}
+
+	PE2ReviewScore struct {
+		ID        uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		ReviewID  uuid.UUID `json:"review_id" form:"review_id" gorm:"type:uuid"`
+		Criterion string    `json:"criterion" form:"criterion"`
+		Score     int       `json:"score" form:"score"`
+	}
+)
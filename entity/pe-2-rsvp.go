@@ -1,9 +1,9 @@
 package entity
 
 import (
-	"github.com/TEDxITS/website-backend-2024/constants"
+	"time"
+
 	"github.com/google/uuid"
-	"gorm.io/gorm"
 )
 
 type (
@@ -18,29 +18,17 @@ type (
 
 		WillingToCome        *bool  `json:"willing_to_come" form:"willing_to_come"`
 		WillingToBeContacted *bool  `json:"willing_to_be_contacted" form:"willing_to_be_contacted"`
+		Attended             *bool  `json:"attended" form:"attended" gorm:"default:false"`
 		Essay                string `json:"essay" form:"essay" gorm:"comment:How do you see Indonesia in the next 10 years due to the influence of its politics?"`
-	}
-)
-
-func (e *PE2RSVP) AfterCreate(tx *gorm.DB) error {
-	if !*e.WillingToCome {
-		return nil
-	}
 
-	var event Event
-	if err := tx.Model(&Event{}).Where(Event{
-		Name: constants.PE2Name,
-	}).Take(&event).Error; err != nil {
-		return err
-	}
+		Status    string     `json:"status" form:"status" gorm:"default:pending"`
+		DecidedAt *time.Time `json:"decided_at" form:"decided_at" gorm:"type:timestamp without time zone"`
 
-	event.Registers += 1
+		// cleared on every decision and set once its email went out, a retry resends while it is empty
+		DecisionNotifiedAt *time.Time `json:"decision_notified_at" form:"decision_notified_at" gorm:"type:timestamp without time zone"`
 
-	if err := tx.Model(&Event{}).Where(Event{
-		Name: constants.PE2Name,
-	}).Updates(event).Error; err != nil {
-		return err
+		// hash of the token sent in the magic link, lets the submitter manage the RSVP without an account
+		ManageTokenHash string     `json:"-" gorm:"index"`
+		WithdrawnAt     *time.Time `json:"withdrawn_at" form:"withdrawn_at" gorm:"type:timestamp without time zone"`
 	}
-
-	return nil
-}
+)
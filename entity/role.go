@@ -3,6 +3,12 @@ package entity
 import "github.com/google/uuid"
 
 type Role struct {
-	ID   uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
-	Name string    `json:"name" form:"name"`
+	ID          uuid.UUID        `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	Name        string           `json:"name" form:"name"`
+	Permissions []RolePermission `json:"permissions,omitempty" gorm:"foreignKey:RoleID;constraint:OnDelete:CASCADE"`
+}
+
+type RolePermission struct {
+	RoleID     uuid.UUID `json:"role_id" gorm:"type:uuid;primaryKey"`
+	Permission string    `json:"permission" gorm:"primaryKey"`
 }
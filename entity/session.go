@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Session struct {
+	ID          uuid.UUID  `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	EventID     uuid.UUID  `json:"event_id" form:"event_id" gorm:"type:uuid;index"`
+	SpeakerID   *uuid.UUID `json:"speaker_id" form:"speaker_id" gorm:"type:uuid;default:null"`
+	Title       string     `json:"title" form:"title"`
+	Description string     `json:"description" form:"description"`
+	Type        string     `json:"type" form:"type"`
+	Stage       string     `json:"stage" form:"stage"`
+	StartTime   time.Time  `json:"start_time" form:"start_time" gorm:"type:timestamp without time zone"`
+	EndTime     time.Time  `json:"end_time" form:"end_time" gorm:"type:timestamp without time zone"`
+
+	Event   *Event   `json:"event,omitempty" gorm:"foreignKey:EventID"`
+	Speaker *Speaker `json:"speaker,omitempty" gorm:"foreignKey:SpeakerID"`
+
+	Timestamp
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Speaker struct {
+		ID        uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		Name      string    `json:"name" form:"name"`
+		Headline  string    `json:"headline" form:"headline"`
+		Bio       string    `json:"bio" form:"bio"`
+		Photo     string    `json:"photo" form:"photo"`
+		Position  int       `json:"position" form:"position"`
+		Instagram string    `json:"instagram" form:"instagram"`
+		LinkedIn  string    `json:"linkedin" form:"linkedin"`
+		Twitter   string    `json:"twitter" form:"twitter"`
+		Website   string    `json:"website" form:"website"`
+
+		// the lineup is revealed progressively, a speaker stays hidden
+		// from the public until this time has passed
+		PublishAt *time.Time `json:"publish_at" form:"publish_at" gorm:"type:timestamp without time zone;default:null"`
+
+		Talks []Talk `json:"talks,omitempty" gorm:"foreignKey:SpeakerID"`
+
+		Timestamp
+	}
+
+	Talk struct {
+		ID        uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		SpeakerID uuid.UUID `json:"speaker_id" form:"speaker_id" gorm:"type:uuid"`
+		Title     string    `json:"title" form:"title"`
+		Abstract  string    `json:"abstract" form:"abstract"`
+
+		Speaker *Speaker `json:"speaker,omitempty" gorm:"foreignKey:SpeakerID"`
+
+		Timestamp
+	}
+)
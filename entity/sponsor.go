@@ -0,0 +1,33 @@
+package entity
+
+import "github.com/google/uuid"
+
+type (
+	Sponsor struct {
+		ID           uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		Name         string    `json:"name" form:"name"`
+		Tier         string    `json:"tier" form:"tier"`
+		Description  string    `json:"description" form:"description"`
+		Logo         string    `json:"logo" form:"logo"`
+		DisplayOrder int       `json:"display_order" form:"display_order"`
+		Website      string    `json:"website" form:"website"`
+		Instagram    string    `json:"instagram" form:"instagram"`
+
+		Placements []SponsorPlacement `json:"placements,omitempty" gorm:"foreignKey:SponsorID"`
+
+		Timestamp
+	}
+
+	// every place a sponsor is shown gets its own short link,
+	// so clicks can be reported back per placement
+	SponsorPlacement struct {
+		ID              uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		SponsorID       uuid.UUID `json:"sponsor_id" form:"sponsor_id" gorm:"type:uuid;index"`
+		Placement       string    `json:"placement" form:"placement"`
+		LinkShortenerID uuid.UUID `json:"link_shortener_id" form:"link_shortener_id" gorm:"type:uuid"`
+
+		LinkShortener *LinkShortener `json:"link_shortener,omitempty" gorm:"foreignKey:LinkShortenerID"`
+
+		Timestamp
+	}
+)
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	Survey struct {
+		ID                     uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		Title                  string    `json:"title" form:"title"`
+		Description            string    `json:"description" form:"description"`
+		RequiredForCertificate *bool     `json:"required_for_certificate" form:"required_for_certificate" gorm:"default:false"`
+		ClosedAt               time.Time `json:"closed_at" form:"closed_at" gorm:"type:timestamp without time zone;default:null"`
+
+		Events    []Event          `json:"events,omitempty" gorm:"many2many:survey_events;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+		Questions []SurveyQuestion `json:"questions,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+		Timestamp
+	}
+
+	SurveyQuestion struct {
+		ID       uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		SurveyID uuid.UUID `json:"survey_id" form:"survey_id" gorm:"type:uuid"`
+		Position int       `json:"position" form:"position"`
+		Type     string    `json:"type" form:"type"`
+		Prompt   string    `json:"prompt" form:"prompt"`
+		Scale    int       `json:"scale" form:"scale"`
+		Required *bool     `json:"required" form:"required" gorm:"default:true"`
+	}
+
+	SurveyInvitation struct {
+		ID          uuid.UUID  `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		SurveyID    uuid.UUID  `json:"survey_id" form:"survey_id" gorm:"type:uuid"`
+		EventID     string     `json:"event_id" form:"event_id"`
+		TicketID    string     `json:"ticket_id" form:"ticket_id" gorm:"default:null"`
+		RSVPID      string     `json:"rsvp_id" form:"rsvp_id" gorm:"default:null"`
+		Email       string     `json:"email" form:"email"`
+		Name        string     `json:"name" form:"name"`
+		TokenHash   string     `json:"-" gorm:"uniqueIndex"`
+		SentAt      *time.Time `json:"sent_at" form:"sent_at" gorm:"type:timestamp without time zone"`
+		CompletedAt *time.Time `json:"completed_at" form:"completed_at" gorm:"type:timestamp without time zone"`
+
+		Survey *Survey `json:"survey,omitempty" gorm:"foreignKey:SurveyID"`
+
+		Timestamp
+	}
+
+	// responses are kept apart from invitations so
+	// answers can not be traced back to the attendee
+	SurveyResponse struct {
+		ID       uuid.UUID      `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		SurveyID uuid.UUID      `json:"survey_id" form:"survey_id" gorm:"type:uuid"`
+		EventID  string         `json:"event_id" form:"event_id"`
+		Answers  []SurveyAnswer `json:"answers,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+
+		Timestamp
+	}
+
+	SurveyAnswer struct {
+		ID               uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		SurveyResponseID uuid.UUID `json:"survey_response_id" form:"survey_response_id" gorm:"type:uuid"`
+		QuestionID       uuid.UUID `json:"question_id" form:"question_id" gorm:"type:uuid"`
+		Score            *int      `json:"score" form:"score"`
+		Text             string    `json:"text" form:"text"`
+	}
+)
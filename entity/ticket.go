@@ -20,12 +20,31 @@ type Ticket struct {
 	PaymentConfirmed *bool `json:"payment_confirmed" form:"payment_confirmed" default:"false"`
 	CheckedIn        *bool `json:"checked_in" form:"checked_in" default:"false"`
 
+	Complimentary *bool  `json:"complimentary" form:"complimentary" gorm:"default:false"`
+	GuestCategory string `json:"guest_category" form:"guest_category"`
+
 	User  *User  `gorm:"foreignKey:UserID"`
 	Event *Event `gorm:"foreignKey:EventID"`
 
 	Timestamp
 }
 
+// tickets registered without a profile snapshot hold no copy of their
+// own and follow the owner's profile, User has to be loaded for that
+func (t *Ticket) GetHandphone() string {
+	if t.Handphone == "" && t.User != nil {
+		return t.User.Handphone
+	}
+	return t.Handphone
+}
+
+func (t *Ticket) GetBirthdate() time.Time {
+	if t.Birthdate.IsZero() && t.User != nil && t.User.Birthdate != nil {
+		return *t.User.Birthdate
+	}
+	return t.Birthdate
+}
+
 func (t *Ticket) BeforeCreate(tx *gorm.DB) error {
 	var event Event
 	if err := tx.Model(&Event{}).Where(Event{
@@ -34,7 +53,11 @@ func (t *Ticket) BeforeCreate(tx *gorm.DB) error {
 		return err
 	}
 
-	event.Registers += 1
+	if t.Complimentary != nil && *t.Complimentary {
+		event.CompRegisters += 1
+	} else {
+		event.Registers += 1
+	}
 
 	if err := tx.Model(&Event{}).Where(Event{
 		ID: uuid.MustParse(t.EventID),
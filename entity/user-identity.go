@@ -0,0 +1,16 @@
+package entity
+
+import "github.com/google/uuid"
+
+// links a user to an account at an external identity provider
+type UserIdentity struct {
+	ID       uuid.UUID `json:"id" form:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID   uuid.UUID `json:"user_id" form:"user_id" gorm:"type:uuid;index"`
+	Provider string    `json:"provider" form:"provider" gorm:"uniqueIndex:idx_user_identity_subject"`
+	Subject  string    `json:"subject" form:"subject" gorm:"uniqueIndex:idx_user_identity_subject"`
+	Email    string    `json:"email" form:"email"`
+
+	User *User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+
+	Timestamp
+}
@@ -0,0 +1,30 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// the secret is stored encrypted, EnabledAt stays empty until the
+	// first code from the authenticator app has been confirmed
+	UserMFA struct {
+		UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;primaryKey"`
+		Secret    string     `json:"-"`
+		EnabledAt *time.Time `json:"enabled_at" gorm:"type:timestamp without time zone"`
+		LastStep  int64      `json:"-"`
+
+		CreatedAt time.Time `json:"created_at" gorm:"type:timestamp without time zone"`
+		UpdatedAt time.Time `json:"updated_at" gorm:"type:timestamp without time zone"`
+	}
+
+	RecoveryCode struct {
+		ID       uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+		UserID   uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+		CodeHash string     `json:"-" gorm:"uniqueIndex"`
+		UsedAt   *time.Time `json:"used_at" gorm:"type:timestamp without time zone"`
+
+		CreatedAt time.Time `json:"created_at" gorm:"type:timestamp without time zone"`
+	}
+)
@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// one-time links sent by email, only the hash of the token is stored.
+// ConsumedAt is also set when a newer token of the same purpose replaces it.
+type UserToken struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:uuid_generate_v4()"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;index"`
+	Purpose    string     `json:"purpose" gorm:"index"`
+	TokenHash  string     `json:"-" gorm:"uniqueIndex"`
+	Payload    string     `json:"-"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"type:timestamp without time zone;index"`
+	ConsumedAt *time.Time `json:"consumed_at" gorm:"type:timestamp without time zone"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"type:timestamp without time zone"`
+}
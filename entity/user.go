@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"time"
+
 	"github.com/TEDxITS/website-backend-2024/helpers"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -14,6 +16,21 @@ type User struct {
 	Password string    `json:"password" form:"password"`
 	Verified bool      `json:"verified" form:"verified"`
 
+	// asked once and reused to prefill every registration
+	Handphone  string     `json:"handphone" form:"handphone"`
+	Birthdate  *time.Time `json:"birthdate" form:"birthdate" gorm:"type:timestamp without time zone"`
+	Institute  string     `json:"institute" form:"institute"`
+	Department string     `json:"department" form:"department"`
+	StudentID  string     `json:"student_id" form:"student_id"`
+	Batch      string     `json:"batch" form:"batch"`
+
+	// personal calendar feeds can not send a bearer token, they use this instead
+	CalendarTokenHash string `json:"-" gorm:"index"`
+
+	// suspended accounts keep their data but can not sign in
+	SuspendedAt   *time.Time `json:"suspended_at" gorm:"type:timestamp without time zone"`
+	SuspendReason string     `json:"suspend_reason"`
+
 	Role   *Role   `json:"role,omitempty" gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" `
 	Events []Event `json:"events,omitempty" gorm:"many2many:tickets;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" `
 
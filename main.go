@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"math/rand"
 	"os"
@@ -14,7 +15,10 @@ import (
 	"github.com/TEDxITS/website-backend-2024/repository"
 	"github.com/TEDxITS/website-backend-2024/routes"
 	"github.com/TEDxITS/website-backend-2024/service"
+	"github.com/TEDxITS/website-backend-2024/utils"
 	"github.com/TEDxITS/website-backend-2024/utils/azure"
+	"github.com/TEDxITS/website-backend-2024/utils/keyring"
+	"github.com/TEDxITS/website-backend-2024/utils/oidc"
 	"github.com/gin-contrib/cors"
 
 	"github.com/gin-gonic/gin"
@@ -25,42 +29,108 @@ import (
 func main() {
 	rand.Seed(time.Now().Unix())
 
+	if err := utils.SetAESKey(config.SetUpAESKey()); err != nil {
+		log.Fatalf("error loading AES_KEY: %v", err)
+	}
+
 	var (
-		db         *gorm.DB               = config.SetUpDatabaseConnection()
-		jwtService config.JWTService      = config.NewJWTService()
-		bucket     *config.SupabaseBucket = config.SetUpSupabaseBucket()
+		db            *gorm.DB                 = config.SetUpDatabaseConnection()
+		bucket        *config.SupabaseBucket   = config.SetUpSupabaseBucket()
+		oidcProviders map[string]oidc.Provider = config.SetUpOIDCProviders()
+		jwtKeyring    *keyring.Keyring         = config.SetUpJWTKeyring()
+		// whether tickets keep their own copy of the owner's profile
+		ticketProfileSnapshot bool     = config.SetUpTicketProfileSnapshot()
+		mfaRequiredRoles      []string = config.SetUpMFARequiredRoles()
 
 		// repositories
-		userRepository          repository.UserRepository          = repository.NewUserRepository(db)
-		linkShortenerRepository repository.LinkShortenerRepository = repository.NewLinkShortenerRepository(db)
-		eventRepository         repository.EventRepository         = repository.NewEventRepository(db)
-		pe2RSVPRepo             repository.PE2RSVPRepository       = repository.NewPE2RSVPRepository(db)
-		roleRepo                repository.RoleRepository          = repository.NewRoleRepository(db)
-		ticketRepository        repository.TicketRepository        = repository.NewTicketRepository(db)
-		bucketRepository        repository.BucketRepository        = repository.NewSupabaseBucketRepository(bucket)
+		userRepository           repository.UserRepository           = repository.NewUserRepository(db)
+		linkShortenerRepository  repository.LinkShortenerRepository  = repository.NewLinkShortenerRepository(db)
+		eventRepository          repository.EventRepository          = repository.NewEventRepository(db)
+		pe2RSVPRepo              repository.PE2RSVPRepository        = repository.NewPE2RSVPRepository(db)
+		roleRepo                 repository.RoleRepository           = repository.NewRoleRepository(db)
+		ticketRepository         repository.TicketRepository         = repository.NewTicketRepository(db)
+		bucketRepository         repository.BucketRepository         = repository.NewSupabaseBucketRepository(bucket)
+		certificateRepository    repository.CertificateRepository    = repository.NewCertificateRepository(db)
+		surveyRepository         repository.SurveyRepository         = repository.NewSurveyRepository(db)
+		pe2ReviewRepository      repository.PE2ReviewRepository      = repository.NewPE2ReviewRepository(db)
+		speakerRepository        repository.SpeakerRepository        = repository.NewSpeakerRepository(db)
+		sessionRepository        repository.SessionRepository        = repository.NewSessionRepository(db)
+		sponsorRepository        repository.SponsorRepository        = repository.NewSponsorRepository(db)
+		analyticsRepository      repository.AnalyticsRepository      = repository.NewAnalyticsRepository(db)
+		authTokenRepository      repository.AuthTokenRepository      = repository.NewAuthTokenRepository(db)
+		userIdentityRepository   repository.UserIdentityRepository   = repository.NewUserIdentityRepository(db)
+		auditLogRepository       repository.AuditLogRepository       = repository.NewAuditLogRepository(db)
+		loginLockoutRepository   repository.LoginLockoutRepository   = repository.NewLoginLockoutRepository(db)
+		userTokenRepository      repository.UserTokenRepository      = repository.NewUserTokenRepository(db)
+		mfaRepository            repository.MFARepository            = repository.NewMFARepository(db)
+		apiKeyRepository         repository.APIKeyRepository         = repository.NewAPIKeyRepository(db)
+		userManagementRepository repository.UserManagementRepository = repository.NewUserManagementRepository(db)
+		personalDataRepository   repository.PersonalDataRepository   = repository.NewPersonalDataRepository(db)
+
+		// revoked access tokens, accounts, role permissions and api keys are looked up per request
+		jwtService config.JWTService = config.NewJWTService(jwtKeyring, authTokenRepository, roleRepo, apiKeyRepository, userRepository)
 
 		// services
-		userService          service.UserService          = service.NewUserService(userRepository, roleRepo)
-		linkShortenerService service.LinkShortenerService = service.NewLinkShortenerService(linkShortenerRepository)
-		preEvent2Service     service.PreEvent2Service     = service.NewPreEvent2Service(eventRepository, pe2RSVPRepo)
-		eventService         service.EventService         = service.NewEventService(eventRepository)
-		mainEventService     service.MainEventService     = service.NewMainEventService(userRepository, ticketRepository, eventRepository, bucketRepository)
-		storageService       service.StorageService       = service.NewStorageService(bucketRepository)
-		preEvent3Service     service.PreEvent3Service     = service.NewPreEvent3Service(userRepository, ticketRepository, eventRepository, bucketRepository)
+		lockoutService         service.LockoutService         = service.NewLockoutService(loginLockoutRepository, userRepository)
+		authService            service.AuthService            = service.NewAuthService(authTokenRepository, userRepository, roleRepo, mfaRepository, jwtService, mfaRequiredRoles)
+		userService            service.UserService            = service.NewUserService(userRepository, roleRepo, userTokenRepository, lockoutService, authService)
+		mfaService             service.MFAService             = service.NewMFAService(userRepository, roleRepo, userTokenRepository, mfaRepository, lockoutService, mfaRequiredRoles)
+		apiKeyService          service.APIKeyService          = service.NewAPIKeyService(apiKeyRepository, eventRepository, auditLogRepository, userRepository, roleRepo)
+		linkShortenerService   service.LinkShortenerService   = service.NewLinkShortenerService(linkShortenerRepository)
+		preEvent2Service       service.PreEvent2Service       = service.NewPreEvent2Service(eventRepository, pe2RSVPRepo, userRepository)
+		eventService           service.EventService           = service.NewEventService(eventRepository)
+		mainEventService       service.MainEventService       = service.NewMainEventService(userRepository, ticketRepository, eventRepository, bucketRepository, ticketProfileSnapshot)
+		storageService         service.StorageService         = service.NewStorageService(bucketRepository)
+		preEvent3Service       service.PreEvent3Service       = service.NewPreEvent3Service(userRepository, ticketRepository, eventRepository, bucketRepository, ticketProfileSnapshot)
+		certificateService     service.CertificateService     = service.NewCertificateService(certificateRepository, userRepository, eventRepository, ticketRepository, pe2RSVPRepo, surveyRepository)
+		surveyService          service.SurveyService          = service.NewSurveyService(surveyRepository, eventRepository, ticketRepository, pe2RSVPRepo)
+		preEvent2ReviewService service.PreEvent2ReviewService = service.NewPreEvent2ReviewService(pe2ReviewRepository, pe2RSVPRepo, userRepository, roleRepo)
+		exportService          service.ExportService          = service.NewExportService(ticketRepository, pe2RSVPRepo, userRepository)
+		complimentaryService   service.ComplimentaryService   = service.NewComplimentaryService(userRepository, ticketRepository, eventRepository, ticketProfileSnapshot)
+		speakerService         service.SpeakerService         = service.NewSpeakerService(speakerRepository, bucketRepository)
+		scheduleService        service.ScheduleService        = service.NewScheduleService(sessionRepository, eventRepository, speakerRepository, ticketRepository, pe2RSVPRepo, userRepository)
+		sponsorService         service.SponsorService         = service.NewSponsorService(sponsorRepository, bucketRepository)
+		analyticsService       service.AnalyticsService       = service.NewAnalyticsService(analyticsRepository)
+		demographicService     service.DemographicService     = service.NewDemographicService(analyticsRepository, eventRepository)
+		oidcService            service.OIDCService            = service.NewOIDCService(oidcProviders, userRepository, userIdentityRepository)
+		roleService            service.RoleService            = service.NewRoleService(roleRepo)
+		userManagementService  service.UserManagementService  = service.NewUserManagementService(userRepository, roleRepo, auditLogRepository, userManagementRepository, loginLockoutRepository, userService, authService)
+		personalDataService    service.PersonalDataService    = service.NewPersonalDataService(userRepository, roleRepo, userTokenRepository, mfaRepository, personalDataRepository, bucketRepository, authService)
 
 		// controllers
-		userController          controller.UserController          = controller.NewUserController(userService, jwtService)
-		linkShortenerController controller.LinkShortenerController = controller.NewLinkShortenerController(linkShortenerService)
-		eventController         controller.EventController         = controller.NewEventController(eventService)
-		preEvent2Controller     controller.PreEvent2Controller     = controller.NewPreEvent2Controller(preEvent2Service)
-		mainEventController     controller.MainEventController     = controller.NewMainEventController(mainEventService)
-		storageController       controller.StorageController       = controller.NewStorageController(storageService)
-		preEvent3Controller     controller.PreEvent3Controller     = controller.NewPreEvent3Controller(preEvent3Service)
+		userController            controller.UserController            = controller.NewUserController(userService, authService, mfaService)
+		linkShortenerController   controller.LinkShortenerController   = controller.NewLinkShortenerController(linkShortenerService)
+		eventController           controller.EventController           = controller.NewEventController(eventService)
+		preEvent2Controller       controller.PreEvent2Controller       = controller.NewPreEvent2Controller(preEvent2Service)
+		mainEventController       controller.MainEventController       = controller.NewMainEventController(mainEventService)
+		storageController         controller.StorageController         = controller.NewStorageController(storageService)
+		preEvent3Controller       controller.PreEvent3Controller       = controller.NewPreEvent3Controller(preEvent3Service)
+		certificateController     controller.CertificateController     = controller.NewCertificateController(certificateService)
+		surveyController          controller.SurveyController          = controller.NewSurveyController(surveyService)
+		preEvent2ReviewController controller.PreEvent2ReviewController = controller.NewPreEvent2ReviewController(preEvent2ReviewService)
+		exportController          controller.ExportController          = controller.NewExportController(exportService)
+		complimentaryController   controller.ComplimentaryController   = controller.NewComplimentaryController(complimentaryService)
+		speakerController         controller.SpeakerController         = controller.NewSpeakerController(speakerService)
+		scheduleController        controller.ScheduleController        = controller.NewScheduleController(scheduleService)
+		sponsorController         controller.SponsorController         = controller.NewSponsorController(sponsorService)
+		analyticsController       controller.AnalyticsController       = controller.NewAnalyticsController(analyticsService)
+		demographicController     controller.DemographicController     = controller.NewDemographicController(demographicService)
+		oidcController            controller.OIDCController            = controller.NewOIDCController(oidcService, authService, mfaService)
+		roleController            controller.RoleController            = controller.NewRoleController(roleService)
+		userManagementController  controller.UserManagementController  = controller.NewUserManagementController(userManagementService)
+		mfaController             controller.MFAController             = controller.NewMFAController(mfaService, authService)
+		wellKnownController       controller.WellKnownController       = controller.NewWellKnownController(jwtService)
+		apiKeyController          controller.APIKeyController          = controller.NewAPIKeyController(apiKeyService)
+		personalDataController    controller.PersonalDataController    = controller.NewPersonalDataController(personalDataService)
 	)
 
 	server := gin.Default()
 	server.RedirectTrailingSlash = true
 
+	if err := server.SetTrustedProxies(config.SetUpTrustedProxies()); err != nil {
+		log.Fatalf("error parsing TRUSTED_PROXIES: %v", err)
+	}
+
 	server.Use(middleware.CORSMiddleware())
 
 	routes.User(server, userController, jwtService)
@@ -70,6 +140,22 @@ func main() {
 	routes.MainEvent(server, mainEventController, jwtService)
 	routes.Storage(server, storageController, jwtService)
 	routes.PreEvent3(server, preEvent3Controller, jwtService)
+	routes.Certificate(server, certificateController, jwtService)
+	routes.Survey(server, surveyController, jwtService)
+	routes.PreEvent2Review(server, preEvent2ReviewController, jwtService)
+	routes.Export(server, exportController, jwtService)
+	routes.Complimentary(server, complimentaryController, jwtService)
+	routes.Speaker(server, speakerController, jwtService)
+	routes.Schedule(server, scheduleController, jwtService)
+	routes.Sponsor(server, sponsorController, jwtService)
+	routes.Analytics(server, analyticsController, demographicController, jwtService)
+	routes.OIDC(server, oidcController, jwtService)
+	routes.Role(server, roleController, jwtService)
+	routes.UserManagement(server, userManagementController, jwtService)
+	routes.MFA(server, mfaController, jwtService)
+	routes.WellKnown(server, wellKnownController, jwtService)
+	routes.APIKey(server, apiKeyController, jwtService)
+	routes.PersonalData(server, personalDataController, jwtService)
 
 	// https://github.com/gin-contrib/cors
 	// https://stackoverflow.com/questions/76196547/websocket-returning-403-every-time
@@ -95,6 +181,21 @@ func main() {
 	*/
 	go azure.StopOnNewDeployment()
 
+	// revoked ids, refresh tokens, email links and old lockouts are worthless once expired
+	go func() {
+		for range time.Tick(constants.TOKEN_PURGE_INTERVAL) {
+			if err := authService.PurgeExpiredTokens(context.Background()); err != nil {
+				log.Printf("error purging expired tokens: %v", err)
+			}
+			if err := userService.PurgeExpiredTokens(context.Background()); err != nil {
+				log.Printf("error purging expired user tokens: %v", err)
+			}
+			if err := lockoutService.PurgeStale(context.Background()); err != nil {
+				log.Printf("error purging stale login lockouts: %v", err)
+			}
+		}
+	}()
+
 	port := os.Getenv("HTTP_PLATFORM_PORT")
 	if port == "" {
 		port = "8888"
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// used in place of Authenticate on routes that integrations may call. A request
+// without an api key is handed to Authenticate, so people keep using their tokens.
+func AuthenticateWithAPIKey(jwtService config.JWTService) gin.HandlerFunc {
+	authenticate := Authenticate(jwtService)
+
+	return func(ctx *gin.Context) {
+		apiKey := ctx.GetHeader(constants.API_KEY_HEADER)
+		if apiKey == "" {
+			authenticate(ctx)
+			return
+		}
+
+		key, err := jwtService.ValidateAPIKey(utils.HashToken(apiKey))
+		if err != nil {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_API_KEY, err.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		permissions := make([]string, 0, len(key.Permissions))
+		for _, permission := range key.Permissions {
+			permissions = append(permissions, permission.Permission)
+		}
+
+		events := make([]string, 0, len(key.Events))
+		for _, event := range key.Events {
+			events = append(events, event.EventID.String())
+		}
+
+		ctx.Set(constants.CTX_KEY_API_KEY_ID, key.ID.String())
+		ctx.Set(constants.CTX_KEY_API_KEY_PERMISSIONS, permissions)
+		ctx.Set(constants.CTX_KEY_API_KEY_EVENTS, events)
+		ctx.Next()
+	}
+}
+
+// narrows api keys tied to events down to the events a route serves,
+// tokens and keys without events pass straight through
+func RequireEvent(eventIDs ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		allowed := ctx.GetStringSlice(constants.CTX_KEY_API_KEY_EVENTS)
+		if len(allowed) == 0 {
+			ctx.Next()
+			return
+		}
+
+		for _, event := range allowed {
+			for _, id := range eventIDs {
+				if event == id {
+					ctx.Next()
+					return
+				}
+			}
+		}
+
+		response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_API_KEY, dto.ErrAPIKeyEventNotAllowed.Error(), nil)
+		ctx.AbortWithStatusJSON(http.StatusForbidden, response)
+	}
+}
@@ -26,7 +26,7 @@ func Authenticate(jwtService config.JWTService) gin.HandlerFunc {
 		}
 
 		authHeader = strings.Replace(authHeader, "Bearer ", "", -1)
-		userId, userRole, err := jwtService.GetPayloadInsideToken(authHeader)
+		userId, userRole, tokenId, err := jwtService.GetPayloadInsideToken(authHeader)
 		if err != nil {
 			if err.Error() == dto.ErrTokenExpired.Error() {
 				response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_TOKEN, dto.ErrTokenExpired.Error(), nil)
@@ -38,13 +38,55 @@ func Authenticate(jwtService config.JWTService) gin.HandlerFunc {
 			return
 		}
 
+		// a revoked token stays cryptographically valid, so the denylist has the final say
+		revoked, err := jwtService.IsTokenRevoked(tokenId)
+		if err != nil || revoked {
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_TOKEN, dto.ErrTokenRevoked.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		// suspension, deletion and role changes apply to tokens that are already out
+		account, err := jwtService.GetAccount(userId)
+		if err != nil {
+			status := http.StatusUnauthorized
+			if err == dto.ErrAccountSuspended {
+				status = http.StatusForbidden
+			}
+
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_TOKEN, err.Error(), nil)
+			ctx.AbortWithStatusJSON(status, response)
+			return
+		}
+
+		if account.Role != nil {
+			userRole = account.Role.Name
+		}
+
 		ctx.Set(constants.CTX_KEY_TOKEN, authHeader)
+		ctx.Set(constants.CTX_KEY_TOKEN_ID, tokenId)
 		ctx.Set(constants.CTX_KEY_USER_ID, userId)
 		ctx.Set(constants.CTX_KEY_ROLE_NAME, userRole)
+		ctx.Set(constants.CTX_KEY_ROLE_ID, account.RoleID)
 		ctx.Next()
 	}
 }
 
+// for public routes that do more for signed in users, a token that
+// is sent still has to be valid so a stale one is not silently ignored
+func AuthenticateOptional(jwtService config.JWTService) gin.HandlerFunc {
+	authenticate := Authenticate(jwtService)
+
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") == "" {
+			ctx.Next()
+			return
+		}
+
+		authenticate(ctx)
+	}
+}
+
 func abortTokenInvalid(ctx *gin.Context) {
 	response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_TOKEN, dto.ErrTokenInvalid.Error(), nil)
 	ctx.AbortWithStatusJSON(http.StatusUnauthorized, response)
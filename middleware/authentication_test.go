@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/utils/keyring"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type fakeDenylist struct{}
+
+func (fakeDenylist) IsTokenRevoked(jti string) (bool, error) { return false, nil }
+
+type fakeAccounts map[string]entity.User
+
+func (f fakeAccounts) GetAccount(userId string) (entity.User, error) {
+	user, ok := f[userId]
+	if !ok {
+		return entity.User{}, dto.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func newTestJWTService(t *testing.T, accounts config.AccountStore) config.JWTService {
+	t.Helper()
+
+	ring := keyring.New()
+	if err := ring.Generate("test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ring.SetActive("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	return config.NewJWTService(ring, fakeDenylist{}, nil, nil, accounts)
+}
+
+func serveAuthenticated(jwtService config.JWTService, token string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	server := gin.New()
+	server.GET("/", Authenticate(jwtService), func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, ctx.GetString(constants.CTX_KEY_ROLE_NAME))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthenticateRejectsExistingTokenOfChangedAccount(t *testing.T) {
+	suspendedAt := time.Now()
+	active := uuid.New()
+	suspended := uuid.New()
+	deleted := uuid.New()
+
+	accounts := fakeAccounts{
+		active.String():    {ID: active, Role: &entity.Role{Name: constants.ENUM_ROLE_USER}},
+		suspended.String(): {ID: suspended, SuspendedAt: &suspendedAt, Role: &entity.Role{Name: constants.ENUM_ROLE_USER}},
+	}
+	jwtService := newTestJWTService(t, accounts)
+
+	tests := []struct {
+		name   string
+		userID string
+		status int
+	}{
+		{"active", active.String(), http.StatusOK},
+		{"suspended", suspended.String(), http.StatusForbidden},
+		{"deleted", deleted.String(), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := jwtService.GenerateToken(tt.userID, constants.ENUM_ROLE_USER, uuid.NewString())
+			if rec := serveAuthenticated(jwtService, token); rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestAuthenticateUsesCurrentRole(t *testing.T) {
+	userID := uuid.New()
+	accounts := fakeAccounts{
+		userID.String(): {ID: userID, Role: &entity.Role{Name: constants.ENUM_ROLE_USER}},
+	}
+	jwtService := newTestJWTService(t, accounts)
+
+	// token still claims the admin role the user was demoted from
+	token := jwtService.GenerateToken(userID.String(), constants.ENUM_ROLE_ADMIN, uuid.NewString())
+	rec := serveAuthenticated(jwtService, token)
+	if rec.Code != http.StatusOK || rec.Body.String() != constants.ENUM_ROLE_USER {
+		t.Fatalf("got %d %q, want role %q", rec.Code, rec.Body.String(), constants.ENUM_ROLE_USER)
+	}
+}
+
+func TestAuthenticateSeesSuspensionAfterForget(t *testing.T) {
+	userID := uuid.New()
+	accounts := fakeAccounts{
+		userID.String(): {ID: userID, Role: &entity.Role{Name: constants.ENUM_ROLE_USER}},
+	}
+	jwtService := newTestJWTService(t, accounts)
+	token := jwtService.GenerateToken(userID.String(), constants.ENUM_ROLE_USER, uuid.NewString())
+
+	if rec := serveAuthenticated(jwtService, token); rec.Code != http.StatusOK {
+		t.Fatalf("status = %d before suspension", rec.Code)
+	}
+
+	now := time.Now()
+	user := accounts[userID.String()]
+	user.SuspendedAt = &now
+	accounts[userID.String()] = user
+	jwtService.ForgetAccount(userID.String())
+
+	if rec := serveAuthenticated(jwtService, token); rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d after suspension, want %d", rec.Code, http.StatusForbidden)
+	}
+}
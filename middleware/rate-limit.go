@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/TEDxITS/website-backend-2024/utils/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+// picks what a request is counted by, an empty key falls back to the client ip
+type RateLimitKey func(ctx *gin.Context) string
+
+var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore()
+
+// replaces the in-memory store, call before the server starts handling requests
+func SetRateLimitStore(store ratelimit.Store) {
+	rateLimitStore = store
+}
+
+func RateLimitByIP(ctx *gin.Context) string {
+	return ctx.ClientIP()
+}
+
+// only meaningful after Authenticate, anonymous requests fall back to the ip
+func RateLimitByUserID(ctx *gin.Context) string {
+	return ctx.GetString(constants.CTX_KEY_USER_ID)
+}
+
+// reads the email field without consuming the body, the handler still binds it afterwards
+func RateLimitByEmail(ctx *gin.Context) string {
+	if ctx.Request.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, 1<<20))
+	if err != nil {
+		return ""
+	}
+	ctx.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), ctx.Request.Body))
+
+	var email string
+	switch ctx.ContentType() {
+	case gin.MIMEJSON:
+		var req struct {
+			Email string `json:"email"`
+		}
+		if json.Unmarshal(body, &req) == nil {
+			email = req.Email
+		}
+	case gin.MIMEPOSTForm:
+		if values, err := url.ParseQuery(string(body)); err == nil {
+			email = values.Get("email")
+		}
+	}
+
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// admins are never limited, a valid admin token is honoured even on public routes
+func RateLimit(jwtService config.JWTService, policy constants.RateLimitPolicy, key RateLimitKey) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if isAdminRequest(ctx, jwtService) {
+			ctx.Next()
+			return
+		}
+
+		kind, value := "key", key(ctx)
+		if value == "" {
+			kind, value = "ip", ctx.ClientIP()
+		}
+
+		allowed, retryAfter, err := rateLimitStore.Take(policy.Name+":"+kind+":"+value, policy.Limit, policy.Period)
+		if err != nil {
+			// an unreachable store should not take the endpoints down with it
+			log.Printf("error rate limiting %s: %v", policy.Name, err)
+			ctx.Next()
+			return
+		}
+
+		if !allowed {
+			ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_RATE_LIMIT, dto.ErrRateLimited.Error(), nil)
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, response)
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func isAdminRequest(ctx *gin.Context, jwtService config.JWTService) bool {
+	if role, ok := ctx.Get(constants.CTX_KEY_ROLE_NAME); ok {
+		return role == constants.ENUM_ROLE_ADMIN
+	}
+
+	authHeader := ctx.GetHeader("Authorization")
+	if jwtService == nil || !strings.HasPrefix(authHeader, "Bearer ") {
+		return false
+	}
+
+	userId, role, tokenId, err := jwtService.GetPayloadInsideToken(strings.TrimPrefix(authHeader, "Bearer "))
+	if err != nil || role != constants.ENUM_ROLE_ADMIN {
+		return false
+	}
+
+	if revoked, err := jwtService.IsTokenRevoked(tokenId); err != nil || revoked {
+		return false
+	}
+
+	// the role in the token may be older than a demotion
+	account, err := jwtService.GetAccount(userId)
+	return err == nil && (account.Role == nil || account.Role.Name == constants.ENUM_ROLE_ADMIN)
+}
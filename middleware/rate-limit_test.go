@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/utils/ratelimit"
+	"github.com/gin-gonic/gin"
+)
+
+func newRateLimitedServer(t *testing.T, trustedProxies []string) *gin.Engine {
+	t.Helper()
+	SetRateLimitStore(ratelimit.NewMemoryStore())
+
+	gin.SetMode(gin.TestMode)
+	server := gin.New()
+	if err := server.SetTrustedProxies(trustedProxies); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := constants.RateLimitPolicy{Name: "test", Limit: 2, Period: time.Hour}
+	server.GET("/", RateLimit(nil, policy, RateLimitByIP), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	return server
+}
+
+func requestFrom(server *gin.Engine, remoteAddr string, forwardedFor string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	server := newRateLimitedServer(t, nil)
+
+	// a new X-Forwarded-For on every request must not buy a new bucket
+	for i := 0; i < 2; i++ {
+		if rec := requestFrom(server, "203.0.113.7:1234", "198.51.100."+strconv.Itoa(i)); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d", i, rec.Code)
+		}
+	}
+
+	rec := requestFrom(server, "203.0.113.7:1234", "198.51.100.99")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Fatal("Retry-After header missing")
+	}
+}
+
+func TestRateLimitHonoursTrustedProxy(t *testing.T) {
+	server := newRateLimitedServer(t, []string{"10.0.0.0/8"})
+
+	for i := 0; i < 2; i++ {
+		requestFrom(server, "10.0.0.1:1234", "198.51.100.1")
+	}
+
+	if rec := requestFrom(server, "10.0.0.1:1234", "198.51.100.1"); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("same client: status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+
+	// behind the proxy every client still gets its own bucket
+	if rec := requestFrom(server, "10.0.0.1:1234", "198.51.100.2"); rec.Code != http.StatusOK {
+		t.Fatalf("other client: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// must run after Authenticate, the role is the one currently stored for the user.
+// An api key is checked against the permissions it was created with instead.
+func RequirePermission(jwtService config.JWTService, permission string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var allowed bool
+		var err error
+		if ctx.GetString(constants.CTX_KEY_API_KEY_ID) != "" {
+			for _, granted := range ctx.GetStringSlice(constants.CTX_KEY_API_KEY_PERMISSIONS) {
+				if granted == permission {
+					allowed = true
+					break
+				}
+			}
+		} else {
+			userRole := ctx.GetString(constants.CTX_KEY_ROLE_NAME)
+			roleId := ctx.GetString(constants.CTX_KEY_ROLE_ID)
+			allowed, err = jwtService.HasPermission(userRole, roleId, permission)
+		}
+
+		if err != nil || !allowed {
+			err := fmt.Sprintf(dto.ErrPermissionNotAllowed.Error(), permission)
+			response := utils.BuildResponseFailed(dto.MESSAGE_FAILED_VERIFY_TOKEN, err, nil)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, response)
+			return
+		}
+
+		ctx.Next()
+	}
+}
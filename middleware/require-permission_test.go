@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/utils/keyring"
+	"github.com/gin-gonic/gin"
+)
+
+// grants keyed by role id, the way role_permissions stores them
+type fakePermissions map[string][]string
+
+func (f fakePermissions) HasPermission(roleId string, permission string) (bool, error) {
+	for _, granted := range f[roleId] {
+		if granted == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func serveWithPermission(t *testing.T, permissions config.PermissionStore, roleName string, roleId string) int {
+	t.Helper()
+	jwtService := config.NewJWTService(keyring.New(), nil, permissions, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	server := gin.New()
+	server.GET("/",
+		func(ctx *gin.Context) {
+			ctx.Set(constants.CTX_KEY_ROLE_NAME, roleName)
+			ctx.Set(constants.CTX_KEY_ROLE_ID, roleId)
+		},
+		RequirePermission(jwtService, constants.PERMISSION_TICKET_READ),
+		func(ctx *gin.Context) { ctx.Status(http.StatusOK) },
+	)
+
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	return rec.Code
+}
+
+func TestRequirePermission(t *testing.T) {
+	permissions := fakePermissions{
+		"role-staff": {constants.PERMISSION_TICKET_READ},
+	}
+
+	tests := []struct {
+		name     string
+		roleName string
+		roleId   string
+		status   int
+	}{
+		{"granted", "staff", "role-staff", http.StatusOK},
+		{"admin", constants.ENUM_ROLE_ADMIN, "role-admin", http.StatusOK},
+		{"missing grant is forbidden", constants.ENUM_ROLE_USER, "role-user", http.StatusForbidden},
+		// a role renamed to match another must not inherit its grants
+		{"lookup by id", "staff", "role-other", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serveWithPermission(t, permissions, tt.roleName, tt.roleId); status != tt.status {
+				t.Fatalf("status = %d, want %d", status, tt.status)
+			}
+		})
+	}
+}
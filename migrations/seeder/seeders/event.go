@@ -34,68 +34,75 @@ func EventSeeder(db *gorm.DB) error {
 			StartDate: time.Date(2024, time.April, 10, 19, 0, 0, 0, time.Now().UTC().Location()),
 			EndDate:   time.Date(2024, time.April, 18, 00, 0, 0, 0, time.Now().UTC().Location()),
 		}, entity.Event{
-			ID:        uuid.MustParse(constants.MainEventEarlyBirdNoMerchID),
-			Name:      constants.MainEventEarlyBirdNoMerch,
-			Price:     85000,
-			WithKit:   &False,
-			Capacity:  constants.MainEventEarlyBirdNoMerchCapacity,
-			Registers: 0,
-			StartDate: time.Date(2024, time.May, 6, 19, 0, 0, 0, time.Now().UTC().Location()),
-			EndDate:   time.Date(2024, time.May, 7, 15, 0, 0, 0, time.Now().UTC().Location()),
+			ID:           uuid.MustParse(constants.MainEventEarlyBirdNoMerchID),
+			Name:         constants.MainEventEarlyBirdNoMerch,
+			Price:        85000,
+			WithKit:      &False,
+			Capacity:     constants.MainEventEarlyBirdNoMerchCapacity,
+			Registers:    0,
+			CompCapacity: constants.MainEventEarlyBirdNoMerchCompCapacity,
+			StartDate:    time.Date(2024, time.May, 6, 19, 0, 0, 0, time.Now().UTC().Location()),
+			EndDate:      time.Date(2024, time.May, 7, 15, 0, 0, 0, time.Now().UTC().Location()),
 		}, entity.Event{
-			ID:        uuid.MustParse(constants.MainEventPreSaleNoMerchID),
-			Name:      constants.MainEventPreSaleNoMerch,
-			Price:     125000,
-			WithKit:   &False,
-			Capacity:  constants.MainEventPreSaleNoMerchCapacity,
-			Registers: 0,
-			StartDate: time.Date(2024, time.May, 9, 15, 0, 0, 0, time.Now().UTC().Location()),
-			EndDate:   time.Date(2024, time.May, 12, 23, 59, 59, 0, time.Now().UTC().Location()),
+			ID:           uuid.MustParse(constants.MainEventPreSaleNoMerchID),
+			Name:         constants.MainEventPreSaleNoMerch,
+			Price:        125000,
+			WithKit:      &False,
+			Capacity:     constants.MainEventPreSaleNoMerchCapacity,
+			Registers:    0,
+			CompCapacity: constants.MainEventPreSaleNoMerchCompCapacity,
+			StartDate:    time.Date(2024, time.May, 9, 15, 0, 0, 0, time.Now().UTC().Location()),
+			EndDate:      time.Date(2024, time.May, 12, 23, 59, 59, 0, time.Now().UTC().Location()),
 		}, entity.Event{
-			ID:        uuid.MustParse(constants.MainEventNormalNoMerchID),
-			Name:      constants.MainEventNormalNoMerch,
-			Price:     115000,
-			WithKit:   &False,
-			Capacity:  constants.MainEventNormalNoMerchCapacity,
-			Registers: 0,
-			StartDate: time.Date(2024, time.May, 16, 19, 0, 0, 0, time.Now().UTC().Location()),
-			EndDate:   time.Date(2024, time.May, 31, 23, 59, 0, 0, time.Now().UTC().Location()),
+			ID:           uuid.MustParse(constants.MainEventNormalNoMerchID),
+			Name:         constants.MainEventNormalNoMerch,
+			Price:        115000,
+			WithKit:      &False,
+			Capacity:     constants.MainEventNormalNoMerchCapacity,
+			Registers:    0,
+			CompCapacity: constants.MainEventNormalNoMerchCompCapacity,
+			StartDate:    time.Date(2024, time.May, 16, 19, 0, 0, 0, time.Now().UTC().Location()),
+			EndDate:      time.Date(2024, time.May, 31, 23, 59, 0, 0, time.Now().UTC().Location()),
 		}, entity.Event{
-			ID:        uuid.MustParse(constants.MainEventEarlyBirdWithMerchID),
-			Name:      constants.MainEventEarlyBirdWithMerch,
-			Price:     105000,
-			WithKit:   &True,
-			Capacity:  constants.MainEventEarlyBirdWithMerchCapacity,
-			Registers: 0,
-			StartDate: time.Date(2024, time.May, 6, 19, 0, 0, 0, time.Now().UTC().Location()),
-			EndDate:   time.Date(2024, time.May, 7, 15, 0, 0, 0, time.Now().UTC().Location()),
+			ID:           uuid.MustParse(constants.MainEventEarlyBirdWithMerchID),
+			Name:         constants.MainEventEarlyBirdWithMerch,
+			Price:        105000,
+			WithKit:      &True,
+			Capacity:     constants.MainEventEarlyBirdWithMerchCapacity,
+			Registers:    0,
+			CompCapacity: constants.MainEventEarlyBirdWithMerchCompCapacity,
+			StartDate:    time.Date(2024, time.May, 6, 19, 0, 0, 0, time.Now().UTC().Location()),
+			EndDate:      time.Date(2024, time.May, 7, 15, 0, 0, 0, time.Now().UTC().Location()),
 		}, entity.Event{
-			ID:        uuid.MustParse(constants.MainEventPreSaleWithMerchID),
-			Name:      constants.MainEventPreSaleWithMerch,
-			Price:     140000,
-			WithKit:   &True,
-			Capacity:  constants.MainEventPreSaleWithMerchCapacity,
-			Registers: 0,
-			StartDate: time.Date(2024, time.May, 9, 15, 0, 0, 0, time.Now().UTC().Location()),
-			EndDate:   time.Date(2024, time.May, 12, 23, 59, 59, 0, time.Now().UTC().Location()),
+			ID:           uuid.MustParse(constants.MainEventPreSaleWithMerchID),
+			Name:         constants.MainEventPreSaleWithMerch,
+			Price:        140000,
+			WithKit:      &True,
+			Capacity:     constants.MainEventPreSaleWithMerchCapacity,
+			Registers:    0,
+			CompCapacity: constants.MainEventPreSaleWithMerchCompCapacity,
+			StartDate:    time.Date(2024, time.May, 9, 15, 0, 0, 0, time.Now().UTC().Location()),
+			EndDate:      time.Date(2024, time.May, 12, 23, 59, 59, 0, time.Now().UTC().Location()),
 		}, entity.Event{
-			ID:        uuid.MustParse(constants.MainEventNormalWithMerchID),
-			Name:      constants.MainEventNormalWithMerch,
-			Price:     145000,
-			WithKit:   &True,
-			Capacity:  constants.MainEventNormalWithMerchCapacity,
-			Registers: 0,
-			StartDate: time.Date(2024, time.May, 16, 15, 0, 0, 0, time.Now().UTC().Location()),
-			EndDate:   time.Date(2024, time.May, 31, 23, 59, 0, 0, time.Now().UTC().Location()),
+			ID:           uuid.MustParse(constants.MainEventNormalWithMerchID),
+			Name:         constants.MainEventNormalWithMerch,
+			Price:        145000,
+			WithKit:      &True,
+			Capacity:     constants.MainEventNormalWithMerchCapacity,
+			Registers:    0,
+			CompCapacity: constants.MainEventNormalWithMerchCompCapacity,
+			StartDate:    time.Date(2024, time.May, 16, 15, 0, 0, 0, time.Now().UTC().Location()),
+			EndDate:      time.Date(2024, time.May, 31, 23, 59, 0, 0, time.Now().UTC().Location()),
 		}, entity.Event{
-			ID:        uuid.MustParse(constants.PreEvent3ID),
-			Name:      constants.PE3Name,
-			Price:     15000,
-			WithKit:   &False,
-			Capacity:  999,
-			Registers: 0,
-			StartDate: time.Date(2024, time.May, 19, 19, 0, 0, 0, time.Now().UTC().Location()),
-			EndDate:   time.Date(2024, time.May, 31, 19, 40, 0, 0, time.Now().UTC().Location()),
+			ID:           uuid.MustParse(constants.PreEvent3ID),
+			Name:         constants.PE3Name,
+			Price:        15000,
+			WithKit:      &False,
+			Capacity:     999,
+			Registers:    0,
+			CompCapacity: constants.PreEvent3CompCapacity,
+			StartDate:    time.Date(2024, time.May, 19, 19, 0, 0, 0, time.Now().UTC().Location()),
+			EndDate:      time.Date(2024, time.May, 31, 19, 40, 0, 0, time.Now().UTC().Location()),
 		},
 	)
 
@@ -110,6 +117,7 @@ func EventSeeder(db *gorm.DB) error {
 		// persist registers count, able to change other such as dates and capacity
 		if !reflect.DeepEqual(event, entity.Event{}) {
 			data.Registers = event.Registers
+			data.CompRegisters = event.CompRegisters
 		}
 
 		if err := db.Save(&data).Error; err != nil {
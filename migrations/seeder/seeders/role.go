@@ -24,7 +24,10 @@ func RoleSeeder(db *gorm.DB) error {
 	}
 	jsonData, _ := io.ReadAll(jsonFile)
 
-	var listRole []entity.Role
+	var listRole []struct {
+		entity.Role
+		Permissions []string `json:"permissions"`
+	}
 	json.Unmarshal(jsonData, &listRole)
 
 	// only create if it does not exist
@@ -35,9 +38,16 @@ func RoleSeeder(db *gorm.DB) error {
 			return err
 		}
 
+		// default permissions are only granted once, later edits by admins are kept
 		exist := db.Find(&role, "name = ?", data.Name).RowsAffected
 		if exist == 0 {
-			if err := db.Create(&data).Error; err != nil {
+			for _, permission := range data.Permissions {
+				data.Role.Permissions = append(data.Role.Permissions, entity.RolePermission{
+					Permission: permission,
+				})
+			}
+
+			if err := db.Create(&data.Role).Error; err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+const (
+	// nullable flags on tickets, an unset value is treated as false
+	sqlTicketPaid = "COALESCE(tickets.payment_confirmed, false)"
+	sqlTicketComp = "COALESCE(tickets.complimentary, false)"
+)
+
+type (
+	AnalyticsRepository interface {
+		GetRevenueByTier(filter AnalyticsFilter) ([]dto.TierRevenueRow, error)
+		GetRegistrationTimeline(interval string, filter AnalyticsFilter) ([]dto.RegistrationBucketRow, error)
+		GetSellOut(filter AnalyticsFilter) ([]dto.SellOutRow, error)
+		GetConversion(filter AnalyticsFilter) (dto.ConversionRow, error)
+		GetAgeBrackets(eventIDs []string) ([]dto.DemographicRow, error)
+		GetPE2Breakdown(column string, status string) ([]dto.DemographicRow, error)
+	}
+
+	// Start is inclusive and End is exclusive, an empty EventIDs means every tier
+	AnalyticsFilter struct {
+		Start    *time.Time
+		End      *time.Time
+		EventIDs []string
+	}
+
+	analyticsRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAnalyticsRepository(db *gorm.DB) AnalyticsRepository {
+	return &analyticsRepository{
+		db: db,
+	}
+}
+
+func (r *analyticsRepository) GetRevenueByTier(filter AnalyticsFilter) ([]dto.TierRevenueRow, error) {
+	var rows []dto.TierRevenueRow
+	err := r.ticketQuery(filter).
+		Select(
+			"events.id AS event_id, events.name, events.price, " +
+				"COUNT(*) FILTER (WHERE NOT " + sqlTicketComp + ") AS sold, " +
+				"COUNT(*) FILTER (WHERE " + sqlTicketPaid + " AND NOT " + sqlTicketComp + ") AS confirmed, " +
+				"COUNT(*) FILTER (WHERE " + sqlTicketComp + ") AS complimentary, " +
+				"COALESCE(SUM(events.price) FILTER (WHERE " + sqlTicketPaid + " AND NOT " + sqlTicketComp + "), 0) AS confirmed_revenue, " +
+				"COALESCE(SUM(events.price) FILTER (WHERE NOT " + sqlTicketPaid + " AND NOT " + sqlTicketComp + "), 0) AS pending_revenue",
+		).
+		Group("events.id, events.name, events.price").
+		Order("events.price, events.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// interval is passed to date_trunc and must already be validated by the caller,
+// complimentary tickets are left out since they are not sales
+func (r *analyticsRepository) GetRegistrationTimeline(interval string, filter AnalyticsFilter) ([]dto.RegistrationBucketRow, error) {
+	var rows []dto.RegistrationBucketRow
+	err := r.ticketQuery(filter).
+		Where("NOT "+sqlTicketComp).
+		Select(
+			"date_trunc(?, tickets.created_at) AS bucket, events.id AS event_id, events.name, "+
+				"COUNT(*) AS registrations, "+
+				"COUNT(*) FILTER (WHERE "+sqlTicketPaid+") AS confirmed, "+
+				"COALESCE(SUM(events.price) FILTER (WHERE "+sqlTicketPaid+"), 0) AS revenue",
+			interval,
+		).
+		Group("1, 2, 3").
+		Order("bucket, events.name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// a tier sells out when its paid tickets reach capacity, the moment
+// is taken from the creation time of the ticket that filled the last seat
+func (r *analyticsRepository) GetSellOut(filter AnalyticsFilter) ([]dto.SellOutRow, error) {
+	ranked := r.db.
+		Model(&entity.Ticket{}).
+		Select("tickets.event_id, tickets.created_at, ROW_NUMBER() OVER (PARTITION BY tickets.event_id ORDER BY tickets.created_at) AS n").
+		Where("NOT " + sqlTicketComp)
+
+	query := r.db.
+		Model(&entity.Event{}).
+		Select("events.id AS event_id, events.name, events.capacity, events.registers, events.start_date, ranked.created_at AS sold_out_at").
+		Joins("LEFT JOIN (?) AS ranked ON ranked.event_id = events.id AND ranked.n = events.capacity", ranked).
+		Where("events.id <> ?", constants.PreEvent2ID)
+
+	if len(filter.EventIDs) > 0 {
+		query = query.Where("events.id IN ?", filter.EventIDs)
+	}
+
+	var rows []dto.SellOutRow
+	if err := query.Order("events.start_date, events.name").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *analyticsRepository) GetConversion(filter AnalyticsFilter) (dto.ConversionRow, error) {
+	var row dto.ConversionRow
+	var buyers dto.ConversionRow
+
+	users := r.db.Model(&entity.User{})
+	if filter.Start != nil {
+		users = users.Where("created_at >= ?", *filter.Start)
+	}
+	if filter.End != nil {
+		users = users.Where("created_at < ?", *filter.End)
+	}
+
+	err := users.
+		Select("COUNT(*) AS registered_users, COUNT(*) FILTER (WHERE verified) AS verified_users").
+		Scan(&row).Error
+	if err != nil {
+		return dto.ConversionRow{}, err
+	}
+
+	// complimentary guests did not buy anything, so they are left out
+	err = r.ticketQuery(filter).
+		Where("NOT " + sqlTicketComp).
+		Select("COUNT(DISTINCT tickets.user_id) AS buyers, COUNT(DISTINCT tickets.user_id) FILTER (WHERE " + sqlTicketPaid + ") AS confirmed_buyers").
+		Scan(&buyers).Error
+	if err != nil {
+		return dto.ConversionRow{}, err
+	}
+
+	row.Buyers = buyers.Buyers
+	row.ConfirmedBuyers = buyers.ConfirmedBuyers
+
+	return row, nil
+}
+
+func (r *analyticsRepository) ticketQuery(filter AnalyticsFilter) *gorm.DB {
+	query := r.db.
+		Model(&entity.Ticket{}).
+		Joins("JOIN events ON tickets.event_id = events.id")
+
+	if filter.Start != nil {
+		query = query.Where("tickets.created_at >= ?", *filter.Start)
+	}
+	if filter.End != nil {
+		query = query.Where("tickets.created_at < ?", *filter.End)
+	}
+	if len(filter.EventIDs) > 0 {
+		query = query.Where("tickets.event_id IN ?", filter.EventIDs)
+	}
+
+	return query
+}
+
+// ages are taken at the event date, or today when the event has no date yet.
+// Tickets registered without a profile snapshot use the owner's profile.
+func (r *analyticsRepository) GetAgeBrackets(eventIDs []string) ([]dto.DemographicRow, error) {
+	birthdate := "CASE WHEN tickets.birthdate IS NULL OR tickets.birthdate < '1900-01-01' THEN users.birthdate ELSE tickets.birthdate END"
+	age := "date_part('year', age(COALESCE(events.event_date, now()), " + birthdate + "))"
+
+	label := "CASE WHEN (" + birthdate + ") IS NULL OR (" + birthdate + ") < '1900-01-01' THEN '" + constants.DEMOGRAPHIC_LABEL_UNKNOWN + "'"
+	for _, bracket := range constants.DEMOGRAPHIC_AGE_BRACKETS {
+		if bracket.Max == 0 {
+			label += " ELSE '" + bracket.Label + "'"
+			continue
+		}
+		label += " WHEN " + age + " < " + strconv.Itoa(bracket.Max) + " THEN '" + bracket.Label + "'"
+	}
+	label += " END"
+
+	var rows []dto.DemographicRow
+	err := r.db.
+		Model(&entity.Ticket{}).
+		Joins("JOIN events ON tickets.event_id = events.id").
+		Joins("LEFT JOIN users ON tickets.user_id = users.id").
+		Select(label+" AS label, COUNT(*) AS count").
+		Where("tickets.event_id IN ?", eventIDs).
+		Where(sqlTicketPaid).
+		Group("label").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// column must be one of the whitelisted rsvp fields, spelling differences
+// in free text answers are merged by grouping on the trimmed lower case value
+func (r *analyticsRepository) GetPE2Breakdown(column string, status string) ([]dto.DemographicRow, error) {
+	key := "LOWER(TRIM(" + column + "))"
+
+	query := r.db.
+		Model(&entity.PE2RSVP{}).
+		Select("COALESCE(NULLIF(MAX(TRIM("+column+")), ''), ?) AS label, COUNT(*) AS count", constants.DEMOGRAPHIC_LABEL_UNKNOWN).
+		Where("status <> ?", constants.ENUM_PE2_STATUS_WITHDRAWN)
+
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var rows []dto.DemographicRow
+	if err := query.Group(key).Order("count DESC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	APIKeyRepository interface {
+		Create(key entity.APIKey) (entity.APIKey, error)
+		GetAll() ([]entity.APIKey, error)
+		GetByID(id string) (entity.APIKey, error)
+		GetByHash(hash string) (entity.APIKey, error)
+		Revoke(id string) error
+		UpdateLastUsed(id string, at time.Time) error
+	}
+
+	apiKeyRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepository) Create(key entity.APIKey) (entity.APIKey, error) {
+	if err := r.db.Omit("CreatedBy").Create(&key).Error; err != nil {
+		return entity.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) GetAll() ([]entity.APIKey, error) {
+	var keys []entity.APIKey
+	if err := r.db.
+		Preload("Permissions").
+		Preload("Events").
+		Preload("CreatedBy", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Order("created_at DESC").
+		Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) GetByID(id string) (entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.
+		Preload("Permissions").
+		Preload("Events").
+		Where("id = ?", id).
+		Take(&key).Error; err != nil {
+		return entity.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) GetByHash(hash string) (entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.
+		Preload("Permissions").
+		Preload("Events").
+		Where("key_hash = ?", hash).
+		Take(&key).Error; err != nil {
+		return entity.APIKey{}, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) Revoke(id string) error {
+	result := r.db.
+		Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepository) UpdateLastUsed(id string, at time.Time) error {
+	return r.db.
+		Model(&entity.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at).Error
+}
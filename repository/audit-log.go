@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"math"
+
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	AuditLogRepository interface {
+		Create(log entity.AuditLog) (entity.AuditLog, error)
+		GetAllPagination(targetID string, limit int, page int) ([]entity.AuditLog, int64, int64, error)
+	}
+
+	auditLogRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{
+		db: db,
+	}
+}
+
+func (r *auditLogRepository) Create(log entity.AuditLog) (entity.AuditLog, error) {
+	if err := r.db.Omit("Actor").Create(&log).Error; err != nil {
+		return entity.AuditLog{}, err
+	}
+	return log, nil
+}
+
+func (r *auditLogRepository) GetAllPagination(targetID string, limit int, page int) ([]entity.AuditLog, int64, int64, error) {
+	var logs []entity.AuditLog
+	var count int64
+
+	query := r.db.Model(&entity.AuditLog{})
+	if targetID != "" {
+		query = query.Where("target_id = ?", targetID)
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	maxPage := int64(math.Ceil(float64(count) / float64(limit)))
+	offset := (page - 1) * limit
+
+	// the actor may since have been deleted, the log still has to show who it was
+	err := query.
+		Preload("Actor", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return logs, maxPage, count, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	AuthTokenRepository interface {
+		CreateRefreshToken(entity.RefreshToken) (entity.RefreshToken, error)
+		GetRefreshTokenByHash(hash string) (entity.RefreshToken, error)
+		GetRefreshTokenByAccessJTI(jti string) (entity.RefreshToken, error)
+		RotateRefreshToken(old entity.RefreshToken, next entity.RefreshToken) (entity.RefreshToken, error)
+		RevokeFamily(familyID string) error
+		RevokeAllByUserID(userID string) error
+		RevokeAccessToken(jti string, expiresAt time.Time) error
+		IsTokenRevoked(jti string) (bool, error)
+		PurgeExpired(now time.Time) error
+	}
+
+	authTokenRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewAuthTokenRepository(db *gorm.DB) AuthTokenRepository {
+	return &authTokenRepository{
+		db: db,
+	}
+}
+
+func (r *authTokenRepository) CreateRefreshToken(token entity.RefreshToken) (entity.RefreshToken, error) {
+	if err := r.db.Create(&token).Error; err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+func (r *authTokenRepository) GetRefreshTokenByHash(hash string) (entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	if err := r.db.Where("token_hash = ?", hash).Take(&token).Error; err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+func (r *authTokenRepository) GetRefreshTokenByAccessJTI(jti string) (entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	if err := r.db.Where("access_jti = ?", jti).Take(&token).Error; err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	return token, nil
+}
+
+// the old token is only revoked if nobody else got to it first,
+// two parallel refreshes with the same token count as reuse
+func (r *authTokenRepository) RotateRefreshToken(old entity.RefreshToken, next entity.RefreshToken) (entity.RefreshToken, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", old.ID).
+			Update("revoked_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return dto.ErrRefreshTokenReused
+		}
+
+		return tx.Create(&next).Error
+	})
+	if err != nil {
+		return entity.RefreshToken{}, err
+	}
+
+	return next, nil
+}
+
+func (r *authTokenRepository) RevokeFamily(familyID string) error {
+	return r.revokeWhere("family_id = ?", familyID)
+}
+
+func (r *authTokenRepository) RevokeAllByUserID(userID string) error {
+	return r.revokeWhere("user_id = ?", userID)
+}
+
+func (r *authTokenRepository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	return r.db.
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entity.RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+}
+
+func (r *authTokenRepository) IsTokenRevoked(jti string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *authTokenRepository) PurgeExpired(now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("expires_at < ?", now).Delete(&entity.RevokedToken{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Unscoped().Where("expires_at < ?", now).Delete(&entity.RefreshToken{}).Error
+	})
+}
+
+// revoke every live refresh token matching the condition and deny the access
+// token last issued with each of them, for at most its remaining lifetime
+func (r *authTokenRepository) revokeWhere(query string, args ...interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var tokens []entity.RefreshToken
+		if err := tx.Where(query, args...).Where("revoked_at IS NULL").Find(&tokens).Error; err != nil {
+			return err
+		}
+
+		if len(tokens) == 0 {
+			return nil
+		}
+
+		now := time.Now()
+		accessExpiresAt := now.Add(time.Minute * constants.JWT_EXPIRE_TIME_IN_MINUTES)
+
+		var denied []entity.RevokedToken
+		for _, token := range tokens {
+			if token.AccessJTI != "" {
+				denied = append(denied, entity.RevokedToken{JTI: token.AccessJTI, ExpiresAt: accessExpiresAt})
+			}
+		}
+
+		if len(denied) > 0 {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&denied).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&entity.RefreshToken{}).
+			Where(query, args...).
+			Where("revoked_at IS NULL").
+			Update("revoked_at", now).Error
+	})
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	CertificateRepository interface {
+		Create(entity.Certificate) (entity.Certificate, error)
+		GetByCode(string) (entity.Certificate, error)
+		GetByTicketID(string) (entity.Certificate, error)
+		GetByRSVPID(string) (entity.Certificate, error)
+		GetAllByUser(userID string, email string) ([]entity.Certificate, error)
+		GetAllByEventID(string) ([]entity.Certificate, error)
+		CheckCodeExist(string) (bool, error)
+	}
+
+	certificateRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewCertificateRepository(db *gorm.DB) CertificateRepository {
+	return &certificateRepository{
+		db: db,
+	}
+}
+
+func (r *certificateRepository) Create(certificate entity.Certificate) (entity.Certificate, error) {
+	if err := r.db.Create(&certificate).Error; err != nil {
+		return entity.Certificate{}, err
+	}
+
+	return certificate, nil
+}
+
+func (r *certificateRepository) GetByCode(code string) (entity.Certificate, error) {
+	var certificate entity.Certificate
+	if err := r.db.Where("code = ?", code).Take(&certificate).Error; err != nil {
+		return entity.Certificate{}, err
+	}
+
+	return certificate, nil
+}
+
+func (r *certificateRepository) GetByTicketID(ticketID string) (entity.Certificate, error) {
+	var certificate entity.Certificate
+	if err := r.db.Where("ticket_id = ?", ticketID).Take(&certificate).Error; err != nil {
+		return entity.Certificate{}, err
+	}
+
+	return certificate, nil
+}
+
+func (r *certificateRepository) GetByRSVPID(rsvpID string) (entity.Certificate, error) {
+	var certificate entity.Certificate
+	if err := r.db.Where("rsvp_id = ?", rsvpID).Take(&certificate).Error; err != nil {
+		return entity.Certificate{}, err
+	}
+
+	return certificate, nil
+}
+
+func (r *certificateRepository) GetAllByUser(userID string, email string) ([]entity.Certificate, error) {
+	var certificates []entity.Certificate
+	if err := r.db.Where("user_id = ? OR email = ?", userID, email).Order("created_at").Find(&certificates).Error; err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+func (r *certificateRepository) GetAllByEventID(eventID string) ([]entity.Certificate, error) {
+	var certificates []entity.Certificate
+	if err := r.db.Where("event_id = ?", eventID).Order("name").Find(&certificates).Error; err != nil {
+		return nil, err
+	}
+
+	return certificates, nil
+}
+
+func (r *certificateRepository) CheckCodeExist(code string) (bool, error) {
+	var certificate entity.Certificate
+	if err := r.db.Where("code = ?", code).Take(&certificate).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"math"
 
+	"github.com/TEDxITS/website-backend-2024/dto"
 	"github.com/TEDxITS/website-backend-2024/entity"
 	"gorm.io/gorm"
 )
@@ -13,6 +14,7 @@ type (
 		GetLinkShortenerByAlias(alias string) (entity.LinkShortener, error)
 		CheckAliasExist(alias string) (bool, error)
 		GetAllLinkShortenerPagination(search string, limit int, page int) ([]entity.LinkShortener, int64, int64, error)
+		IncrementClicks(id string) error
 	}
 
 	linkShortenerRepo struct {
@@ -27,13 +29,35 @@ func NewLinkShortenerRepository(db *gorm.DB) LinkShortenerRepository {
 }
 
 func (r *linkShortenerRepo) CreateLinkShortener(linkShorten entity.LinkShortener) (entity.LinkShortener, error) {
-	if err := r.db.Create(&linkShorten).Error; err != nil {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return createLinkShortener(tx, &linkShorten)
+	}); err != nil {
 		return entity.LinkShortener{}, err
 	}
 
 	return linkShorten, nil
 }
 
+// every short link is created through here, also the ones made for sponsor
+// placements. The alias is locked for the rest of the transaction, so two
+// requests for the same alias can not both find it free.
+func createLinkShortener(tx *gorm.DB, linkShorten *entity.LinkShortener) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "link_shortener:"+linkShorten.Alias).Error; err != nil {
+		return err
+	}
+
+	var count int64
+	if err := tx.Model(&entity.LinkShortener{}).Where("alias = ?", linkShorten.Alias).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return dto.ErrAliasHasBeenTaken
+	}
+
+	return tx.Create(linkShorten).Error
+}
+
 func (r *linkShortenerRepo) GetLinkShortenerByAlias(alias string) (entity.LinkShortener, error) {
 	var linkShorten entity.LinkShortener
 	if err := r.db.Where("alias = ?", alias).Take(&linkShorten).Error; err != nil {
@@ -82,3 +106,7 @@ func (r *linkShortenerRepo) GetAllLinkShortenerPagination(search string, limit i
 
 	return links, maxPage, count, nil
 }
+
+func (r *linkShortenerRepo) IncrementClicks(id string) error {
+	return r.db.Model(&entity.LinkShortener{}).Where("id = ?", id).UpdateColumn("clicks", gorm.Expr("clicks + ?", 1)).Error
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	LoginLockoutRepository interface {
+		GetByKeys(keys []string) ([]entity.LoginLockout, error)
+		Update(key string, kind string, fn func(lockout *entity.LoginLockout)) (entity.LoginLockout, error)
+		DeleteByKey(key string) error
+		GetByUnlockTokenHash(hash string) (entity.LoginLockout, error)
+		GetActive() ([]entity.LoginLockout, error)
+		GetByID(id string) (entity.LoginLockout, error)
+		Delete(id string) error
+		PurgeStale(before time.Time) error
+	}
+
+	loginLockoutRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewLoginLockoutRepository(db *gorm.DB) LoginLockoutRepository {
+	return &loginLockoutRepository{
+		db: db,
+	}
+}
+
+func (r *loginLockoutRepository) GetByKeys(keys []string) ([]entity.LoginLockout, error) {
+	var lockouts []entity.LoginLockout
+	if err := r.db.Where("key IN ?", keys).Find(&lockouts).Error; err != nil {
+		return nil, err
+	}
+	return lockouts, nil
+}
+
+// the row is locked while fn runs, parallel failed attempts can not overwrite each other's count
+func (r *loginLockoutRepository) Update(key string, kind string, fn func(lockout *entity.LoginLockout)) (entity.LoginLockout, error) {
+	var lockout entity.LoginLockout
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.LoginLockout{Key: key, Kind: kind}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).Take(&lockout).Error; err != nil {
+			return err
+		}
+
+		fn(&lockout)
+
+		return tx.Save(&lockout).Error
+	})
+	if err != nil {
+		return entity.LoginLockout{}, err
+	}
+
+	return lockout, nil
+}
+
+func (r *loginLockoutRepository) DeleteByKey(key string) error {
+	return r.db.Where("key = ?", key).Delete(&entity.LoginLockout{}).Error
+}
+
+func (r *loginLockoutRepository) GetByUnlockTokenHash(hash string) (entity.LoginLockout, error) {
+	var lockout entity.LoginLockout
+	if err := r.db.Where("unlock_token_hash = ?", hash).Take(&lockout).Error; err != nil {
+		return entity.LoginLockout{}, err
+	}
+	return lockout, nil
+}
+
+func (r *loginLockoutRepository) GetActive() ([]entity.LoginLockout, error) {
+	var lockouts []entity.LoginLockout
+	if err := r.db.Where("locked_until > ?", time.Now()).Order("locked_until DESC").Find(&lockouts).Error; err != nil {
+		return nil, err
+	}
+	return lockouts, nil
+}
+
+func (r *loginLockoutRepository) GetByID(id string) (entity.LoginLockout, error) {
+	var lockout entity.LoginLockout
+	if err := r.db.Where("id = ?", id).Take(&lockout).Error; err != nil {
+		return entity.LoginLockout{}, err
+	}
+	return lockout, nil
+}
+
+func (r *loginLockoutRepository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&entity.LoginLockout{}).Error
+}
+
+// rows that are neither locked nor counting recent failures carry no state worth keeping
+func (r *loginLockoutRepository) PurgeStale(before time.Time) error {
+	return r.db.
+		Where("last_failed_at < ?", before).
+		Where("locked_until IS NULL OR locked_until < ?", before).
+		Delete(&entity.LoginLockout{}).Error
+}
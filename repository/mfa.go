@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	MFARepository interface {
+		GetByUserID(userID string) (entity.UserMFA, error)
+		Save(mfa entity.UserMFA) (entity.UserMFA, error)
+		Enable(userID string) error
+		Delete(userID string) error
+		UseStep(userID string, step int64) error
+		ReplaceRecoveryCodes(userID string, codes []entity.RecoveryCode) error
+		UseRecoveryCode(userID string, hash string) error
+		CountRecoveryCodes(userID string) (int64, error)
+	}
+
+	mfaRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepository{
+		db: db,
+	}
+}
+
+func (r *mfaRepository) GetByUserID(userID string) (entity.UserMFA, error) {
+	var mfa entity.UserMFA
+	if err := r.db.Where("user_id = ?", userID).Take(&mfa).Error; err != nil {
+		return entity.UserMFA{}, err
+	}
+	return mfa, nil
+}
+
+func (r *mfaRepository) Save(mfa entity.UserMFA) (entity.UserMFA, error) {
+	if err := r.db.Save(&mfa).Error; err != nil {
+		return entity.UserMFA{}, err
+	}
+	return mfa, nil
+}
+
+func (r *mfaRepository) Enable(userID string) error {
+	return r.db.
+		Model(&entity.UserMFA{}).
+		Where("user_id = ?", userID).
+		Update("enabled_at", time.Now()).Error
+}
+
+func (r *mfaRepository) Delete(userID string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&entity.UserMFA{}).Error
+	})
+}
+
+// a code is only accepted for a step later than the last one, so it can not be replayed
+func (r *mfaRepository) UseStep(userID string, step int64) error {
+	result := r.db.
+		Model(&entity.UserMFA{}).
+		Where("user_id = ? AND last_step < ?", userID, step).
+		Update("last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(userID string, codes []entity.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *mfaRepository) UseRecoveryCode(userID string, hash string) error {
+	result := r.db.
+		Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, hash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) CountRecoveryCodes(userID string) (int64, error) {
+	var count int64
+	if err := r.db.
+		Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	// everything stored about one person, gathered for access and erasure requests.
+	// Records made before an account existed are matched by email.
+	PersonalDataRepository interface {
+		GetTickets(userID string) ([]entity.Ticket, error)
+		GetPE2RSVPs(email string) ([]entity.PE2RSVP, error)
+		GetCertificates(userID string, email string) ([]entity.Certificate, error)
+		GetSurveyInvitations(email string) ([]entity.SurveyInvitation, error)
+		GetIdentities(userID string) ([]entity.UserIdentity, error)
+		Anonymise(user entity.User, lockoutKey string) error
+	}
+
+	personalDataRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPersonalDataRepository(db *gorm.DB) PersonalDataRepository {
+	return &personalDataRepository{
+		db: db,
+	}
+}
+
+func (r *personalDataRepository) GetTickets(userID string) ([]entity.Ticket, error) {
+	var tickets []entity.Ticket
+	if err := r.db.Preload("Event").Where("user_id = ?", userID).Order("created_at").Find(&tickets).Error; err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *personalDataRepository) GetPE2RSVPs(email string) ([]entity.PE2RSVP, error) {
+	var rsvps []entity.PE2RSVP
+	if err := r.db.Where("LOWER(email) = LOWER(?)", email).Find(&rsvps).Error; err != nil {
+		return nil, err
+	}
+	return rsvps, nil
+}
+
+func (r *personalDataRepository) GetCertificates(userID string, email string) ([]entity.Certificate, error) {
+	var certificates []entity.Certificate
+	if err := r.db.Where("user_id = ? OR LOWER(email) = LOWER(?)", userID, email).Order("created_at").Find(&certificates).Error; err != nil {
+		return nil, err
+	}
+	return certificates, nil
+}
+
+func (r *personalDataRepository) GetSurveyInvitations(email string) ([]entity.SurveyInvitation, error) {
+	var invitations []entity.SurveyInvitation
+	if err := r.db.Preload("Survey").Where("LOWER(email) = LOWER(?)", email).Order("created_at").Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+func (r *personalDataRepository) GetIdentities(userID string) ([]entity.UserIdentity, error) {
+	var identities []entity.UserIdentity
+	if err := r.db.Where("user_id = ?", userID).Find(&identities).Error; err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+// tickets and RSVPs are kept with their personal fields blanked, so registration
+// counts stay the same. Birthdates are cut down to the year, enough for the age
+// groups of the demographics report. Everything only useful to the person is removed.
+func (r *personalDataRepository) Anonymise(user entity.User, lockoutKey string) error {
+	userID := user.ID.String()
+
+	// tickets without their own birthdate fall back to the profile one in the report
+	fallbackBirthdate := time.Time{}
+	if user.Birthdate != nil {
+		fallbackBirthdate = time.Date(user.Birthdate.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := revokeAPIKeysCreatedBy(tx, userID); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.Ticket{}).
+			Where("user_id = ?", userID).
+			Updates(map[string]interface{}{
+				"handphone": "",
+				"birthdate": gorm.Expr("CASE WHEN birthdate IS NULL OR birthdate < '1900-01-01' THEN ? ELSE date_trunc('year', birthdate) END", fallbackBirthdate),
+				"payment":   "",
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.PE2RSVP{}).
+			Where("LOWER(email) = LOWER(?)", user.Email).
+			Updates(map[string]interface{}{
+				"name":              constants.ANONYMISED_NAME,
+				"email":             "",
+				"student_id":        "",
+				"essay":             "",
+				"manage_token_hash": "",
+			}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&entity.SurveyInvitation{}).
+			Where("LOWER(email) = LOWER(?)", user.Email).
+			Updates(map[string]interface{}{
+				"name":  constants.ANONYMISED_NAME,
+				"email": "",
+			}).Error; err != nil {
+			return err
+		}
+
+		// a certificate only proves a name attended, without the name it has no use
+		if err := tx.Unscoped().
+			Where("user_id = ? OR LOWER(email) = LOWER(?)", userID, user.Email).
+			Delete(&entity.Certificate{}).Error; err != nil {
+			return err
+		}
+
+		for _, model := range []interface{}{
+			&entity.UserIdentity{},
+			&entity.UserToken{},
+			&entity.RecoveryCode{},
+			&entity.UserMFA{},
+			&entity.RefreshToken{},
+		} {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("key = ?", lockoutKey).Delete(&entity.LoginLockout{}).Error; err != nil {
+			return err
+		}
+
+		// older entries copied the email into the detail, like a cleared lockout key
+		if user.Email != "" {
+			if err := tx.Model(&entity.AuditLog{}).
+				Where("strpos(LOWER(detail), LOWER(?)) > 0", user.Email).
+				Update("detail", "").Error; err != nil {
+				return err
+			}
+		}
+
+		// the row stays so tickets keep pointing somewhere, the email is freed for a new account
+		if err := tx.Model(&entity.User{}).
+			Where("id = ?", userID).
+			Updates(map[string]interface{}{
+				"name":                constants.ANONYMISED_NAME,
+				"email":               userID + constants.ANONYMISED_EMAIL_DOMAIN,
+				"password":            "",
+				"verified":            false,
+				"calendar_token_hash": "",
+				"suspend_reason":      "",
+				"handphone":           "",
+				"birthdate":           nil,
+				"institute":           "",
+				"department":          "",
+				"student_id":          "",
+				"batch":               "",
+			}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", userID).Delete(&entity.User{}).Error
+	})
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"math"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	PE2ReviewRepository interface {
+		CreateAssignments([]entity.PE2Review) (int64, error)
+		GetByReviewerID(string) ([]entity.PE2Review, error)
+		GetByID(string) (entity.PE2Review, error)
+		GetByRSVPID(string) ([]entity.PE2Review, error)
+		SubmitReview(entity.PE2Review) (entity.PE2Review, error)
+		GetSummaryPagination(search string, limit int, page int) ([]dto.PE2ReviewSummaryData, int64, int64, error)
+	}
+
+	pe2ReviewRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewPE2ReviewRepository(db *gorm.DB) PE2ReviewRepository {
+	return &pe2ReviewRepository{
+		db: db,
+	}
+}
+
+func (r *pe2ReviewRepository) CreateAssignments(reviews []entity.PE2Review) (int64, error) {
+	// an RSVP assigned twice to the same reviewer is silently skipped
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reviews)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *pe2ReviewRepository) GetByReviewerID(reviewerID string) ([]entity.PE2Review, error) {
+	var reviews []entity.PE2Review
+	err := r.db.
+		Preload("RSVP").
+		Preload("Scores").
+		Where("reviewer_id = ?", reviewerID).
+		Order("created_at").
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *pe2ReviewRepository) GetByID(id string) (entity.PE2Review, error) {
+	var review entity.PE2Review
+	if err := r.db.Preload("RSVP").Preload("Scores").Where("id = ?", id).Take(&review).Error; err != nil {
+		return entity.PE2Review{}, err
+	}
+
+	return review, nil
+}
+
+func (r *pe2ReviewRepository) GetByRSVPID(rsvpID string) ([]entity.PE2Review, error) {
+	var reviews []entity.PE2Review
+	err := r.db.
+		Preload("Reviewer").
+		Preload("Scores").
+		Where("rsvp_id = ?", rsvpID).
+		Order("submitted_at").
+		Find(&reviews).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return reviews, nil
+}
+
+func (r *pe2ReviewRepository) SubmitReview(review entity.PE2Review) (entity.PE2Review, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// reviewers may revise their scores, replace the previous ones
+		if err := tx.Where("review_id = ?", review.ID).Delete(&entity.PE2ReviewScore{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("RSVP", "Reviewer").Save(&review).Error
+	})
+	if err != nil {
+		return entity.PE2Review{}, err
+	}
+
+	return review, nil
+}
+
+func (r *pe2ReviewRepository) GetSummaryPagination(search string, limit int, page int) ([]dto.PE2ReviewSummaryData, int64, int64, error) {
+	var summaries []dto.PE2ReviewSummaryData
+	var count int64
+
+	if err := r.db.Model(&entity.PE2RSVP{}).Where("name LIKE ?", "%"+search+"%").Count(&count).Error; err != nil {
+		return nil, 0, 0, err
+	}
+
+	maxPage := int64(math.Ceil(float64(count) / float64(limit)))
+	offset := (page - 1) * limit
+
+	err := r.db.
+		Model(&entity.PE2RSVP{}).
+		Select("pe2_rsvps.id, pe2_rsvps.name, pe2_rsvps.institute, pe2_rsvps.status, "+
+			"COUNT(pe2_reviews.id) AS assigned, COUNT(pe2_reviews.submitted_at) AS reviewed, "+
+			"COALESCE(AVG(pe2_reviews.total_score) FILTER (WHERE pe2_reviews.submitted_at IS NOT NULL), 0) AS average_score").
+		Joins("LEFT JOIN pe2_reviews ON pe2_reviews.rsvp_id = pe2_rsvps.id AND pe2_reviews.deleted_at IS NULL").
+		Where("pe2_rsvps.name LIKE ?", "%"+search+"%").
+		Group("pe2_rsvps.id").
+		Order("average_score DESC, pe2_rsvps.name").
+		Offset(offset).
+		Limit(limit).
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return summaries, maxPage, count, nil
+}
@@ -2,9 +2,13 @@ package repository
 
 import (
 	"math"
+	"time"
 
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
 	"github.com/TEDxITS/website-backend-2024/entity"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
@@ -15,6 +19,19 @@ type (
 		GetById(string) (entity.PE2RSVP, error)
 		CountTotal() (int64, error)
 		CountAttends() (int64, error)
+		Update(entity.PE2RSVP) (entity.PE2RSVP, error)
+		GetAttendedByEmail(string) ([]entity.PE2RSVP, error)
+		GetAllAttended() ([]entity.PE2RSVP, error)
+		GetAll() ([]entity.PE2RSVP, error)
+		GetByIds([]string) ([]entity.PE2RSVP, error)
+		CountByStatus(string) (int64, error)
+		UpdateStatus(id string, status string) (entity.PE2RSVP, error)
+		MarkDecisionNotified(id string) error
+		SetManageTokenHash(id string, hash string) error
+		UpdateWithRegisters(entity.PE2RSVP) (entity.PE2RSVP, error)
+		GetByManageTokenHash(string) (entity.PE2RSVP, error)
+		GetByEmail(string) (entity.PE2RSVP, error)
+		Stream(search string, fn func([]entity.PE2RSVP) error) error
 	}
 
 	pe2RSVPRepository struct {
@@ -101,3 +118,197 @@ func (r *pe2RSVPRepository) GetById(id string) (entity.PE2RSVP, error) {
 	}
 	return attendee, nil
 }
+
+func (r *pe2RSVPRepository) Update(rsvp entity.PE2RSVP) (entity.PE2RSVP, error) {
+	if err := r.db.Save(&rsvp).Error; err != nil {
+		return entity.PE2RSVP{}, err
+	}
+
+	return rsvp, nil
+}
+
+func (r *pe2RSVPRepository) GetAttendedByEmail(email string) ([]entity.PE2RSVP, error) {
+	var rsvps []entity.PE2RSVP
+	if err := r.db.Where("email = ? AND attended = ?", email, true).Find(&rsvps).Error; err != nil {
+		return nil, err
+	}
+
+	return rsvps, nil
+}
+
+func (r *pe2RSVPRepository) GetAllAttended() ([]entity.PE2RSVP, error) {
+	var rsvps []entity.PE2RSVP
+	if err := r.db.Where("attended = ?", true).Find(&rsvps).Error; err != nil {
+		return nil, err
+	}
+
+	return rsvps, nil
+}
+
+func (r *pe2RSVPRepository) GetAll() ([]entity.PE2RSVP, error) {
+	var rsvps []entity.PE2RSVP
+	if err := r.db.Order("name").Find(&rsvps).Error; err != nil {
+		return nil, err
+	}
+
+	return rsvps, nil
+}
+
+func (r *pe2RSVPRepository) GetByIds(ids []string) ([]entity.PE2RSVP, error) {
+	var rsvps []entity.PE2RSVP
+	if err := r.db.Where("id IN ?", ids).Order("name").Find(&rsvps).Error; err != nil {
+		return nil, err
+	}
+
+	return rsvps, nil
+}
+
+func (r *pe2RSVPRepository) CountByStatus(status string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.PE2RSVP{}).Where("status = ?", status).Count(&count).Error; err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *pe2RSVPRepository) UpdateStatus(id string, status string) (entity.PE2RSVP, error) {
+	var rsvp entity.PE2RSVP
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// lock the event row so concurrent acceptances can not exceed the capacity
+		var event entity.Event
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", constants.PE2Name).Take(&event).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("id = ?", id).Take(&rsvp).Error; err != nil {
+			return err
+		}
+
+		if status == constants.ENUM_PE2_STATUS_ACCEPTED && rsvp.Status != constants.ENUM_PE2_STATUS_ACCEPTED {
+			accepted, err := countPE2Registers(tx)
+			if err != nil {
+				return err
+			}
+
+			if accepted >= int64(event.Capacity) {
+				return dto.ErrPE2RSVPFull
+			}
+		}
+
+		now := time.Now()
+		rsvp.Status = status
+		rsvp.DecidedAt = &now
+		rsvp.DecisionNotifiedAt = nil
+		if err := tx.Save(&rsvp).Error; err != nil {
+			return err
+		}
+
+		return recountPE2Registers(tx, event)
+	})
+	if err != nil {
+		return entity.PE2RSVP{}, err
+	}
+
+	return rsvp, nil
+}
+
+func (r *pe2RSVPRepository) MarkDecisionNotified(id string) error {
+	return r.db.Model(&entity.PE2RSVP{}).Where("id = ?", id).Update("decision_notified_at", time.Now()).Error
+}
+
+// only the column is written, an edit made through the old link is kept
+func (r *pe2RSVPRepository) SetManageTokenHash(id string, hash string) error {
+	return r.db.Model(&entity.PE2RSVP{}).Where("id = ?", id).Update("manage_token_hash", hash).Error
+}
+
+// save an RSVP whose attendance may have changed and keep the event registers in sync
+func (r *pe2RSVPRepository) UpdateWithRegisters(rsvp entity.PE2RSVP) (entity.PE2RSVP, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var event entity.Event
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("name = ?", constants.PE2Name).Take(&event).Error; err != nil {
+			return err
+		}
+
+		var current entity.PE2RSVP
+		if err := tx.Where("id = ?", rsvp.ID).Take(&current).Error; err != nil {
+			return err
+		}
+
+		// only a slot that was not taken before needs to fit in the capacity
+		if isPE2Register(rsvp) && !isPE2Register(current) {
+			registers, err := countPE2Registers(tx)
+			if err != nil {
+				return err
+			}
+
+			if registers >= int64(event.Capacity) {
+				return dto.ErrPE2RSVPFull
+			}
+		}
+
+		if err := tx.Save(&rsvp).Error; err != nil {
+			return err
+		}
+
+		return recountPE2Registers(tx, event)
+	})
+	if err != nil {
+		return entity.PE2RSVP{}, err
+	}
+
+	return rsvp, nil
+}
+
+func (r *pe2RSVPRepository) GetByManageTokenHash(hash string) (entity.PE2RSVP, error) {
+	var rsvp entity.PE2RSVP
+	if err := r.db.Where("manage_token_hash = ?", hash).Take(&rsvp).Error; err != nil {
+		return entity.PE2RSVP{}, err
+	}
+
+	return rsvp, nil
+}
+
+func (r *pe2RSVPRepository) GetByEmail(email string) (entity.PE2RSVP, error) {
+	var rsvp entity.PE2RSVP
+	if err := r.db.Where("email = ?", email).Take(&rsvp).Error; err != nil {
+		return entity.PE2RSVP{}, err
+	}
+
+	return rsvp, nil
+}
+
+func (r *pe2RSVPRepository) Stream(search string, fn func([]entity.PE2RSVP) error) error {
+	var rsvps []entity.PE2RSVP
+	return r.db.
+		Where("name LIKE ?", "%"+search+"%").
+		FindInBatches(&rsvps, constants.EXPORT_BATCH_SIZE, func(tx *gorm.DB, batch int) error {
+			return fn(rsvps)
+		}).Error
+}
+
+func isPE2Register(rsvp entity.PE2RSVP) bool {
+	return rsvp.Status == constants.ENUM_PE2_STATUS_ACCEPTED && rsvp.WillingToCome != nil && *rsvp.WillingToCome
+}
+
+// registers of pre-event 2 are the accepted applicants that are still willing to come
+func countPE2Registers(tx *gorm.DB) (int64, error) {
+	var count int64
+	err := tx.Model(&entity.PE2RSVP{}).
+		Where("status = ? AND willing_to_come = ?", constants.ENUM_PE2_STATUS_ACCEPTED, true).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func recountPE2Registers(tx *gorm.DB, event entity.Event) error {
+	count, err := countPE2Registers(tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Model(&entity.Event{}).Where("id = ?", event.ID).Update("registers", count).Error
+}
@@ -8,6 +8,14 @@ import (
 type (
 	RoleRepository interface {
 		GetRolebyId(roleId string) (entity.Role, error)
+		GetAll() ([]entity.Role, error)
+		GetByIDWithPermissions(roleId string) (entity.Role, error)
+		CheckNameExist(name string) (bool, error)
+		Create(role entity.Role) (entity.Role, error)
+		Update(role entity.Role) (entity.Role, error)
+		Delete(roleId string) error
+		CountUsers(roleId string) (int64, error)
+		HasPermission(roleId string, permission string) (bool, error)
 	}
 
 	roleRepository struct {
@@ -28,3 +36,89 @@ func (r *roleRepository) GetRolebyId(roleId string) (entity.Role, error) {
 	}
 	return role, nil
 }
+
+func (r *roleRepository) GetAll() ([]entity.Role, error) {
+	var roles []entity.Role
+	if err := r.db.Preload("Permissions").Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *roleRepository) GetByIDWithPermissions(roleId string) (entity.Role, error) {
+	var role entity.Role
+	if err := r.db.Preload("Permissions").Where("id = ?", roleId).Take(&role).Error; err != nil {
+		return entity.Role{}, err
+	}
+	return role, nil
+}
+
+func (r *roleRepository) CheckNameExist(name string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.Role{}).Where("name = ?", name).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *roleRepository) Create(role entity.Role) (entity.Role, error) {
+	if err := r.db.Create(&role).Error; err != nil {
+		return entity.Role{}, err
+	}
+	return role, nil
+}
+
+// permissions are replaced as a whole, so the set sent by the admin is the set stored
+func (r *roleRepository) Update(role entity.Role) (entity.Role, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.Role{}).Where("id = ?", role.ID).Update("name", role.Name).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&entity.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		if len(role.Permissions) == 0 {
+			return nil
+		}
+
+		return tx.Create(&role.Permissions).Error
+	})
+	if err != nil {
+		return entity.Role{}, err
+	}
+
+	return role, nil
+}
+
+func (r *roleRepository) Delete(roleId string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", roleId).Delete(&entity.RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", roleId).Delete(&entity.Role{}).Error
+	})
+}
+
+func (r *roleRepository) CountUsers(roleId string) (int64, error) {
+	var count int64
+	if err := r.db.Model(&entity.User{}).Where("role_id = ?", roleId).Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *roleRepository) HasPermission(roleId string, permission string) (bool, error) {
+	var count int64
+	err := r.db.
+		Model(&entity.RolePermission{}).
+		Where("role_id = ? AND permission = ?", roleId, permission).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	SessionRepository interface {
+		Create(entity.Session) (entity.Session, error)
+		Update(entity.Session) (entity.Session, error)
+		Delete(id string) error
+		GetByID(id string) (entity.Session, error)
+		GetByEventIDs(eventIDs []string) ([]entity.Session, error)
+	}
+
+	sessionRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (r *sessionRepository) Create(session entity.Session) (entity.Session, error) {
+	if err := r.db.Omit("Event", "Speaker").Create(&session).Error; err != nil {
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) Update(session entity.Session) (entity.Session, error) {
+	if err := r.db.Omit("Event", "Speaker").Save(&session).Error; err != nil {
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) Delete(id string) error {
+	return r.db.Where("id = ?", id).Delete(&entity.Session{}).Error
+}
+
+func (r *sessionRepository) GetByID(id string) (entity.Session, error) {
+	var session entity.Session
+	if err := r.db.Preload("Event").Preload("Speaker").Where("id = ?", id).Take(&session).Error; err != nil {
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepository) GetByEventIDs(eventIDs []string) ([]entity.Session, error) {
+	var sessions []entity.Session
+	err := r.db.
+		Preload("Event").
+		Preload("Speaker").
+		Where("event_id IN ?", eventIDs).
+		Order("start_time, stage").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	SpeakerRepository interface {
+		Create(entity.Speaker) (entity.Speaker, error)
+		Update(entity.Speaker) (entity.Speaker, error)
+		Delete(id string) error
+		GetAll() ([]entity.Speaker, error)
+		GetByID(id string) (entity.Speaker, error)
+		GetAllPublished(now time.Time) ([]entity.Speaker, error)
+		GetPublishedByID(id string, now time.Time) (entity.Speaker, error)
+		CreateTalk(entity.Talk) (entity.Talk, error)
+		UpdateTalk(entity.Talk) (entity.Talk, error)
+		DeleteTalk(id string) error
+		GetTalkByID(id string) (entity.Talk, error)
+	}
+
+	speakerRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSpeakerRepository(db *gorm.DB) SpeakerRepository {
+	return &speakerRepository{
+		db: db,
+	}
+}
+
+func (r *speakerRepository) Create(speaker entity.Speaker) (entity.Speaker, error) {
+	if err := r.db.Create(&speaker).Error; err != nil {
+		return entity.Speaker{}, err
+	}
+
+	return speaker, nil
+}
+
+func (r *speakerRepository) Update(speaker entity.Speaker) (entity.Speaker, error) {
+	if err := r.db.Omit("Talks").Save(&speaker).Error; err != nil {
+		return entity.Speaker{}, err
+	}
+
+	return speaker, nil
+}
+
+func (r *speakerRepository) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("speaker_id = ?", id).Delete(&entity.Talk{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&entity.Speaker{}).Error
+	})
+}
+
+func (r *speakerRepository) GetAll() ([]entity.Speaker, error) {
+	var speakers []entity.Speaker
+	if err := r.db.Preload("Talks").Order("position, name").Find(&speakers).Error; err != nil {
+		return nil, err
+	}
+
+	return speakers, nil
+}
+
+func (r *speakerRepository) GetByID(id string) (entity.Speaker, error) {
+	var speaker entity.Speaker
+	if err := r.db.Preload("Talks").Where("id = ?", id).Take(&speaker).Error; err != nil {
+		return entity.Speaker{}, err
+	}
+
+	return speaker, nil
+}
+
+func (r *speakerRepository) GetAllPublished(now time.Time) ([]entity.Speaker, error) {
+	var speakers []entity.Speaker
+	err := r.db.
+		Preload("Talks").
+		Where("publish_at IS NOT NULL AND publish_at <= ?", now).
+		Order("position, name").
+		Find(&speakers).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return speakers, nil
+}
+
+func (r *speakerRepository) GetPublishedByID(id string, now time.Time) (entity.Speaker, error) {
+	var speaker entity.Speaker
+	err := r.db.
+		Preload("Talks").
+		Where("id = ? AND publish_at IS NOT NULL AND publish_at <= ?", id, now).
+		Take(&speaker).Error
+	if err != nil {
+		return entity.Speaker{}, err
+	}
+
+	return speaker, nil
+}
+
+func (r *speakerRepository) CreateTalk(talk entity.Talk) (entity.Talk, error) {
+	if err := r.db.Create(&talk).Error; err != nil {
+		return entity.Talk{}, err
+	}
+
+	return talk, nil
+}
+
+func (r *speakerRepository) UpdateTalk(talk entity.Talk) (entity.Talk, error) {
+	if err := r.db.Omit("Speaker").Save(&talk).Error; err != nil {
+		return entity.Talk{}, err
+	}
+
+	return talk, nil
+}
+
+func (r *speakerRepository) DeleteTalk(id string) error {
+	return r.db.Where("id = ?", id).Delete(&entity.Talk{}).Error
+}
+
+func (r *speakerRepository) GetTalkByID(id string) (entity.Talk, error) {
+	var talk entity.Talk
+	if err := r.db.Where("id = ?", id).Take(&talk).Error; err != nil {
+		return entity.Talk{}, err
+	}
+
+	return talk, nil
+}
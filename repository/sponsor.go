@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	SponsorRepository interface {
+		Create(entity.Sponsor, entity.SponsorPlacement) (entity.Sponsor, error)
+		Update(entity.Sponsor) (entity.Sponsor, error)
+		Delete(id string) error
+		GetAll(tier string) ([]entity.Sponsor, error)
+		GetByID(id string) (entity.Sponsor, error)
+		CreatePlacement(entity.SponsorPlacement) (entity.SponsorPlacement, error)
+		CheckPlacementExist(sponsorID string, placement string) (bool, error)
+	}
+
+	sponsorRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSponsorRepository(db *gorm.DB) SponsorRepository {
+	return &sponsorRepository{
+		db: db,
+	}
+}
+
+// the sponsor never exists without its first placement and that placement's link
+func (r *sponsorRepository) Create(sponsor entity.Sponsor, placement entity.SponsorPlacement) (entity.Sponsor, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Placements").Create(&sponsor).Error; err != nil {
+			return err
+		}
+
+		placement.SponsorID = sponsor.ID
+		return createPlacement(tx, &placement)
+	})
+	if err != nil {
+		return entity.Sponsor{}, err
+	}
+
+	sponsor.Placements = []entity.SponsorPlacement{placement}
+	return sponsor, nil
+}
+
+// the tracked links keep their alias and click count,
+// only the destination follows the sponsor's website
+func (r *sponsorRepository) Update(sponsor entity.Sponsor) (entity.Sponsor, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Placements").Save(&sponsor).Error; err != nil {
+			return err
+		}
+
+		placements := tx.Model(&entity.SponsorPlacement{}).Select("link_shortener_id").Where("sponsor_id = ?", sponsor.ID)
+		return tx.Model(&entity.LinkShortener{}).Where("id IN (?)", placements).Update("link", sponsor.Website).Error
+	})
+	if err != nil {
+		return entity.Sponsor{}, err
+	}
+
+	return sponsor, nil
+}
+
+// the short links are left in place, printed material may still point to them
+func (r *sponsorRepository) Delete(id string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("sponsor_id = ?", id).Delete(&entity.SponsorPlacement{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&entity.Sponsor{}).Error
+	})
+}
+
+func (r *sponsorRepository) GetAll(tier string) ([]entity.Sponsor, error) {
+	var sponsors []entity.Sponsor
+
+	query := r.db.Preload("Placements.LinkShortener")
+	if tier != "" {
+		query = query.Where("tier = ?", tier)
+	}
+
+	if err := query.Order("display_order, name").Find(&sponsors).Error; err != nil {
+		return nil, err
+	}
+
+	return sponsors, nil
+}
+
+func (r *sponsorRepository) GetByID(id string) (entity.Sponsor, error) {
+	var sponsor entity.Sponsor
+	if err := r.db.Preload("Placements.LinkShortener").Where("id = ?", id).Take(&sponsor).Error; err != nil {
+		return entity.Sponsor{}, err
+	}
+
+	return sponsor, nil
+}
+
+func (r *sponsorRepository) CreatePlacement(placement entity.SponsorPlacement) (entity.SponsorPlacement, error) {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return createPlacement(tx, &placement)
+	}); err != nil {
+		return entity.SponsorPlacement{}, err
+	}
+
+	return placement, nil
+}
+
+func (r *sponsorRepository) CheckPlacementExist(sponsorID string, placement string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.SponsorPlacement{}).Where("sponsor_id = ? AND placement = ?", sponsorID, placement).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// the short link is created first so the placement can point to it,
+// dto.ErrAliasHasBeenTaken is returned when its alias is already used
+func createPlacement(tx *gorm.DB, placement *entity.SponsorPlacement) error {
+	if err := createLinkShortener(tx, placement.LinkShortener); err != nil {
+		return err
+	}
+
+	placement.LinkShortenerID = placement.LinkShortener.ID
+	return tx.Omit("LinkShortener").Create(placement).Error
+}
@@ -14,6 +14,7 @@ type (
 	BucketRepository interface {
 		UploadFile(string, *multipart.FileHeader) error
 		DownloadFile(string, string) ([]byte, error)
+		DeleteFile(string, string) error
 	}
 
 	bucketRepository struct {
@@ -100,3 +101,24 @@ func (r *bucketRepository) DownloadFile(folder, filename string) ([]byte, error)
 
 	return data, nil
 }
+
+// deleting a file that is already gone is not an error
+func (r *bucketRepository) DeleteFile(folder, filename string) error {
+	url := r.bucket.BucketURL + folder + "/" + filename
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.bucket.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return dto.ErrFailedToDeleteFile
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	SurveyRepository interface {
+		Create(entity.Survey) (entity.Survey, error)
+		GetAll() ([]entity.Survey, error)
+		GetByID(string) (entity.Survey, error)
+		GetInvitedAttendees(surveyID string) ([]entity.SurveyInvitation, error)
+		CreateInvitation(entity.SurveyInvitation) (entity.SurveyInvitation, error)
+		UpdateInvitation(entity.SurveyInvitation) (entity.SurveyInvitation, error)
+		GetInvitationByTokenHash(string) (entity.SurveyInvitation, error)
+		SubmitResponse(invitation entity.SurveyInvitation, response entity.SurveyResponse) error
+		CountInvitations(surveyID string) (int64, int64, error)
+		CountResponsesPerEvent(surveyID string) ([]dto.SurveyResponseCount, error)
+		AggregateAnswers(surveyID string) ([]dto.SurveyAnswerAggregate, error)
+		HasPendingRequiredSurvey(ticketID string, rsvpID string) (bool, error)
+	}
+
+	surveyRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewSurveyRepository(db *gorm.DB) SurveyRepository {
+	return &surveyRepository{
+		db: db,
+	}
+}
+
+func (r *surveyRepository) Create(survey entity.Survey) (entity.Survey, error) {
+	// events are only linked, never created from here
+	if err := r.db.Omit("Events.*").Create(&survey).Error; err != nil {
+		return entity.Survey{}, err
+	}
+
+	return survey, nil
+}
+
+func (r *surveyRepository) GetAll() ([]entity.Survey, error) {
+	var surveys []entity.Survey
+	if err := r.db.Preload("Events").Order("created_at DESC").Find(&surveys).Error; err != nil {
+		return nil, err
+	}
+
+	return surveys, nil
+}
+
+func (r *surveyRepository) GetByID(id string) (entity.Survey, error) {
+	var survey entity.Survey
+	err := r.db.
+		Preload("Events").
+		Preload("Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Where("id = ?", id).
+		Take(&survey).Error
+	if err != nil {
+		return entity.Survey{}, err
+	}
+
+	return survey, nil
+}
+
+func (r *surveyRepository) GetInvitedAttendees(surveyID string) ([]entity.SurveyInvitation, error) {
+	var invitations []entity.SurveyInvitation
+	if err := r.db.Where("survey_id = ?", surveyID).Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+
+	return invitations, nil
+}
+
+func (r *surveyRepository) CreateInvitation(invitation entity.SurveyInvitation) (entity.SurveyInvitation, error) {
+	if err := r.db.Create(&invitation).Error; err != nil {
+		return entity.SurveyInvitation{}, err
+	}
+
+	return invitation, nil
+}
+
+func (r *surveyRepository) UpdateInvitation(invitation entity.SurveyInvitation) (entity.SurveyInvitation, error) {
+	if err := r.db.Omit("Survey").Save(&invitation).Error; err != nil {
+		return entity.SurveyInvitation{}, err
+	}
+
+	return invitation, nil
+}
+
+func (r *surveyRepository) GetInvitationByTokenHash(hash string) (entity.SurveyInvitation, error) {
+	var invitation entity.SurveyInvitation
+	err := r.db.
+		Preload("Survey.Questions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Where("token_hash = ?", hash).
+		Take(&invitation).Error
+	if err != nil {
+		return entity.SurveyInvitation{}, err
+	}
+
+	return invitation, nil
+}
+
+func (r *surveyRepository) SubmitResponse(invitation entity.SurveyInvitation, response entity.SurveyResponse) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// guard against the same link being submitted twice concurrently
+		result := tx.Model(&entity.SurveyInvitation{}).
+			Where("id = ? AND completed_at IS NULL", invitation.ID).
+			Update("completed_at", time.Now())
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return dto.ErrSurveyAlreadyCompleted
+		}
+
+		return tx.Create(&response).Error
+	})
+}
+
+func (r *surveyRepository) CountInvitations(surveyID string) (int64, int64, error) {
+	var invited int64
+	if err := r.db.Model(&entity.SurveyInvitation{}).Where("survey_id = ?", surveyID).Count(&invited).Error; err != nil {
+		return 0, 0, err
+	}
+
+	var completed int64
+	if err := r.db.Model(&entity.SurveyInvitation{}).Where("survey_id = ? AND completed_at IS NOT NULL", surveyID).Count(&completed).Error; err != nil {
+		return 0, 0, err
+	}
+
+	return invited, completed, nil
+}
+
+func (r *surveyRepository) CountResponsesPerEvent(surveyID string) ([]dto.SurveyResponseCount, error) {
+	var counts []dto.SurveyResponseCount
+	err := r.db.
+		Model(&entity.SurveyResponse{}).
+		Select("event_id, COUNT(*) AS total").
+		Where("survey_id = ?", surveyID).
+		Group("event_id").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *surveyRepository) AggregateAnswers(surveyID string) ([]dto.SurveyAnswerAggregate, error) {
+	var rows []dto.SurveyAnswerAggregate
+	err := r.db.
+		Model(&entity.SurveyAnswer{}).
+		Select("survey_answers.question_id, survey_responses.event_id, survey_answers.score, COUNT(*) AS total").
+		Joins("JOIN survey_responses ON survey_answers.survey_response_id = survey_responses.id").
+		Where("survey_responses.survey_id = ?", surveyID).
+		Group("survey_answers.question_id, survey_responses.event_id, survey_answers.score").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (r *surveyRepository) HasPendingRequiredSurvey(ticketID string, rsvpID string) (bool, error) {
+	var count int64
+	err := r.db.
+		Model(&entity.SurveyInvitation{}).
+		Joins("JOIN surveys ON survey_invitations.survey_id = surveys.id").
+		Where("surveys.required_for_certificate = ?", true).
+		Where("survey_invitations.completed_at IS NULL").
+		Where("survey_invitations.ticket_id = ? OR survey_invitations.rsvp_id = ?", ticketID, rsvpID).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 
 	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
 	"github.com/TEDxITS/website-backend-2024/entity"
 
 	"gorm.io/gorm"
@@ -13,16 +14,23 @@ import (
 type (
 	TicketRepository interface {
 		CreateTicket(ticket entity.Ticket) (entity.Ticket, error)
-		JoinGetAllPaginationME(search string, limit, page int) ([]entity.Ticket, int64, int64, error)
+		JoinGetAllPaginationME(search string, limit, page int, eventIDs []string) ([]entity.Ticket, int64, int64, error)
 		JoinGetAllPaginationPE3(search string, limit, page int) ([]entity.Ticket, int64, int64, error)
 		FindByUserID(userID string) (entity.Ticket, error)
 		UpdateTicket(ticket entity.Ticket) (entity.Ticket, error)
 		GetTicketByUserId(userId string) (entity.Ticket, error)
 		FindByTicketID(ticketID string) (entity.Ticket, error)
 		GetTicketById(id string) (entity.Ticket, error)
-		CountME() (int64, int64, int64, error)
+		CountME(eventIDs []string) (int64, int64, int64, error)
 		CountPE3() (int64, int64, int64, error)
 		FindAll() ([]entity.Ticket, error)
+		FindCheckedInByUserID(userID string) ([]entity.Ticket, error)
+		FindCheckedInByEventID(eventID string) ([]entity.Ticket, error)
+		CreateCompTicket(ticket entity.Ticket) (entity.Ticket, error)
+		CheckUserHasTicket(userID string, eventID string) (bool, error)
+		FindConfirmedByUserID(userID string) ([]entity.Ticket, error)
+		StreamME(search string, fn func([]entity.Ticket) error) error
+		StreamPE3(search string, fn func([]entity.Ticket) error) error
 	}
 
 	ticketRepository struct {
@@ -45,7 +53,7 @@ func (r *ticketRepository) CreateTicket(ticket entity.Ticket) (entity.Ticket, er
 	return ticket, nil
 }
 
-func (r *ticketRepository) JoinGetAllPaginationME(search string, limit, page int) ([]entity.Ticket, int64, int64, error) {
+func (r *ticketRepository) JoinGetAllPaginationME(search string, limit, page int, eventIDs []string) ([]entity.Ticket, int64, int64, error) {
 	var tickets []entity.Ticket
 	var count int64
 
@@ -56,12 +64,13 @@ func (r *ticketRepository) JoinGetAllPaginationME(search string, limit, page int
 			Joins("JOIN events ON tickets.event_id = events.id").
 			Where("users.name LIKE ?", "%"+search+"%").
 			Where("event_id <> ?", constants.PreEvent3ID).
+			Scopes(ticketEventScope(eventIDs)).
 			Count(&count).Error
 		if err != nil {
 			return nil, 0, 0, err
 		}
 	} else {
-		err := r.db.Model(&entity.Ticket{}).Where("event_id <> ?", constants.PreEvent3ID).Count(&count).Error
+		err := r.db.Model(&entity.Ticket{}).Where("event_id <> ?", constants.PreEvent3ID).Scopes(ticketEventScope(eventIDs)).Count(&count).Error
 		if err != nil {
 			return nil, 0, 0, err
 		}
@@ -77,6 +86,7 @@ func (r *ticketRepository) JoinGetAllPaginationME(search string, limit, page int
 		Preload(clause.Associations).
 		Where("users.name LIKE ?", "%"+search+"%").
 		Where("event_id <> ?", constants.PreEvent3ID).
+		Scopes(ticketEventScope(eventIDs)).
 		Offset(offset).
 		Limit(limit).
 		Find(&tickets).Error
@@ -145,19 +155,19 @@ func (r *ticketRepository) GetTicketById(id string) (entity.Ticket, error) {
 	return ticket, nil
 }
 
-func (r *ticketRepository) CountME() (int64, int64, int64, error) {
+func (r *ticketRepository) CountME(eventIDs []string) (int64, int64, int64, error) {
 	var total int64
-	if err := r.db.Model(&entity.Ticket{}).Where("event_id <> ?", constants.PreEvent3ID).Count(&total).Error; err != nil {
+	if err := r.db.Model(&entity.Ticket{}).Where("event_id <> ?", constants.PreEvent3ID).Scopes(ticketEventScope(eventIDs)).Count(&total).Error; err != nil {
 		return 0, 0, 0, err
 	}
 
 	var confirmed int64
-	if err := r.db.Model(&entity.Ticket{}).Where("payment_confirmed = ? AND event_id <> ?", true, constants.PreEvent3ID).Count(&confirmed).Error; err != nil {
+	if err := r.db.Model(&entity.Ticket{}).Where("payment_confirmed = ? AND event_id <> ?", true, constants.PreEvent3ID).Scopes(ticketEventScope(eventIDs)).Count(&confirmed).Error; err != nil {
 		return 0, 0, 0, err
 	}
 
 	var checked int64
-	if err := r.db.Model(&entity.Ticket{}).Where("checked_in = ? AND event_id <> ?", true, constants.PreEvent3ID).Count(&checked).Error; err != nil {
+	if err := r.db.Model(&entity.Ticket{}).Where("checked_in = ? AND event_id <> ?", true, constants.PreEvent3ID).Scopes(ticketEventScope(eventIDs)).Count(&checked).Error; err != nil {
 		return 0, 0, 0, err
 	}
 
@@ -221,3 +231,104 @@ func (r *ticketRepository) FindAll() ([]entity.Ticket, error) {
 
 	return tickets, nil
 }
+
+func (r *ticketRepository) FindCheckedInByUserID(userID string) ([]entity.Ticket, error) {
+	var tickets []entity.Ticket
+	err := r.db.
+		Preload(clause.Associations).
+		Where("user_id = ? AND checked_in = ?", userID, true).
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+func (r *ticketRepository) FindCheckedInByEventID(eventID string) ([]entity.Ticket, error) {
+	var tickets []entity.Ticket
+	err := r.db.
+		Preload(clause.Associations).
+		Where("event_id = ? AND checked_in = ?", eventID, true).
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+func (r *ticketRepository) CreateCompTicket(ticket entity.Ticket) (entity.Ticket, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// lock the event row so parallel imports can not go over the comp quota
+		var event entity.Event
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", ticket.EventID).Take(&event).Error; err != nil {
+			return err
+		}
+
+		if event.CompRegisters >= event.CompCapacity {
+			return dto.ErrCompQuotaFull
+		}
+
+		return tx.Create(&ticket).Error
+	})
+	if err != nil {
+		return entity.Ticket{}, err
+	}
+
+	return ticket, nil
+}
+
+func (r *ticketRepository) CheckUserHasTicket(userID string, eventID string) (bool, error) {
+	var count int64
+	if err := r.db.Model(&entity.Ticket{}).Where("user_id = ? AND event_id = ?", userID, eventID).Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (r *ticketRepository) FindConfirmedByUserID(userID string) ([]entity.Ticket, error) {
+	var tickets []entity.Ticket
+	err := r.db.
+		Where("user_id = ? AND payment_confirmed = ?", userID, true).
+		Find(&tickets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+func (r *ticketRepository) StreamME(search string, fn func([]entity.Ticket) error) error {
+	return r.streamJoined(search, r.db.Where("event_id <> ?", constants.PreEvent3ID), fn)
+}
+
+func (r *ticketRepository) StreamPE3(search string, fn func([]entity.Ticket) error) error {
+	return r.streamJoined(search, r.db.Where("event_id = ?", constants.PreEvent3ID), fn)
+}
+
+// same joins as the paginated listing, but fed to fn in batches instead of pages
+func (r *ticketRepository) streamJoined(search string, scope *gorm.DB, fn func([]entity.Ticket) error) error {
+	var tickets []entity.Ticket
+	return r.db.
+		Model(&entity.Ticket{}).
+		Joins("JOIN users ON tickets.user_id = users.id").
+		Joins("JOIN events ON tickets.event_id = events.id").
+		Preload(clause.Associations).
+		Where("users.name LIKE ?", "%"+search+"%").
+		Where(scope).
+		FindInBatches(&tickets, constants.EXPORT_BATCH_SIZE, func(tx *gorm.DB, batch int) error {
+			return fn(tickets)
+		}).Error
+}
+
+// a key scoped to some tiers only sees their tickets, no ids means every tier
+func ticketEventScope(eventIDs []string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if len(eventIDs) == 0 {
+			return db
+		}
+		return db.Where("tickets.event_id IN ?", eventIDs)
+	}
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	UserIdentityRepository interface {
+		Create(entity.UserIdentity) (entity.UserIdentity, error)
+		GetByProviderSubject(provider string, subject string) (entity.UserIdentity, error)
+	}
+
+	userIdentityRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewUserIdentityRepository(db *gorm.DB) UserIdentityRepository {
+	return &userIdentityRepository{
+		db: db,
+	}
+}
+
+func (r *userIdentityRepository) Create(identity entity.UserIdentity) (entity.UserIdentity, error) {
+	if err := r.db.Omit("User").Create(&identity).Error; err != nil {
+		return entity.UserIdentity{}, err
+	}
+
+	return identity, nil
+}
+
+func (r *userIdentityRepository) GetByProviderSubject(provider string, subject string) (entity.UserIdentity, error) {
+	var identity entity.UserIdentity
+	err := r.db.
+		Preload("User.Role").
+		Where("provider = ? AND subject = ?", provider, subject).
+		Take(&identity).Error
+	if err != nil {
+		return entity.UserIdentity{}, err
+	}
+
+	return identity, nil
+}
@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	// every change is written in one transaction with its audit log,
+	// so a change is never left in place without a record of who made it.
+	// Api keys a user created are revoked whenever that user loses access.
+	UserManagementRepository interface {
+		ChangeRole(userId string, roleId string, log entity.AuditLog) error
+		SetSuspension(userId string, suspendedAt *time.Time, reason string, log entity.AuditLog) error
+		Delete(userId string, log entity.AuditLog) error
+		Verify(userId string, log entity.AuditLog) error
+		SetPassword(userId string, hashedPassword string, log entity.AuditLog) error
+		ClearLockout(id string, log entity.AuditLog) error
+	}
+
+	userManagementRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewUserManagementRepository(db *gorm.DB) UserManagementRepository {
+	return &userManagementRepository{
+		db: db,
+	}
+}
+
+func (r *userManagementRepository) ChangeRole(userId string, roleId string, log entity.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := revokeAPIKeysCreatedBy(tx, userId); err != nil {
+			return err
+		}
+
+		return updateUser(tx, userId, map[string]interface{}{"role_id": roleId}, log)
+	})
+}
+
+// a map is needed so the suspension can be cleared again
+func (r *userManagementRepository) SetSuspension(userId string, suspendedAt *time.Time, reason string, log entity.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if suspendedAt != nil {
+			if err := revokeAPIKeysCreatedBy(tx, userId); err != nil {
+				return err
+			}
+		}
+
+		return updateUser(tx, userId, map[string]interface{}{
+			"suspended_at":   suspendedAt,
+			"suspend_reason": reason,
+		}, log)
+	})
+}
+
+func (r *userManagementRepository) Delete(userId string, log entity.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := revokeAPIKeysCreatedBy(tx, userId); err != nil {
+			return err
+		}
+
+		if err := tx.Where("id = ?", userId).Delete(&entity.User{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Actor").Create(&log).Error
+	})
+}
+
+func (r *userManagementRepository) Verify(userId string, log entity.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return updateUser(tx, userId, map[string]interface{}{"verified": true}, log)
+	})
+}
+
+func (r *userManagementRepository) SetPassword(userId string, hashedPassword string, log entity.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return updateUser(tx, userId, map[string]interface{}{"password": hashedPassword}, log)
+	})
+}
+
+func (r *userManagementRepository) ClearLockout(id string, log entity.AuditLog) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ?", id).Delete(&entity.LoginLockout{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Omit("Actor").Create(&log).Error
+	})
+}
+
+func updateUser(tx *gorm.DB, userId string, fields map[string]interface{}, log entity.AuditLog) error {
+	if err := tx.Model(&entity.User{}).Where("id = ?", userId).Updates(fields).Error; err != nil {
+		return err
+	}
+
+	return tx.Omit("Actor").Create(&log).Error
+}
+
+func revokeAPIKeysCreatedBy(tx *gorm.DB, userId string) error {
+	return tx.Model(&entity.APIKey{}).
+		Where("created_by_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", time.Now()).Error
+}
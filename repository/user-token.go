@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"gorm.io/gorm"
+)
+
+type (
+	UserTokenRepository interface {
+		Create(token entity.UserToken) (entity.UserToken, error)
+		GetByHash(hash string) (entity.UserToken, error)
+		Consume(token entity.UserToken) error
+		PurgeExpired(before time.Time) error
+	}
+
+	userTokenRepository struct {
+		db *gorm.DB
+	}
+)
+
+func NewUserTokenRepository(db *gorm.DB) UserTokenRepository {
+	return &userTokenRepository{
+		db: db,
+	}
+}
+
+// any link of the same purpose sent earlier stops working once a new one is issued
+func (r *userTokenRepository) Create(token entity.UserToken) (entity.UserToken, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.UserToken{}).
+			Where("user_id = ? AND purpose = ? AND consumed_at IS NULL", token.UserID, token.Purpose).
+			Update("consumed_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&token).Error
+	})
+	if err != nil {
+		return entity.UserToken{}, err
+	}
+
+	return token, nil
+}
+
+func (r *userTokenRepository) GetByHash(hash string) (entity.UserToken, error) {
+	var token entity.UserToken
+	if err := r.db.Where("token_hash = ?", hash).Take(&token).Error; err != nil {
+		return entity.UserToken{}, err
+	}
+	return token, nil
+}
+
+// conditional on still being unused, two requests racing with the same link can not both win
+func (r *userTokenRepository) Consume(token entity.UserToken) error {
+	result := r.db.
+		Model(&entity.UserToken{}).
+		Where("id = ? AND consumed_at IS NULL", token.ID).
+		Update("consumed_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (r *userTokenRepository) PurgeExpired(before time.Time) error {
+	return r.db.
+		Where("expires_at < ? OR consumed_at < ?", before, before).
+		Delete(&entity.UserToken{}).Error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"math"
 
+	"github.com/TEDxITS/website-backend-2024/constants"
 	"github.com/TEDxITS/website-backend-2024/entity"
 	"gorm.io/gorm"
 )
@@ -11,10 +12,14 @@ type (
 	UserRepository interface {
 		RegisterUser(user entity.User) (entity.User, error)
 		GetUserById(userId string) (entity.User, error)
+		GetAccount(userId string) (entity.User, error)
 		GetUserByEmail(email string) (entity.User, error)
 		GetAllUserPagination(search string, limit int, page int) ([]entity.User, int64, int64, error)
 		CheckEmailExist(email string) (bool, error)
 		UpdateUser(user entity.User) (entity.User, error)
+		Stream(search string, fn func([]entity.User) error) error
+		GetUserByCalendarTokenHash(hash string) (entity.User, error)
+		UpdateProfile(user entity.User) error
 	}
 
 	userRepository struct {
@@ -50,6 +55,15 @@ func (r *userRepository) GetUserById(userId string) (entity.User, error) {
 	return user, nil
 }
 
+// soft deleted accounts are not found, which is what locks them out
+func (r *userRepository) GetAccount(userId string) (entity.User, error) {
+	var user entity.User
+	if err := r.db.Preload("Role").Where("id = ?", userId).Take(&user).Error; err != nil {
+		return entity.User{}, err
+	}
+	return user, nil
+}
+
 func (r *userRepository) GetUserByEmail(email string) (entity.User, error) {
 	var user entity.User
 	if err := r.db.Where("email = ?", email).Take(&user).Error; err != nil {
@@ -107,3 +121,30 @@ func (r *userRepository) UpdateUser(user entity.User) (entity.User, error) {
 	}
 	return user, nil
 }
+
+func (r *userRepository) Stream(search string, fn func([]entity.User) error) error {
+	var users []entity.User
+	return r.db.
+		Preload("Role").
+		Where("name LIKE ?", "%"+search+"%").
+		FindInBatches(&users, constants.EXPORT_BATCH_SIZE, func(tx *gorm.DB, batch int) error {
+			return fn(users)
+		}).Error
+}
+
+func (r *userRepository) GetUserByCalendarTokenHash(hash string) (entity.User, error) {
+	var user entity.User
+	if err := r.db.Where("calendar_token_hash = ?", hash).Take(&user).Error; err != nil {
+		return entity.User{}, err
+	}
+	return user, nil
+}
+
+// the name and profile columns are always written so a field can be cleared,
+// callers pass the whole user as loaded
+func (r *userRepository) UpdateProfile(user entity.User) error {
+	return r.db.Model(&entity.User{}).
+		Where("id = ?", user.ID).
+		Select("name", "handphone", "birthdate", "institute", "department", "student_id", "batch").
+		Updates(&user).Error
+}
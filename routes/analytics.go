@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+)
+
+func Analytics(route *gin.Engine, analyticsController controller.AnalyticsController, demographicController controller.DemographicController, jwtService config.JWTService) {
+	routes := route.Group("/api/analytics", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN))
+	{
+		routes.GET("/sales/revenue", analyticsController.GetSalesRevenue)
+		routes.GET("/sales/timeline", analyticsController.GetSalesTimeline)
+		routes.GET("/sales/sell-out", analyticsController.GetSellOut)
+		routes.GET("/sales/conversion", analyticsController.GetConversion)
+		routes.GET("/demographics/:event_id", demographicController.GetDemographicReport)
+		routes.GET("/demographics/:event_id/export", demographicController.ExportDemographicReport)
+	}
+}
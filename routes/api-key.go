@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func APIKey(route *gin.Engine, apiKeyController controller.APIKeyController, jwtService config.JWTService) {
+	routes := route.Group("/api/api-keys", middleware.Authenticate(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_API_KEY_MANAGE))
+	{
+		routes.GET("", apiKeyController.GetAll)
+		routes.POST("", apiKeyController.Create)
+		routes.DELETE("/:id", apiKeyController.Revoke)
+	}
+}
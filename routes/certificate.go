@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func Certificate(route *gin.Engine, certificateController controller.CertificateController, jwtService config.JWTService) {
+	routes := route.Group("/api/certificates")
+	{
+		routes.GET("", middleware.Authenticate(jwtService), certificateController.GetMyCertificates)
+		routes.GET("/verify/:code", certificateController.VerifyCertificate)
+		routes.POST("/bulk", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), certificateController.BulkGenerate)
+		routes.GET("/:code/download", middleware.Authenticate(jwtService), certificateController.DownloadCertificate)
+	}
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+)
+
+func Complimentary(route *gin.Engine, complimentaryController controller.ComplimentaryController, jwtService config.JWTService) {
+	routes := route.Group("/api/ticket/complimentary", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN))
+	{
+		routes.POST("", complimentaryController.IssueCompTicket)
+		routes.POST("/import", complimentaryController.ImportCompTickets)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+)
+
+func Export(route *gin.Engine, exportController controller.ExportController, jwtService config.JWTService) {
+	routes := route.Group("/api", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN))
+	{
+		routes.GET("/ticket/main-event/export", exportController.ExportMainEvent)
+		routes.GET("/ticket/pre-event-3/export", exportController.ExportPreEvent3)
+		routes.GET("/ticket/pre-event-2/export", exportController.ExportPE2RSVP)
+		routes.GET("/user/export", exportController.ExportUser)
+	}
+}
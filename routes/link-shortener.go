@@ -12,7 +12,7 @@ func LinkShortener(route *gin.Engine, linkShortenerController controller.LinkSho
 	routes := route.Group("/api/links")
 	{
 		routes.GET("/:alias", linkShortenerController.RedirectByAlias)
-		routes.GET("", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), linkShortenerController.GetAllPagination)
-		routes.POST("", linkShortenerController.Create)
+		routes.GET("", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_LINK_MANAGE), linkShortenerController.GetAllPagination)
+		routes.POST("", middleware.RateLimit(jwtService, constants.RATE_LIMIT_LINK_CREATE, middleware.RateLimitByIP), linkShortenerController.Create)
 	}
 }
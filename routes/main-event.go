@@ -12,14 +12,14 @@ func MainEvent(route *gin.Engine, mainEventController controller.MainEventContro
 	routes := route.Group("/api/ticket")
 	{
 		routes.POST("/main-event", middleware.Authenticate(jwtService), mainEventController.RegisterMainEvent)
-		routes.POST("/main-event/check-in", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), mainEventController.CheckIn)
-		routes.POST("/main-event/confirm-payment", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), mainEventController.ConfirmPayment)
-		routes.GET("/main-event", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), mainEventController.GetMainEventPaginated)
-		routes.GET("/main-event/counter", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), mainEventController.GetMainEventCounter)
+		routes.POST("/main-event/check-in", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_TICKET_CHECKIN), middleware.RequireEvent(constants.MainEventIDs...), mainEventController.CheckIn)
+		routes.POST("/main-event/confirm-payment", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_PAYMENT_CONFIRM), middleware.RequireEvent(constants.MainEventIDs...), mainEventController.ConfirmPayment)
+		routes.GET("/main-event", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_TICKET_READ), middleware.RequireEvent(constants.MainEventIDs...), mainEventController.GetMainEventPaginated)
+		routes.GET("/main-event/counter", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_TICKET_READ), middleware.RequireEvent(constants.MainEventIDs...), mainEventController.GetMainEventCounter)
 		routes.GET("/main-event/status", mainEventController.GetStatus)
 		// routes.GET("/main-event/status/early-bird")
 		// routes.GET("/main-event/status/pre-sale")
 		// routes.GET("/main-event/status/normal")
-		routes.GET("/main-event/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), mainEventController.GetMainEventDetail)
+		routes.GET("/main-event/:id", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_TICKET_READ), middleware.RequireEvent(constants.MainEventIDs...), mainEventController.GetMainEventDetail)
 	}
 }
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func MFA(route *gin.Engine, mfaController controller.MFAController, jwtService config.JWTService) {
+	routes := route.Group("/api/user/mfa")
+	{
+		// second login step, authorized by the mfa token from the login response
+		routes.POST("/verify", middleware.RateLimit(jwtService, constants.RATE_LIMIT_MFA, middleware.RateLimitByIP), mfaController.Verify)
+		routes.POST("/setup", middleware.RateLimit(jwtService, constants.RATE_LIMIT_MFA, middleware.RateLimitByIP), mfaController.Setup)
+		routes.POST("/setup/confirm", middleware.RateLimit(jwtService, constants.RATE_LIMIT_MFA, middleware.RateLimitByIP), mfaController.ConfirmSetup)
+
+		routes.GET("", middleware.Authenticate(jwtService), mfaController.GetStatus)
+		routes.POST("/enroll", middleware.Authenticate(jwtService), mfaController.Enroll)
+		routes.POST("/activate", middleware.Authenticate(jwtService), middleware.RateLimit(jwtService, constants.RATE_LIMIT_MFA, middleware.RateLimitByUserID), mfaController.Activate)
+		routes.POST("/disable", middleware.Authenticate(jwtService), middleware.RateLimit(jwtService, constants.RATE_LIMIT_MFA, middleware.RateLimitByUserID), mfaController.Disable)
+		routes.POST("/recovery-codes", middleware.Authenticate(jwtService), middleware.RateLimit(jwtService, constants.RATE_LIMIT_MFA, middleware.RateLimitByUserID), mfaController.RegenerateRecoveryCodes)
+	}
+}
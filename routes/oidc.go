@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/gin-gonic/gin"
+)
+
+func OIDC(route *gin.Engine, oidcController controller.OIDCController, jwtService config.JWTService) {
+	routes := route.Group("/api/user/oauth")
+	{
+		routes.GET("/:provider", oidcController.GetAuthURL)
+		routes.POST("/:provider/callback", oidcController.Callback)
+	}
+}
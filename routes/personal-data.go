@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func PersonalData(route *gin.Engine, personalDataController controller.PersonalDataController, jwtService config.JWTService) {
+	routes := route.Group("/api/user/me")
+	{
+		routes.GET("/export", middleware.Authenticate(jwtService), middleware.RateLimit(jwtService, constants.RATE_LIMIT_DATA_EXPORT, middleware.RateLimitByUserID), personalDataController.Export)
+		routes.POST("/delete", middleware.Authenticate(jwtService), middleware.RateLimit(jwtService, constants.RATE_LIMIT_EMAIL, middleware.RateLimitByUserID), personalDataController.RequestDeletion)
+		routes.POST("/delete/confirm", personalDataController.ConfirmDeletion)
+	}
+}
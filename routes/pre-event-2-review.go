@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+)
+
+func PreEvent2Review(route *gin.Engine, reviewController controller.PreEvent2ReviewController, jwtService config.JWTService) {
+	// reviewers only ever see their own blind assignments
+	reviewer := route.Group("/api/ticket/pre-event-2", middleware.Authenticate(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_PE2_REVIEW))
+	{
+		reviewer.GET("/review/rubric", reviewController.GetRubric)
+		reviewer.GET("/review", reviewController.GetMyReviews)
+		reviewer.POST("/review/:id", reviewController.SubmitReview)
+	}
+
+	routes := route.Group("/api/ticket/pre-event-2", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN))
+	{
+		routes.GET("/review/summary", reviewController.GetReviewSummary)
+		routes.GET("/review/similarity", reviewController.GetEssaySimilarities)
+		routes.POST("/review/assign", reviewController.AssignReviewers)
+		routes.GET("/:id/review", reviewController.GetRSVPReviews)
+		routes.POST("/:id/decision", reviewController.DecideRSVP)
+	}
+}
@@ -12,10 +12,15 @@ import (
 func PreEvent2(route *gin.Engine, preevent2Controller controller.PreEvent2Controller, jwtService config.JWTService) {
 	routes := route.Group("/api/ticket")
 	{
-		routes.POST("/pre-event-2", preevent2Controller.CreatePE2RSVP)
-		routes.GET("/pre-event-2", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), preevent2Controller.GetPE2RSVPPaginated)
-		routes.GET("/pre-event-2/counter", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), preevent2Controller.GetPE2RSVPCounter)
+		routes.POST("/pre-event-2", middleware.RateLimit(jwtService, constants.RATE_LIMIT_RSVP, middleware.RateLimitByIP), middleware.AuthenticateOptional(jwtService), preevent2Controller.CreatePE2RSVP)
+		routes.GET("/pre-event-2", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_TICKET_READ), middleware.RequireEvent(constants.PreEvent2ID), preevent2Controller.GetPE2RSVPPaginated)
+		routes.POST("/pre-event-2/check-in", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_TICKET_CHECKIN), middleware.RequireEvent(constants.PreEvent2ID), preevent2Controller.CheckInPE2RSVP)
+		routes.GET("/pre-event-2/counter", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_TICKET_READ), middleware.RequireEvent(constants.PreEvent2ID), preevent2Controller.GetPE2RSVPCounter)
 		routes.GET("/pre-event-2/status", preevent2Controller.GetPE2RSVPStatus)
-		routes.GET("/pre-event-2/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), preevent2Controller.GetPE2RSVPDetail)
+		routes.GET("/pre-event-2/me", preevent2Controller.GetMyPE2RSVP)
+		routes.PUT("/pre-event-2/me", preevent2Controller.UpdateMyPE2RSVP)
+		routes.DELETE("/pre-event-2/me", preevent2Controller.WithdrawMyPE2RSVP)
+		routes.POST("/pre-event-2/me/resend", middleware.RateLimit(jwtService, constants.RATE_LIMIT_EMAIL, middleware.RateLimitByEmail), middleware.RateLimit(jwtService, constants.RATE_LIMIT_EMAIL_IP, middleware.RateLimitByIP), preevent2Controller.ResendPE2RSVPLink)
+		routes.GET("/pre-event-2/:id", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_TICKET_READ), middleware.RequireEvent(constants.PreEvent2ID), preevent2Controller.GetPE2RSVPDetail)
 	}
 }
@@ -12,7 +12,7 @@ func PreEvent3(r *gin.Engine, c controller.PreEvent3Controller, jwt config.JWTSe
 	preEvent3 := r.Group("/api/ticket/pre-event-3")
 	{
 		preEvent3.POST("", middleware.Authenticate(jwt), c.RegisterPreEvent3)
-		preEvent3.GET("", middleware.Authenticate(jwt), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), c.GetPreEvent3Paginated)
+		preEvent3.GET("", middleware.AuthenticateWithAPIKey(jwt), middleware.RequirePermission(jwt, constants.PERMISSION_TICKET_READ), middleware.RequireEvent(constants.PreEvent3ID), c.GetPreEvent3Paginated)
 		preEvent3.GET("/status", c.GetPreEvent3Status)
 		preEvent3.GET("/counter", c.GetPreEvent3Counter)
 	}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func Role(route *gin.Engine, roleController controller.RoleController, jwtService config.JWTService) {
+	routes := route.Group("/api/roles", middleware.Authenticate(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_ROLE_MANAGE))
+	{
+		routes.GET("", roleController.GetAllRole)
+		routes.GET("/permissions", roleController.GetPermissions)
+		routes.GET("/:id", roleController.GetRoleDetail)
+		routes.POST("", roleController.CreateRole)
+		routes.PUT("/:id", roleController.UpdateRole)
+		routes.DELETE("/:id", roleController.DeleteRole)
+	}
+}
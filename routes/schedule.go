@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+)
+
+func Schedule(route *gin.Engine, scheduleController controller.ScheduleController, jwtService config.JWTService) {
+	routes := route.Group("/api/schedule")
+	{
+		routes.GET("/:event_id", scheduleController.GetSchedule)
+		routes.GET("/:event_id/calendar.ics", scheduleController.GetEventCalendar)
+		routes.GET("/feed/:token", scheduleController.GetPersonalCalendar)
+		routes.POST("/feed", middleware.Authenticate(jwtService), scheduleController.CreateCalendarFeed)
+		routes.POST("/sessions", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), scheduleController.CreateSession)
+		routes.PUT("/sessions/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), scheduleController.UpdateSession)
+		routes.DELETE("/sessions/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), scheduleController.DeleteSession)
+	}
+}
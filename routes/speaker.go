@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+)
+
+func Speaker(route *gin.Engine, speakerController controller.SpeakerController, jwtService config.JWTService) {
+	routes := route.Group("/api/speakers")
+	{
+		routes.GET("", speakerController.GetPublishedSpeakers)
+		routes.GET("/:id", speakerController.GetPublishedSpeakerDetail)
+		routes.GET("/all", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), speakerController.GetAllSpeaker)
+		routes.GET("/all/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), speakerController.GetSpeakerDetail)
+		routes.POST("", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), speakerController.CreateSpeaker)
+		routes.PUT("/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), speakerController.UpdateSpeaker)
+		routes.DELETE("/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), speakerController.DeleteSpeaker)
+		routes.POST("/talks", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), speakerController.CreateTalk)
+		routes.PUT("/talks/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), speakerController.UpdateTalk)
+		routes.DELETE("/talks/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), speakerController.DeleteTalk)
+	}
+}
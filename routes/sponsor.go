@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+)
+
+func Sponsor(route *gin.Engine, sponsorController controller.SponsorController, jwtService config.JWTService) {
+	routes := route.Group("/api/sponsors")
+	{
+		routes.GET("", sponsorController.GetPublicSponsors)
+		routes.GET("/:id", sponsorController.GetPublicSponsorDetail)
+		routes.GET("/all", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), sponsorController.GetAllSponsor)
+		routes.GET("/all/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), sponsorController.GetSponsorDetail)
+		routes.GET("/report", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), sponsorController.GetAllSponsorReport)
+		routes.GET("/report/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), sponsorController.GetSponsorReport)
+		routes.POST("", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), sponsorController.CreateSponsor)
+		routes.PUT("/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), sponsorController.UpdateSponsor)
+		routes.DELETE("/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), sponsorController.DeleteSponsor)
+		routes.POST("/:id/placements", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), sponsorController.CreatePlacement)
+	}
+}
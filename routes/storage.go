@@ -11,6 +11,8 @@ import (
 func Storage(route *gin.Engine, storageController controller.StorageController, jwtService config.JWTService) {
 	routes := route.Group("/api/storage")
 	{
-		routes.GET("/main-event/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), storageController.GetMainEventPaymentFile)
+		routes.GET("/main-event/:id", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_PAYMENT_CONFIRM), middleware.RequireEvent(constants.MainEventIDs...), storageController.GetMainEventPaymentFile)
+		routes.GET("/speaker/:id", storageController.GetSpeakerPhoto)
+		routes.GET("/sponsor/:id", storageController.GetSponsorLogo)
 	}
 }
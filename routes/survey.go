@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func Survey(route *gin.Engine, surveyController controller.SurveyController, jwtService config.JWTService) {
+	routes := route.Group("/api/surveys")
+	{
+		routes.GET("/respond", surveyController.GetByToken)
+		routes.POST("/respond", surveyController.Submit)
+		routes.POST("", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), surveyController.Create)
+		routes.GET("", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), surveyController.GetAll)
+		routes.GET("/:id", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), surveyController.GetDetail)
+		routes.POST("/:id/invite", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), surveyController.Invite)
+		routes.GET("/:id/result", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), surveyController.GetResult)
+	}
+}
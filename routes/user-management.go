@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/TEDxITS/website-backend-2024/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+func UserManagement(route *gin.Engine, userManagementController controller.UserManagementController, jwtService config.JWTService) {
+	routes := route.Group("/api/user/manage", middleware.Authenticate(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_USER_MANAGE))
+	{
+		routes.GET("/audit-logs", userManagementController.GetAuditLogs)
+		routes.GET("/lockouts", userManagementController.GetLockouts)
+		routes.DELETE("/lockouts/:id", userManagementController.ClearLockout)
+		routes.PUT("/:id/role", userManagementController.ChangeRole)
+		routes.POST("/:id/suspend", userManagementController.Suspend)
+		routes.POST("/:id/reactivate", userManagementController.Reactivate)
+		routes.POST("/:id/verify", userManagementController.ForceVerify)
+		routes.POST("/:id/reset-password", userManagementController.ForceResetPassword)
+		routes.DELETE("/:id", userManagementController.Delete)
+	}
+}
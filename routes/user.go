@@ -11,14 +11,21 @@ import (
 func User(route *gin.Engine, userController controller.UserController, jwtService config.JWTService) {
 	routes := route.Group("/api/user")
 	{
-		routes.POST("", userController.Register)
+		routes.POST("", middleware.RateLimit(jwtService, constants.RATE_LIMIT_REGISTER, middleware.RateLimitByIP), userController.Register)
 		routes.POST("/login", userController.Login)
+		routes.POST("/refresh", userController.RefreshToken)
+		routes.POST("/logout", middleware.Authenticate(jwtService), userController.Logout)
+		routes.POST("/logout/all", middleware.Authenticate(jwtService), userController.LogoutAll)
 		routes.PATCH("", middleware.Authenticate(jwtService), userController.Update)
+		routes.PUT("/password", middleware.Authenticate(jwtService), userController.ChangePassword)
+		routes.POST("/email", middleware.Authenticate(jwtService), middleware.RateLimit(jwtService, constants.RATE_LIMIT_EMAIL, middleware.RateLimitByUserID), userController.ChangeEmail)
+		routes.POST("/email/confirm", userController.ConfirmEmail)
 		routes.GET("/me", middleware.Authenticate(jwtService), userController.Me)
-		routes.GET("", middleware.Authenticate(jwtService), middleware.OnlyAllow(constants.ENUM_ROLE_ADMIN), userController.GetAllPagination)
+		routes.GET("", middleware.AuthenticateWithAPIKey(jwtService), middleware.RequirePermission(jwtService, constants.PERMISSION_USER_READ), userController.GetAllPagination)
 		routes.GET("/verify", userController.Verify)
-		routes.POST("/verify/resend", userController.ResendVerifyEmail)
-		routes.POST("/send-reset-password", userController.SendResetPasswordEmail)
+		routes.POST("/verify/resend", middleware.RateLimit(jwtService, constants.RATE_LIMIT_EMAIL, middleware.RateLimitByEmail), middleware.RateLimit(jwtService, constants.RATE_LIMIT_EMAIL_IP, middleware.RateLimitByIP), userController.ResendVerifyEmail)
+		routes.POST("/send-reset-password", middleware.RateLimit(jwtService, constants.RATE_LIMIT_EMAIL, middleware.RateLimitByEmail), middleware.RateLimit(jwtService, constants.RATE_LIMIT_EMAIL_IP, middleware.RateLimitByIP), userController.SendResetPasswordEmail)
 		routes.POST("/reset-password", userController.ResetPassword)
+		routes.POST("/unlock", userController.UnlockAccount)
 	}
 }
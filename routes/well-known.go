@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/controller"
+	"github.com/gin-gonic/gin"
+)
+
+func WellKnown(route *gin.Engine, wellKnownController controller.WellKnownController, jwtService config.JWTService) {
+	routes := route.Group("/.well-known")
+	{
+		routes.GET("/jwks.json", wellKnownController.JWKS)
+	}
+}
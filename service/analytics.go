@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/repository"
+)
+
+type (
+	AnalyticsService interface {
+		GetSalesRevenue(ctx context.Context, req dto.AnalyticsQuery) (dto.SalesRevenueResponse, error)
+		GetSalesTimeline(ctx context.Context, req dto.AnalyticsQuery) (dto.SalesTimelineResponse, error)
+		GetSellOut(ctx context.Context, req dto.AnalyticsQuery) ([]dto.SellOutResponse, error)
+		GetConversion(ctx context.Context, req dto.AnalyticsQuery) (dto.ConversionResponse, error)
+	}
+
+	analyticsService struct {
+		analyticsRepo repository.AnalyticsRepository
+	}
+)
+
+func NewAnalyticsService(aRepo repository.AnalyticsRepository) AnalyticsService {
+	return &analyticsService{
+		analyticsRepo: aRepo,
+	}
+}
+
+func (s *analyticsService) GetSalesRevenue(ctx context.Context, req dto.AnalyticsQuery) (dto.SalesRevenueResponse, error) {
+	filter, err := toAnalyticsFilter(req)
+	if err != nil {
+		return dto.SalesRevenueResponse{}, err
+	}
+
+	rows, err := s.analyticsRepo.GetRevenueByTier(filter)
+	if err != nil {
+		return dto.SalesRevenueResponse{}, err
+	}
+
+	result := dto.SalesRevenueResponse{
+		Tiers: []dto.TierRevenueResponse{},
+	}
+	for _, row := range rows {
+		tier := dto.TierRevenueResponse{
+			EventID:          row.EventID,
+			Name:             row.Name,
+			Price:            row.Price,
+			Sold:             row.Sold,
+			Confirmed:        row.Confirmed,
+			Pending:          row.Sold - row.Confirmed,
+			Complimentary:    row.Complimentary,
+			ConfirmedRevenue: row.ConfirmedRevenue,
+			PendingRevenue:   row.PendingRevenue,
+		}
+
+		result.Tiers = append(result.Tiers, tier)
+		result.Total.Sold += tier.Sold
+		result.Total.Confirmed += tier.Confirmed
+		result.Total.Pending += tier.Pending
+		result.Total.Complimentary += tier.Complimentary
+		result.Total.ConfirmedRevenue += tier.ConfirmedRevenue
+		result.Total.PendingRevenue += tier.PendingRevenue
+	}
+
+	return result, nil
+}
+
+func (s *analyticsService) GetSalesTimeline(ctx context.Context, req dto.AnalyticsQuery) (dto.SalesTimelineResponse, error) {
+	interval := req.Interval
+	if interval == "" {
+		interval = dto.ENUM_ANALYTICS_INTERVAL_DAY
+	}
+
+	if interval != dto.ENUM_ANALYTICS_INTERVAL_HOUR && interval != dto.ENUM_ANALYTICS_INTERVAL_DAY {
+		return dto.SalesTimelineResponse{}, dto.ErrAnalyticsIntervalInvalid
+	}
+
+	filter, err := toAnalyticsFilter(req)
+	if err != nil {
+		return dto.SalesTimelineResponse{}, err
+	}
+
+	rows, err := s.analyticsRepo.GetRegistrationTimeline(interval, filter)
+	if err != nil {
+		return dto.SalesTimelineResponse{}, err
+	}
+
+	result := dto.SalesTimelineResponse{
+		Interval: interval,
+		Buckets:  []dto.SalesTimelineBucket{},
+	}
+	for _, row := range rows {
+		result.Buckets = append(result.Buckets, dto.SalesTimelineBucket{
+			Time:          row.Bucket,
+			EventID:       row.EventID,
+			Name:          row.Name,
+			Registrations: row.Registrations,
+			Confirmed:     row.Confirmed,
+			Revenue:       row.Revenue,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *analyticsService) GetSellOut(ctx context.Context, req dto.AnalyticsQuery) ([]dto.SellOutResponse, error) {
+	filter, err := toAnalyticsFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.analyticsRepo.GetSellOut(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.SellOutResponse{}
+	for _, row := range rows {
+		res := dto.SellOutResponse{
+			EventID:    row.EventID,
+			Name:       row.Name,
+			Capacity:   row.Capacity,
+			Registers:  row.Registers,
+			SalesStart: row.StartDate,
+			SoldOut:    row.SoldOutAt != nil,
+			SoldOutAt:  row.SoldOutAt,
+		}
+
+		if row.SoldOutAt != nil && !row.StartDate.IsZero() {
+			seconds := int64(row.SoldOutAt.Sub(row.StartDate).Seconds())
+			res.SellOutSeconds = &seconds
+		}
+
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+func (s *analyticsService) GetConversion(ctx context.Context, req dto.AnalyticsQuery) (dto.ConversionResponse, error) {
+	filter, err := toAnalyticsFilter(req)
+	if err != nil {
+		return dto.ConversionResponse{}, err
+	}
+
+	row, err := s.analyticsRepo.GetConversion(filter)
+	if err != nil {
+		return dto.ConversionResponse{}, err
+	}
+
+	result := dto.ConversionResponse{
+		RegisteredUsers: row.RegisteredUsers,
+		VerifiedUsers:   row.VerifiedUsers,
+		Buyers:          row.Buyers,
+		ConfirmedBuyers: row.ConfirmedBuyers,
+	}
+
+	if row.RegisteredUsers > 0 {
+		result.BuyerRate = float64(row.Buyers) / float64(row.RegisteredUsers)
+		result.ConfirmedRate = float64(row.ConfirmedBuyers) / float64(row.RegisteredUsers)
+	}
+
+	return result, nil
+}
+
+// the end date is inclusive for admins, so the filter runs until the next midnight
+func toAnalyticsFilter(req dto.AnalyticsQuery) (repository.AnalyticsFilter, error) {
+	filter := repository.AnalyticsFilter{
+		Start:    req.Start,
+		EventIDs: req.Tier,
+	}
+
+	if req.End != nil {
+		end := req.End.AddDate(0, 0, 1)
+		filter.End = &end
+	}
+
+	if req.Start != nil && req.End != nil && req.End.Before(*req.Start) {
+		return repository.AnalyticsFilter{}, dto.ErrAnalyticsRangeInvalid
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type (
+	APIKeyService interface {
+		GetAll(ctx context.Context) ([]dto.APIKeyResponse, error)
+		Create(ctx context.Context, actorID string, req dto.APIKeyRequest) (dto.APIKeyCreateResponse, error)
+		Revoke(ctx context.Context, actorID string, id string) error
+	}
+
+	apiKeyService struct {
+		apiKeyRepo   repository.APIKeyRepository
+		eventRepo    repository.EventRepository
+		auditLogRepo repository.AuditLogRepository
+		userRepo     repository.UserRepository
+		roleRepo     repository.RoleRepository
+	}
+)
+
+func NewAPIKeyService(
+	akRepo repository.APIKeyRepository,
+	eRepo repository.EventRepository,
+	aRepo repository.AuditLogRepository,
+	uRepo repository.UserRepository,
+	rRepo repository.RoleRepository,
+) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo:   akRepo,
+		eventRepo:    eRepo,
+		auditLogRepo: aRepo,
+		userRepo:     uRepo,
+		roleRepo:     rRepo,
+	}
+}
+
+func (s *apiKeyService) GetAll(ctx context.Context) ([]dto.APIKeyResponse, error) {
+	keys, err := s.apiKeyRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.APIKeyResponse{}
+	for _, key := range keys {
+		result = append(result, toAPIKeyResponse(key))
+	}
+
+	return result, nil
+}
+
+func (s *apiKeyService) Create(ctx context.Context, actorID string, req dto.APIKeyRequest) (dto.APIKeyCreateResponse, error) {
+	actor, err := uuid.Parse(actorID)
+	if err != nil {
+		return dto.APIKeyCreateResponse{}, dto.ErrUserNotFound
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return dto.APIKeyCreateResponse{}, dto.ErrAPIKeyExpiryInvalid
+	}
+
+	permissions, err := toAPIKeyPermissions(req.Permissions)
+	if err != nil {
+		return dto.APIKeyCreateResponse{}, err
+	}
+
+	// api_key.manage must not be a way to hand out access the creator does not have
+	if err := s.checkActorHolds(actorID, permissions); err != nil {
+		return dto.APIKeyCreateResponse{}, err
+	}
+
+	events, err := s.toAPIKeyEvents(req.EventIDs)
+	if err != nil {
+		return dto.APIKeyCreateResponse{}, err
+	}
+
+	token, err := utils.GenRandomToken()
+	if err != nil {
+		return dto.APIKeyCreateResponse{}, err
+	}
+
+	raw := constants.API_KEY_PREFIX + token
+	key, err := s.apiKeyRepo.Create(entity.APIKey{
+		Name:        strings.TrimSpace(req.Name),
+		Prefix:      raw[:constants.API_KEY_PREFIX_LENGTH],
+		KeyHash:     utils.HashToken(raw),
+		CreatedByID: actor,
+		ExpiresAt:   req.ExpiresAt,
+		Permissions: permissions,
+		Events:      events,
+	})
+	if err != nil {
+		return dto.APIKeyCreateResponse{}, err
+	}
+
+	detail := key.Name + " (" + strings.Join(req.Permissions, ", ") + ")"
+	if err := writeAuditLog(s.auditLogRepo, actorID, constants.ENUM_AUDIT_API_KEY_CREATE, constants.AUDIT_TARGET_API_KEY, key.ID.String(), detail); err != nil {
+		return dto.APIKeyCreateResponse{}, err
+	}
+
+	return dto.APIKeyCreateResponse{
+		APIKeyResponse: toAPIKeyResponse(key),
+		Key:            raw,
+	}, nil
+}
+
+// revoked keys are kept so their last use stays visible in the listing
+func (s *apiKeyService) Revoke(ctx context.Context, actorID string, id string) error {
+	key, err := s.apiKeyRepo.GetByID(id)
+	if err != nil {
+		return dto.ErrAPIKeyNotFound
+	}
+
+	if err := s.apiKeyRepo.Revoke(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return dto.ErrAPIKeyRevoked
+		}
+		return err
+	}
+
+	return writeAuditLog(s.auditLogRepo, actorID, constants.ENUM_AUDIT_API_KEY_REVOKE, constants.AUDIT_TARGET_API_KEY, id, key.Name)
+}
+
+func (s *apiKeyService) checkActorHolds(actorID string, permissions []entity.APIKeyPermission) error {
+	actor, err := s.userRepo.GetUserById(actorID)
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	role, err := s.roleRepo.GetRolebyId(actor.RoleID)
+	if err != nil {
+		return dto.ErrRoleNotFound
+	}
+
+	if role.Name == constants.ENUM_ROLE_ADMIN {
+		return nil
+	}
+
+	for _, permission := range permissions {
+		allowed, err := s.roleRepo.HasPermission(role.ID.String(), permission.Permission)
+		if err != nil || !allowed {
+			return fmt.Errorf(dto.ErrAPIKeyPermissionNotHeld.Error(), permission.Permission)
+		}
+	}
+
+	return nil
+}
+
+func (s *apiKeyService) toAPIKeyEvents(eventIDs []string) ([]entity.APIKeyEvent, error) {
+	seen := make(map[string]bool)
+	var result []entity.APIKeyEvent
+	for _, id := range eventIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		event, err := s.eventRepo.GetByID(id)
+		if err != nil {
+			return nil, dto.ErrEventNotFound
+		}
+
+		result = append(result, entity.APIKeyEvent{
+			EventID: event.ID,
+		})
+	}
+
+	return result, nil
+}
+
+func toAPIKeyPermissions(permissions []string) ([]entity.APIKeyPermission, error) {
+	seen := make(map[string]bool)
+	var result []entity.APIKeyPermission
+	for _, permission := range permissions {
+		if !isAPIKeyPermission(permission) {
+			return nil, fmt.Errorf(dto.ErrAPIKeyPermissionInvalid.Error(), permission)
+		}
+
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+
+		result = append(result, entity.APIKeyPermission{
+			Permission: permission,
+		})
+	}
+
+	return result, nil
+}
+
+// the route only knows the ticket is for some main event tier,
+// the ticket itself decides whether a scoped key may touch it
+func isEventAllowed(eventID string, eventIDs []string) bool {
+	if len(eventIDs) == 0 {
+		return true
+	}
+
+	for _, id := range eventIDs {
+		if id == eventID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAPIKeyPermission(permission string) bool {
+	for _, p := range constants.API_KEY_PERMISSIONS {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toAPIKeyResponse(key entity.APIKey) dto.APIKeyResponse {
+	res := dto.APIKeyResponse{
+		ID:          key.ID.String(),
+		Name:        key.Name,
+		Prefix:      key.Prefix,
+		Permissions: []string{},
+		EventIDs:    []string{},
+		ExpiresAt:   key.ExpiresAt,
+		LastUsedAt:  key.LastUsedAt,
+		RevokedAt:   key.RevokedAt,
+		CreatedAt:   key.CreatedAt,
+	}
+
+	if key.CreatedBy != nil {
+		res.CreatedBy = key.CreatedBy.Name
+	}
+
+	for _, permission := range key.Permissions {
+		res.Permissions = append(res.Permissions, permission.Permission)
+	}
+
+	for _, event := range key.Events {
+		res.EventIDs = append(res.EventIDs, event.EventID.String())
+	}
+
+	return res
+}
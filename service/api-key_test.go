@@ -0,0 +1,62 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/google/uuid"
+)
+
+func TestCheckActorHolds(t *testing.T) {
+	admin := entity.Role{ID: uuid.New(), Name: constants.ENUM_ROLE_ADMIN}
+	scanner := entity.Role{ID: uuid.New(), Name: "scanner"}
+	adminUser := entity.User{ID: uuid.New(), RoleID: admin.ID.String()}
+	scannerUser := entity.User{ID: uuid.New(), RoleID: scanner.ID.String()}
+
+	s := &apiKeyService{
+		userRepo: stubUserRepository{users: map[string]entity.User{
+			adminUser.ID.String():   adminUser,
+			scannerUser.ID.String(): scannerUser,
+		}},
+		roleRepo: stubRoleRepository{
+			roles: map[string]entity.Role{
+				admin.ID.String():   admin,
+				scanner.ID.String(): scanner,
+			},
+			grants: map[string][]string{
+				scanner.ID.String(): {constants.PERMISSION_TICKET_CHECKIN, constants.PERMISSION_API_KEY_MANAGE},
+			},
+		},
+	}
+
+	checkin := []entity.APIKeyPermission{{Permission: constants.PERMISSION_TICKET_CHECKIN}}
+	read := []entity.APIKeyPermission{{Permission: constants.PERMISSION_TICKET_CHECKIN}, {Permission: constants.PERMISSION_TICKET_READ}}
+
+	if err := s.checkActorHolds(scannerUser.ID.String(), checkin); err != nil {
+		t.Fatalf("held permission rejected: %v", err)
+	}
+
+	if err := s.checkActorHolds(scannerUser.ID.String(), read); err == nil {
+		t.Fatal("permission the creator does not hold was granted")
+	}
+
+	if err := s.checkActorHolds(adminUser.ID.String(), read); err != nil {
+		t.Fatalf("admin rejected: %v", err)
+	}
+}
+
+func TestIsEventAllowed(t *testing.T) {
+	if !isEventAllowed(constants.MainEventNormalNoMerchID, nil) {
+		t.Fatal("unscoped key rejected")
+	}
+
+	if !isEventAllowed(constants.MainEventNormalNoMerchID, []string{constants.MainEventNormalNoMerchID}) {
+		t.Fatal("scoped event rejected")
+	}
+
+	// both tiers pass the route level check, only the ticket tells them apart
+	if isEventAllowed(constants.MainEventNormalWithMerchID, []string{constants.MainEventNormalNoMerchID}) {
+		t.Fatal("ticket of another tier allowed")
+	}
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/google/uuid"
+)
+
+type (
+	AuthService interface {
+		IssueTokens(ctx context.Context, user entity.User, userAgent string) (entity.Authorization, error)
+		RefreshTokens(ctx context.Context, refreshToken string, userAgent string) (entity.Authorization, error)
+		Logout(ctx context.Context, userID string, tokenID string) error
+		LogoutAll(ctx context.Context, userID string, tokenID string) error
+		RevokeUserSessions(ctx context.Context, userID string) error
+		PurgeExpiredTokens(ctx context.Context) error
+	}
+
+	authService struct {
+		authTokenRepo repository.AuthTokenRepository
+		userRepo      repository.UserRepository
+		roleRepo      repository.RoleRepository
+		mfaRepo       repository.MFARepository
+		jwtService    config.JWTService
+
+		mfaRequiredRoles []string
+	}
+)
+
+func NewAuthService(
+	atRepo repository.AuthTokenRepository,
+	uRepo repository.UserRepository,
+	rRepo repository.RoleRepository,
+	mRepo repository.MFARepository,
+	jwtService config.JWTService,
+	mfaRequiredRoles []string,
+) AuthService {
+	return &authService{
+		authTokenRepo:    atRepo,
+		userRepo:         uRepo,
+		roleRepo:         rRepo,
+		mfaRepo:          mRepo,
+		jwtService:       jwtService,
+		mfaRequiredRoles: mfaRequiredRoles,
+	}
+}
+
+// a fresh login always starts a new token family
+func (s *authService) IssueTokens(ctx context.Context, user entity.User, userAgent string) (entity.Authorization, error) {
+	if user.SuspendedAt != nil {
+		return entity.Authorization{}, dto.ErrAccountSuspended
+	}
+
+	role, err := s.getRoleName(user)
+	if err != nil {
+		return entity.Authorization{}, err
+	}
+
+	refreshToken, token, err := s.newRefreshToken(user.ID, uuid.New(), userAgent)
+	if err != nil {
+		return entity.Authorization{}, err
+	}
+
+	if _, err := s.authTokenRepo.CreateRefreshToken(token); err != nil {
+		return entity.Authorization{}, dto.ErrGenerateToken
+	}
+
+	return s.buildAuthorization(user.ID.String(), role, refreshToken, token), nil
+}
+
+func (s *authService) RefreshTokens(ctx context.Context, refreshToken string, userAgent string) (entity.Authorization, error) {
+	current, err := s.authTokenRepo.GetRefreshTokenByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		return entity.Authorization{}, dto.ErrRefreshTokenInvalid
+	}
+
+	// someone is replaying a token that was already exchanged, the
+	// legitimate holder and the attacker both lose the session
+	if current.RevokedAt != nil {
+		if err := s.authTokenRepo.RevokeFamily(current.FamilyID.String()); err != nil {
+			return entity.Authorization{}, err
+		}
+		return entity.Authorization{}, dto.ErrRefreshTokenReused
+	}
+
+	if time.Now().After(current.ExpiresAt) {
+		return entity.Authorization{}, dto.ErrRefreshTokenExpired
+	}
+
+	// the role is read again so a demotion takes effect on the next refresh
+	user, err := s.userRepo.GetUserById(current.UserID.String())
+	if err != nil {
+		return entity.Authorization{}, dto.ErrRefreshTokenInvalid
+	}
+
+	if user.SuspendedAt != nil {
+		return entity.Authorization{}, dto.ErrAccountSuspended
+	}
+
+	role, err := s.getRoleName(user)
+	if err != nil {
+		return entity.Authorization{}, err
+	}
+
+	// a session from before the role required two-factor has to sign in again and enroll
+	if isMFARequired(s.mfaRequiredRoles, role) {
+		if mfa, err := s.mfaRepo.GetByUserID(user.ID.String()); err != nil || mfa.EnabledAt == nil {
+			return entity.Authorization{}, dto.ErrMFAEnrollmentNeeded
+		}
+	}
+
+	newRefreshToken, next, err := s.newRefreshToken(user.ID, current.FamilyID, userAgent)
+	if err != nil {
+		return entity.Authorization{}, err
+	}
+
+	if _, err := s.authTokenRepo.RotateRefreshToken(current, next); err != nil {
+		if err == dto.ErrRefreshTokenReused {
+			_ = s.authTokenRepo.RevokeFamily(current.FamilyID.String())
+		}
+		return entity.Authorization{}, err
+	}
+
+	return s.buildAuthorization(user.ID.String(), role, newRefreshToken, next), nil
+}
+
+// ends the session the access token belongs to
+func (s *authService) Logout(ctx context.Context, userID string, tokenID string) error {
+	if tokenID == "" {
+		return dto.ErrTokenInvalid
+	}
+
+	if err := s.revokeAccessToken(tokenID); err != nil {
+		return err
+	}
+
+	session, err := s.authTokenRepo.GetRefreshTokenByAccessJTI(tokenID)
+	if err != nil || session.UserID.String() != userID {
+		// the access token may outlive its refresh token, denying it is enough
+		return nil
+	}
+
+	return s.authTokenRepo.RevokeFamily(session.FamilyID.String())
+}
+
+func (s *authService) LogoutAll(ctx context.Context, userID string, tokenID string) error {
+	if tokenID != "" {
+		if err := s.revokeAccessToken(tokenID); err != nil {
+			return err
+		}
+	}
+
+	return s.authTokenRepo.RevokeAllByUserID(userID)
+}
+
+// also drops the cached account, so a suspension or role change is seen on the next request
+func (s *authService) RevokeUserSessions(ctx context.Context, userID string) error {
+	s.jwtService.ForgetAccount(userID)
+	return s.authTokenRepo.RevokeAllByUserID(userID)
+}
+
+func (s *authService) PurgeExpiredTokens(ctx context.Context) error {
+	return s.authTokenRepo.PurgeExpired(time.Now())
+}
+
+func (s *authService) revokeAccessToken(tokenID string) error {
+	return s.authTokenRepo.RevokeAccessToken(tokenID, time.Now().Add(time.Minute*constants.JWT_EXPIRE_TIME_IN_MINUTES))
+}
+
+func (s *authService) getRoleName(user entity.User) (string, error) {
+	if user.Role != nil {
+		return user.Role.Name, nil
+	}
+
+	role, err := s.roleRepo.GetRolebyId(user.RoleID)
+	if err != nil {
+		return "", dto.ErrUserNotFound
+	}
+
+	return role.Name, nil
+}
+
+// the raw refresh token is only ever returned to the client, we keep its hash
+func (s *authService) newRefreshToken(userID uuid.UUID, familyID uuid.UUID, userAgent string) (string, entity.RefreshToken, error) {
+	refreshToken, err := utils.GenRandomToken()
+	if err != nil {
+		return "", entity.RefreshToken{}, dto.ErrGenerateToken
+	}
+
+	return refreshToken, entity.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(refreshToken),
+		AccessJTI: uuid.NewString(),
+		UserAgent: userAgent,
+		ExpiresAt: time.Now().AddDate(0, 0, constants.REFRESH_TOKEN_EXPIRE_TIME_IN_DAY),
+	}, nil
+}
+
+func (s *authService) buildAuthorization(userID string, role string, refreshToken string, token entity.RefreshToken) entity.Authorization {
+	return entity.Authorization{
+		Token:            s.jwtService.GenerateToken(userID, role, token.AccessJTI),
+		Role:             role,
+		ExpiresAt:        time.Now().Add(time.Minute * constants.JWT_EXPIRE_TIME_IN_MINUTES),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: token.ExpiresAt,
+	}
+}
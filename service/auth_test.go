@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/config"
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/TEDxITS/website-backend-2024/utils/keyring"
+	"github.com/google/uuid"
+)
+
+func newTestAuthService(t *testing.T) (*authService, entity.User, *stubAuthTokenRepository) {
+	t.Helper()
+
+	ring := keyring.New()
+	if err := ring.Generate("test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ring.SetActive("test"); err != nil {
+		t.Fatal(err)
+	}
+
+	user := entity.User{ID: uuid.New(), Role: &entity.Role{Name: constants.ENUM_ROLE_USER}}
+	tokens := &stubAuthTokenRepository{tokens: map[string]entity.RefreshToken{}}
+
+	return &authService{
+		authTokenRepo: tokens,
+		userRepo:      stubUserRepository{users: map[string]entity.User{user.ID.String(): user}},
+		jwtService:    config.NewJWTService(ring, nil, nil, nil, nil),
+	}, user, tokens
+}
+
+func TestRefreshTokensRotates(t *testing.T) {
+	s, user, tokens := newTestAuthService(t)
+
+	first, err := s.IssueTokens(context.Background(), user, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := s.RefreshTokens(context.Background(), first.RefreshToken, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if second.RefreshToken == first.RefreshToken || second.Token == "" {
+		t.Fatalf("refresh did not hand out a new pair: %+v", second)
+	}
+
+	old := tokens.tokens[utils.HashToken(first.RefreshToken)]
+	next := tokens.tokens[utils.HashToken(second.RefreshToken)]
+	if old.RevokedAt == nil || next.RevokedAt != nil || next.FamilyID != old.FamilyID {
+		t.Fatalf("old %+v, next %+v", old, next)
+	}
+
+	if _, err := s.RefreshTokens(context.Background(), second.RefreshToken, "agent"); err != nil {
+		t.Fatalf("rotated token refused: %v", err)
+	}
+}
+
+func TestRefreshTokensReuseRevokesFamily(t *testing.T) {
+	s, user, _ := newTestAuthService(t)
+
+	first, err := s.IssueTokens(context.Background(), user, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.RefreshTokens(context.Background(), first.RefreshToken, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the stolen first token is replayed, so the holder of the second loses it too
+	if _, err := s.RefreshTokens(context.Background(), first.RefreshToken, "agent"); err != dto.ErrRefreshTokenReused {
+		t.Fatalf("replay: err = %v, want %v", err, dto.ErrRefreshTokenReused)
+	}
+	if _, err := s.RefreshTokens(context.Background(), second.RefreshToken, "agent"); err != dto.ErrRefreshTokenReused {
+		t.Fatalf("family: err = %v, want %v", err, dto.ErrRefreshTokenReused)
+	}
+}
+
+func TestRefreshTokensConcurrentReuse(t *testing.T) {
+	s, user, tokens := newTestAuthService(t)
+
+	first, err := s.IssueTokens(context.Background(), user, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.RefreshTokens(context.Background(), first.RefreshToken, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the replay read the token before the first refresh revoked it, only the rotation notices
+	tokens.staleReads = true
+	if _, err := s.RefreshTokens(context.Background(), first.RefreshToken, "agent"); err != dto.ErrRefreshTokenReused {
+		t.Fatalf("err = %v, want %v", err, dto.ErrRefreshTokenReused)
+	}
+
+	if next := tokens.tokens[utils.HashToken(second.RefreshToken)]; next.RevokedAt == nil {
+		t.Fatal("family was not revoked")
+	}
+}
+
+func TestRefreshTokensRejects(t *testing.T) {
+	s, user, tokens := newTestAuthService(t)
+
+	issued, err := s.IssueTokens(context.Background(), user, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.RefreshTokens(context.Background(), "unknown", "agent"); err != dto.ErrRefreshTokenInvalid {
+		t.Fatalf("unknown: err = %v, want %v", err, dto.ErrRefreshTokenInvalid)
+	}
+
+	hash := utils.HashToken(issued.RefreshToken)
+	token := tokens.tokens[hash]
+	token.ExpiresAt = time.Now().Add(-time.Minute)
+	tokens.tokens[hash] = token
+	if _, err := s.RefreshTokens(context.Background(), issued.RefreshToken, "agent"); err != dto.ErrRefreshTokenExpired {
+		t.Fatalf("expired: err = %v, want %v", err, dto.ErrRefreshTokenExpired)
+	}
+}
+
+func TestRefreshTokensRejectsSuspendedAccount(t *testing.T) {
+	s, user, _ := newTestAuthService(t)
+
+	issued, err := s.IssueTokens(context.Background(), user, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	user.SuspendedAt = &now
+	s.userRepo.(stubUserRepository).users[user.ID.String()] = user
+
+	if _, err := s.RefreshTokens(context.Background(), issued.RefreshToken, "agent"); err != dto.ErrAccountSuspended {
+		t.Fatalf("err = %v, want %v", err, dto.ErrAccountSuspended)
+	}
+}
+
+func TestRefreshTokensRequiresMFAForRole(t *testing.T) {
+	s, user, _ := newTestAuthService(t)
+
+	issued, err := s.IssueTokens(context.Background(), user, "agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the role started requiring two-factor after the session was issued
+	s.mfaRepo = stubMFARepository{}
+	s.mfaRequiredRoles = []string{constants.ENUM_ROLE_USER}
+
+	if _, err := s.RefreshTokens(context.Background(), issued.RefreshToken, "agent"); err != dto.ErrMFAEnrollmentNeeded {
+		t.Fatalf("err = %v, want %v", err, dto.ErrMFAEnrollmentNeeded)
+	}
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"gorm.io/gorm"
+)
+
+type (
+	CertificateService interface {
+		GetMyCertificates(ctx context.Context, userID string) ([]dto.CertificateResponse, error)
+		DownloadCertificate(ctx context.Context, code string, userID string, userRole string) ([]byte, error)
+		BulkGenerate(ctx context.Context, req dto.CertificateBulkRequest) ([]byte, error)
+		VerifyCertificate(ctx context.Context, code string) (dto.CertificateVerifyResponse, error)
+	}
+
+	certificateService struct {
+		certificateRepo repository.CertificateRepository
+		userRepo        repository.UserRepository
+		eventRepo       repository.EventRepository
+		ticketRepo      repository.TicketRepository
+		pe2RSVPRepo     repository.PE2RSVPRepository
+		surveyRepo      repository.SurveyRepository
+	}
+)
+
+func NewCertificateService(
+	cRepo repository.CertificateRepository,
+	uRepo repository.UserRepository,
+	eRepo repository.EventRepository,
+	tRepo repository.TicketRepository,
+	pRepo repository.PE2RSVPRepository,
+	sRepo repository.SurveyRepository,
+) CertificateService {
+	return &certificateService{
+		certificateRepo: cRepo,
+		userRepo:        uRepo,
+		eventRepo:       eRepo,
+		ticketRepo:      tRepo,
+		pe2RSVPRepo:     pRepo,
+		surveyRepo:      sRepo,
+	}
+}
+
+func (s *certificateService) GetMyCertificates(ctx context.Context, userID string) ([]dto.CertificateResponse, error) {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return nil, dto.ErrUserNotFound
+	}
+
+	// certificates are issued lazily, the first time the
+	// attendee opens their account after being checked in
+	tickets, err := s.ticketRepo.FindCheckedInByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ticket := range tickets {
+		if _, err := s.issueTicketCertificate(ticket); err != nil {
+			return nil, err
+		}
+	}
+
+	rsvps, err := s.pe2RSVPRepo.GetAttendedByEmail(user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rsvp := range rsvps {
+		if _, err := s.issueRSVPCertificate(rsvp); err != nil {
+			return nil, err
+		}
+	}
+
+	certificates, err := s.certificateRepo.GetAllByUser(userID, user.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []dto.CertificateResponse
+	for _, c := range certificates {
+		result = append(result, dto.CertificateResponse{
+			ID:        c.ID.String(),
+			Code:      c.Code,
+			Name:      c.Name,
+			EventName: c.EventName,
+			EventDate: c.EventDate,
+			IssuedAt:  c.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *certificateService) DownloadCertificate(ctx context.Context, code string, userID string, userRole string) ([]byte, error) {
+	certificate, err := s.certificateRepo.GetByCode(code)
+	if err != nil {
+		return nil, dto.ErrCertificateNotFound
+	}
+
+	if userRole != constants.ENUM_ROLE_ADMIN {
+		user, err := s.userRepo.GetUserById(userID)
+		if err != nil {
+			return nil, dto.ErrUserNotFound
+		}
+
+		if certificate.UserID != userID && certificate.Email != user.Email {
+			return nil, dto.ErrCertificateAccessDenied
+		}
+
+		pending, err := s.surveyRepo.HasPendingRequiredSurvey(certificate.TicketID, certificate.RSVPID)
+		if err != nil {
+			return nil, err
+		}
+
+		if pending {
+			return nil, dto.ErrSurveyNotCompleted
+		}
+	}
+
+	return s.renderCertificate(certificate)
+}
+
+func (s *certificateService) BulkGenerate(ctx context.Context, req dto.CertificateBulkRequest) ([]byte, error) {
+	if _, err := s.eventRepo.GetByID(req.EventID); err != nil {
+		return nil, dto.ErrEventNotFound
+	}
+
+	tickets, err := s.ticketRepo.FindCheckedInByEventID(req.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var certificates []entity.Certificate
+	for _, ticket := range tickets {
+		certificate, err := s.issueTicketCertificate(ticket)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, certificate)
+	}
+
+	// pre-event 2 attendees RSVP without an account
+	if req.EventID == constants.PreEvent2ID {
+		rsvps, err := s.pe2RSVPRepo.GetAllAttended()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rsvp := range rsvps {
+			certificate, err := s.issueRSVPCertificate(rsvp)
+			if err != nil {
+				return nil, err
+			}
+			certificates = append(certificates, certificate)
+		}
+	}
+
+	if len(certificates) == 0 {
+		return nil, dto.ErrNoCertificateToGenerate
+	}
+
+	var archive bytes.Buffer
+	zipWriter := zip.NewWriter(&archive)
+	for _, certificate := range certificates {
+		file, err := s.renderCertificate(certificate)
+		if err != nil {
+			return nil, err
+		}
+
+		writer, err := zipWriter.Create(certificate.Code + ".pdf")
+		if err != nil {
+			return nil, dto.ErrFailedToArchiveCertFiles
+		}
+
+		if _, err := writer.Write(file); err != nil {
+			return nil, dto.ErrFailedToArchiveCertFiles
+		}
+	}
+
+	if err := zipWriter.Close(); err != nil {
+		return nil, dto.ErrFailedToArchiveCertFiles
+	}
+
+	return archive.Bytes(), nil
+}
+
+func (s *certificateService) VerifyCertificate(ctx context.Context, code string) (dto.CertificateVerifyResponse, error) {
+	certificate, err := s.certificateRepo.GetByCode(code)
+	if err != nil {
+		return dto.CertificateVerifyResponse{}, dto.ErrCertificateNotFound
+	}
+
+	return dto.CertificateVerifyResponse{
+		Valid:     true,
+		Code:      certificate.Code,
+		Name:      certificate.Name,
+		EventName: certificate.EventName,
+		EventDate: certificate.EventDate,
+		IssuedAt:  certificate.CreatedAt,
+	}, nil
+}
+
+func (s *certificateService) issueTicketCertificate(ticket entity.Ticket) (entity.Certificate, error) {
+	if ticket.CheckedIn == nil || !*ticket.CheckedIn {
+		return entity.Certificate{}, dto.ErrCertificateNotEligible
+	}
+
+	certificate, err := s.certificateRepo.GetByTicketID(ticket.TicketID)
+	if err == nil {
+		return certificate, nil
+	}
+
+	if err != gorm.ErrRecordNotFound {
+		return entity.Certificate{}, err
+	}
+
+	event := ticket.Event
+	if event == nil {
+		e, err := s.eventRepo.GetByID(ticket.EventID)
+		if err != nil {
+			return entity.Certificate{}, dto.ErrEventNotFound
+		}
+		event = &e
+	}
+
+	user := ticket.User
+	if user == nil {
+		u, err := s.userRepo.GetUserById(ticket.UserID)
+		if err != nil {
+			return entity.Certificate{}, dto.ErrUserNotFound
+		}
+		user = &u
+	}
+
+	code, err := s.generateCode()
+	if err != nil {
+		return entity.Certificate{}, err
+	}
+
+	return s.certificateRepo.Create(entity.Certificate{
+		Code:      code,
+		EventID:   ticket.EventID,
+		UserID:    ticket.UserID,
+		TicketID:  ticket.TicketID,
+		Email:     user.Email,
+		Name:      user.Name,
+		EventName: certificateEventName(*event),
+		EventDate: event.EventDate,
+	})
+}
+
+func (s *certificateService) issueRSVPCertificate(rsvp entity.PE2RSVP) (entity.Certificate, error) {
+	if rsvp.Attended == nil || !*rsvp.Attended {
+		return entity.Certificate{}, dto.ErrCertificateNotEligible
+	}
+
+	certificate, err := s.certificateRepo.GetByRSVPID(rsvp.ID.String())
+	if err == nil {
+		return certificate, nil
+	}
+
+	if err != gorm.ErrRecordNotFound {
+		return entity.Certificate{}, err
+	}
+
+	event, err := s.eventRepo.GetPE2Detail()
+	if err != nil {
+		return entity.Certificate{}, dto.ErrEventNotFound
+	}
+
+	code, err := s.generateCode()
+	if err != nil {
+		return entity.Certificate{}, err
+	}
+
+	return s.certificateRepo.Create(entity.Certificate{
+		Code:      code,
+		EventID:   event.ID.String(),
+		RSVPID:    rsvp.ID.String(),
+		Email:     rsvp.Email,
+		Name:      rsvp.Name,
+		EventName: certificateEventName(event),
+		EventDate: event.EventDate,
+	})
+}
+
+func (s *certificateService) generateCode() (string, error) {
+	for {
+		code, err := utils.GenCertificateCode()
+		if err != nil {
+			return "", dto.ErrGenerateCertificate
+		}
+
+		exist, err := s.certificateRepo.CheckCodeExist(code)
+		if err != nil {
+			return "", err
+		}
+
+		if !exist {
+			return code, nil
+		}
+	}
+}
+
+func (s *certificateService) renderCertificate(certificate entity.Certificate) ([]byte, error) {
+	var eventDate string
+	if !certificate.EventDate.IsZero() {
+		eventDate = certificate.EventDate.Format("2 January 2006")
+	}
+
+	file, err := utils.RenderPDFTemplate("./utils/template/certificate.tmpl", struct {
+		Name       string
+		EventName  string
+		EventDate  string
+		Code       string
+		VerifyLink string
+	}{
+		Name:       certificate.Name,
+		EventName:  certificate.EventName,
+		EventDate:  eventDate,
+		Code:       certificate.Code,
+		VerifyLink: constants.BASE_URL + "/api/certificates/verify/" + certificate.Code,
+	}, utils.PDF_A4_LANDSCAPE_WIDTH, utils.PDF_A4_LANDSCAPE_HEIGHT)
+	if err != nil {
+		return nil, dto.ErrGenerateCertificate
+	}
+
+	return file, nil
+}
+
+// main event tickets are split into several tiers,
+// but all of them attend the very same event
+func certificateEventName(event entity.Event) string {
+	if event.ID.String() == constants.PreEvent2ID || event.ID.String() == constants.PreEvent3ID {
+		return event.Name
+	}
+
+	return constants.MainEventName
+}
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"gorm.io/gorm"
+)
+
+type (
+	ComplimentaryService interface {
+		IssueCompTicket(ctx context.Context, req dto.CompTicketRequest) (dto.CompTicketResponse, error)
+		ImportCompTickets(ctx context.Context, req dto.CompTicketImportRequest) (dto.CompTicketImportResponse, error)
+	}
+
+	complimentaryService struct {
+		userRepo   repository.UserRepository
+		ticketRepo repository.TicketRepository
+		eventRepo  repository.EventRepository
+
+		profileSnapshot bool
+	}
+)
+
+func NewComplimentaryService(uRepo repository.UserRepository, tRepo repository.TicketRepository, eRepo repository.EventRepository, profileSnapshot bool) ComplimentaryService {
+	return &complimentaryService{
+		userRepo:        uRepo,
+		ticketRepo:      tRepo,
+		eventRepo:       eRepo,
+		profileSnapshot: profileSnapshot,
+	}
+}
+
+func (s *complimentaryService) IssueCompTicket(ctx context.Context, req dto.CompTicketRequest) (dto.CompTicketResponse, error) {
+	event, err := s.eventRepo.GetByID(req.EventID)
+	if err != nil {
+		return dto.CompTicketResponse{}, dto.ErrEventNotFound
+	}
+
+	res, email, err := s.issue(event, req)
+	if err != nil {
+		return dto.CompTicketResponse{}, err
+	}
+
+	res.EmailSent = email.send() == nil
+	return res, nil
+}
+
+func (s *complimentaryService) ImportCompTickets(ctx context.Context, req dto.CompTicketImportRequest) (dto.CompTicketImportResponse, error) {
+	if strings.ToLower(utils.GetExtensions(req.File.Filename)) != "csv" {
+		return dto.CompTicketImportResponse{}, dto.ErrCompImportFileInvalid
+	}
+
+	event, err := s.eventRepo.GetByID(req.EventID)
+	if err != nil {
+		return dto.CompTicketImportResponse{}, dto.ErrEventNotFound
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		return dto.CompTicketImportResponse{}, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return dto.CompTicketImportResponse{}, dto.ErrCompImportHeaderInvalid
+	}
+
+	index := make(map[string]int)
+	for i, column := range header {
+		index[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, required := range []string{"name", "email", "category"} {
+		if _, ok := index[required]; !ok {
+			return dto.CompTicketImportResponse{}, dto.ErrCompImportHeaderInvalid
+		}
+	}
+
+	field := func(record []string, column string) string {
+		i, ok := index[column]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	// every row is issued on its own, a bad row is reported without stopping the rest.
+	// Emails wait until every row is in so a long list does not hold up the response.
+	var emails []compTicketEmail
+	res := dto.CompTicketImportResponse{
+		Tickets: []dto.CompTicketResponse{},
+		Errors:  []dto.CompTicketImportError{},
+	}
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			res.Errors = append(res.Errors, dto.CompTicketImportError{Row: row, Error: err.Error()})
+			continue
+		}
+
+		ticket, email, err := s.issue(event, dto.CompTicketRequest{
+			Name:      field(record, "name"),
+			Email:     field(record, "email"),
+			Category:  field(record, "category"),
+			EventID:   req.EventID,
+			Handphone: field(record, "handphone"),
+		})
+		if err != nil {
+			res.Errors = append(res.Errors, dto.CompTicketImportError{
+				Row:   row,
+				Email: field(record, "email"),
+				Error: err.Error(),
+			})
+			continue
+		}
+
+		ticket.EmailQueued = true
+		res.Tickets = append(res.Tickets, ticket)
+		emails = append(emails, email)
+	}
+
+	res.Issued = len(res.Tickets)
+	res.Failed = len(res.Errors)
+
+	go func() {
+		for _, email := range emails {
+			if err := email.send(); err != nil {
+				log.Printf("error sending complimentary ticket to %s: %v", email.user.Email, err)
+			}
+		}
+	}()
+
+	return res, nil
+}
+
+// the ticket is stored right away, the email is handed back for the caller to send
+func (s *complimentaryService) issue(event entity.Event, req dto.CompTicketRequest) (dto.CompTicketResponse, compTicketEmail, error) {
+	if !isGuestCategory(req.Category) {
+		return dto.CompTicketResponse{}, compTicketEmail{}, dto.ErrGuestCategoryInvalid
+	}
+
+	if !utils.ValidateEmail(req.Email) {
+		return dto.CompTicketResponse{}, compTicketEmail{}, dto.ErrEmailFormatInvalid
+	}
+
+	// a guest may not have given a number, but one that is given must be valid
+	handphone := utils.NormalizeHandphone(req.Handphone)
+	if handphone != "" {
+		if err := validateHandphone(handphone); err != nil {
+			return dto.CompTicketResponse{}, compTicketEmail{}, err
+		}
+	}
+
+	// attach to the existing account, or open one the guest can claim later
+	newAccount := false
+	user, err := s.userRepo.GetUserByEmail(req.Email)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return dto.CompTicketResponse{}, compTicketEmail{}, err
+		}
+
+		password, err := utils.GenRandomToken()
+		if err != nil {
+			return dto.CompTicketResponse{}, compTicketEmail{}, err
+		}
+
+		user, err = s.userRepo.RegisterUser(entity.User{
+			Name:      req.Name,
+			Email:     req.Email,
+			Password:  password,
+			Verified:  true,
+			Handphone: handphone,
+		})
+		if err != nil {
+			return dto.CompTicketResponse{}, compTicketEmail{}, dto.ErrCreateUser
+		}
+		newAccount = true
+	}
+
+	exist, err := s.ticketRepo.CheckUserHasTicket(user.ID.String(), event.ID.String())
+	if err != nil {
+		return dto.CompTicketResponse{}, compTicketEmail{}, err
+	}
+
+	if exist {
+		return dto.CompTicketResponse{}, compTicketEmail{}, dto.ErrGuestAlreadyHasTicket
+	}
+
+	var code string
+	for {
+		code = utils.GenUniqueCode()
+		if _, err := s.ticketRepo.GetTicketById(code); err != nil {
+			if err != gorm.ErrRecordNotFound {
+				return dto.CompTicketResponse{}, compTicketEmail{}, err
+			}
+			break
+		}
+	}
+
+	True := true
+	False := false
+	ticket := entity.Ticket{
+		TicketID:         code,
+		UserID:           user.ID.String(),
+		EventID:          event.ID.String(),
+		PaymentConfirmed: &True,
+		CheckedIn:        &False,
+		Complimentary:    &True,
+		GuestCategory:    req.Category,
+	}
+	if s.profileSnapshot {
+		ticket.Handphone = handphone
+	}
+
+	ticket, err = s.ticketRepo.CreateCompTicket(ticket)
+	if err != nil {
+		return dto.CompTicketResponse{}, compTicketEmail{}, err
+	}
+
+	// the ticket is already issued, the profile is only a convenience
+	if handphone != "" {
+		if err := rememberCompHandphone(s.userRepo, user, handphone, s.profileSnapshot); err != nil {
+			log.Printf("error saving guest handphone: %v", err)
+		}
+	}
+
+	return dto.CompTicketResponse{
+		TicketID:   ticket.TicketID,
+		UserID:     user.ID.String(),
+		Name:       user.Name,
+		Email:      user.Email,
+		Category:   ticket.GuestCategory,
+		EventID:    ticket.EventID,
+		NewAccount: newAccount,
+	}, compTicketEmail{user: user, event: event, ticket: ticket}, nil
+}
+
+// like rememberTicketProfile, only for the number since guests are not asked their birthdate
+func rememberCompHandphone(userRepo repository.UserRepository, user entity.User, handphone string, snapshot bool) error {
+	if user.Handphone == handphone || user.Handphone != "" && snapshot {
+		return nil
+	}
+
+	user.Handphone = handphone
+	return userRepo.UpdateProfile(user)
+}
+
+type compTicketEmail struct {
+	user   entity.User
+	event  entity.Event
+	ticket entity.Ticket
+}
+
+func (e compTicketEmail) send() error {
+	user, event, ticket := e.user, e.event, e.ticket
+
+	readHtml, err := os.ReadFile("./utils/template/mail_complimentary_ticket.html")
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("custom").Parse(string(readHtml))
+	if err != nil {
+		return err
+	}
+
+	var strMail bytes.Buffer
+	if err := tmpl.Execute(&strMail, struct {
+		Name      string
+		Email     string
+		EventName string
+		Category  string
+		TicketID  string
+	}{
+		Name:      user.Name,
+		Email:     user.Email,
+		EventName: event.Name,
+		Category:  strings.ReplaceAll(ticket.GuestCategory, "_", " "),
+		TicketID:  ticket.TicketID,
+	}); err != nil {
+		return err
+	}
+
+	return utils.SendMail(utils.Email{
+		Email:   user.Email,
+		Subject: "Your Complimentary Ticket - TEDxITS",
+		Body:    strMail.String(),
+	})
+}
+
+func isGuestCategory(category string) bool {
+	switch category {
+	case constants.ENUM_GUEST_SPEAKER,
+		constants.ENUM_GUEST_SPONSOR,
+		constants.ENUM_GUEST_MEDIA_PARTNER,
+		constants.ENUM_GUEST_COMMITTEE:
+		return true
+	}
+
+	return false
+}
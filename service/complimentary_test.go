@@ -0,0 +1,94 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/google/uuid"
+)
+
+func newTestComplimentaryService(users ...entity.User) (*complimentaryService, stubUserRepository, stubTicketRepository) {
+	userRepo := stubUserRepository{users: map[string]entity.User{}}
+	for _, user := range users {
+		userRepo.users[user.ID.String()] = user
+	}
+	ticketRepo := stubTicketRepository{tickets: map[string]entity.Ticket{}}
+
+	return &complimentaryService{
+		userRepo:        userRepo,
+		ticketRepo:      ticketRepo,
+		profileSnapshot: true,
+	}, userRepo, ticketRepo
+}
+
+func TestIssueCompTicketNormalisesHandphone(t *testing.T) {
+	s, users, tickets := newTestComplimentaryService()
+	event := entity.Event{ID: uuid.MustParse(constants.PreEvent3ID)}
+
+	res, email, err := s.issue(event, dto.CompTicketRequest{
+		Name:      "Guest",
+		Email:     "guest@example.com",
+		Category:  constants.ENUM_GUEST_SPEAKER,
+		Handphone: "0812-3456 7890",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !res.NewAccount || email.user.Email != "guest@example.com" {
+		t.Fatalf("got %+v, want a new account with a pending email", res)
+	}
+
+	if ticket := tickets.tickets[res.TicketID]; ticket.Handphone != "081234567890" {
+		t.Fatalf("ticket handphone = %q", ticket.Handphone)
+	}
+
+	if user := users.users[res.UserID]; user.Handphone != "081234567890" {
+		t.Fatalf("profile handphone = %q", user.Handphone)
+	}
+}
+
+func TestIssueCompTicketRejectsInvalidHandphone(t *testing.T) {
+	s, users, _ := newTestComplimentaryService()
+
+	_, _, err := s.issue(entity.Event{ID: uuid.New()}, dto.CompTicketRequest{
+		Name:      "Guest",
+		Email:     "guest@example.com",
+		Category:  constants.ENUM_GUEST_SPONSOR,
+		Handphone: "call me",
+	})
+	if err != dto.ErrHandphoneInvalid {
+		t.Fatalf("err = %v, want %v", err, dto.ErrHandphoneInvalid)
+	}
+
+	if len(users.users) != 0 {
+		t.Fatal("account created for a rejected row")
+	}
+}
+
+func TestIssueCompTicketWithoutHandphoneKeepsProfile(t *testing.T) {
+	existing := entity.User{ID: uuid.New(), Email: "guest@example.com", Handphone: "081111111111"}
+	s, users, tickets := newTestComplimentaryService(existing)
+
+	res, _, err := s.issue(entity.Event{ID: uuid.New()}, dto.CompTicketRequest{
+		Name:     "Guest",
+		Email:    existing.Email,
+		Category: constants.ENUM_GUEST_COMMITTEE,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if users.users[existing.ID.String()].Handphone != existing.Handphone {
+		t.Fatal("profile handphone was cleared")
+	}
+
+	// an empty ticket number reads through to the profile
+	ticket := tickets.tickets[res.TicketID]
+	ticket.User = &existing
+	if ticket.GetHandphone() != existing.Handphone {
+		t.Fatalf("ticket handphone = %q", ticket.GetHandphone())
+	}
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+)
+
+type (
+	DemographicService interface {
+		GetDemographicReport(ctx context.Context, eventID string, req dto.DemographicQuery) (dto.DemographicReportResponse, error)
+		ExportDemographicReport(ctx context.Context, eventID string, req dto.DemographicQuery, w io.Writer) error
+	}
+
+	demographicService struct {
+		analyticsRepo repository.AnalyticsRepository
+		eventRepo     repository.EventRepository
+	}
+)
+
+func NewDemographicService(aRepo repository.AnalyticsRepository, eRepo repository.EventRepository) DemographicService {
+	return &demographicService{
+		analyticsRepo: aRepo,
+		eventRepo:     eRepo,
+	}
+}
+
+// pre-event 2 only knows where applicants study, ticketed events only know
+// the birthdate, so each event reports whatever its registrations collected
+func (s *demographicService) GetDemographicReport(ctx context.Context, eventID string, req dto.DemographicQuery) (dto.DemographicReportResponse, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return dto.DemographicReportResponse{}, dto.ErrEventNotFound
+	}
+
+	result := dto.DemographicReportResponse{
+		EventID:     event.ID.String(),
+		Name:        event.Name,
+		MinCellSize: constants.DEMOGRAPHIC_MIN_CELL_SIZE,
+	}
+
+	if eventID != constants.PreEvent2ID {
+		rows, err := s.analyticsRepo.GetAgeBrackets(expandMainEventIDs(eventID))
+		if err != nil {
+			return dto.DemographicReportResponse{}, err
+		}
+
+		result.Total = sumDemographicRows(rows)
+		result.AgeBrackets = suppressDemographicRows(sortAgeBrackets(rows), result.Total, 0)
+	} else {
+		switch req.Status {
+		case "",
+			constants.ENUM_PE2_STATUS_PENDING,
+			constants.ENUM_PE2_STATUS_ACCEPTED,
+			constants.ENUM_PE2_STATUS_REJECTED,
+			constants.ENUM_PE2_STATUS_WAITLISTED:
+		default:
+			return dto.DemographicReportResponse{}, dto.ErrDemographicStatusInvalid
+		}
+
+		breakdowns := []struct {
+			column string
+			cells  *[]dto.DemographicCell
+		}{
+			{constants.ENUM_DEMOGRAPHIC_INSTITUTE, &result.Institutes},
+			{constants.ENUM_DEMOGRAPHIC_DEPARTMENT, &result.Departments},
+			{constants.ENUM_DEMOGRAPHIC_BATCH, &result.Batches},
+		}
+
+		for _, breakdown := range breakdowns {
+			rows, err := s.analyticsRepo.GetPE2Breakdown(breakdown.column, req.Status)
+			if err != nil {
+				return dto.DemographicReportResponse{}, err
+			}
+
+			result.Total = sumDemographicRows(rows)
+			*breakdown.cells = suppressDemographicRows(rows, result.Total, constants.DEMOGRAPHIC_TOP_N)
+		}
+	}
+
+	// too few people to say anything without pointing at someone
+	if result.Total < constants.DEMOGRAPHIC_MIN_CELL_SIZE {
+		return dto.DemographicReportResponse{
+			EventID:     result.EventID,
+			Name:        result.Name,
+			MinCellSize: result.MinCellSize,
+			Suppressed:  true,
+		}, nil
+	}
+
+	return result, nil
+}
+
+func (s *demographicService) ExportDemographicReport(ctx context.Context, eventID string, req dto.DemographicQuery, w io.Writer) error {
+	report, err := s.GetDemographicReport(ctx, eventID, req)
+	if err != nil {
+		return err
+	}
+
+	writer := utils.NewCSVExportWriter(w)
+	if err := writer.WriteRow([]string{"Breakdown", "Label", "Count", "Share"}); err != nil {
+		return err
+	}
+
+	breakdowns := []struct {
+		name  string
+		cells []dto.DemographicCell
+	}{
+		{constants.ENUM_DEMOGRAPHIC_AGE, report.AgeBrackets},
+		{constants.ENUM_DEMOGRAPHIC_INSTITUTE, report.Institutes},
+		{constants.ENUM_DEMOGRAPHIC_DEPARTMENT, report.Departments},
+		{constants.ENUM_DEMOGRAPHIC_BATCH, report.Batches},
+	}
+
+	for _, breakdown := range breakdowns {
+		for _, cell := range breakdown.cells {
+			row := []string{
+				breakdown.name,
+				cell.Label,
+				strconv.FormatInt(cell.Count, 10),
+				strconv.FormatFloat(cell.Share, 'f', 4, 64),
+			}
+
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return writer.Close()
+}
+
+func sumDemographicRows(rows []dto.DemographicRow) int64 {
+	var total int64
+	for _, row := range rows {
+		total += row.Count
+	}
+
+	return total
+}
+
+func sortAgeBrackets(rows []dto.DemographicRow) []dto.DemographicRow {
+	order := map[string]int{}
+	for i, bracket := range constants.DEMOGRAPHIC_AGE_BRACKETS {
+		order[bracket.Label] = i
+	}
+	order[constants.DEMOGRAPHIC_LABEL_UNKNOWN] = len(constants.DEMOGRAPHIC_AGE_BRACKETS)
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return order[rows[i].Label] < order[rows[j].Label]
+	})
+
+	return rows
+}
+
+// cells below the minimum size, and anything past the top n, are folded
+// into "other". When "other" itself ends up too small, the smallest visible
+// cells are folded in as well, otherwise the total would give it away.
+func suppressDemographicRows(rows []dto.DemographicRow, total int64, topN int) []dto.DemographicCell {
+	var visible []dto.DemographicRow
+	var other int64
+
+	for _, row := range rows {
+		if row.Count < constants.DEMOGRAPHIC_MIN_CELL_SIZE || (topN > 0 && len(visible) >= topN) {
+			other += row.Count
+			continue
+		}
+
+		visible = append(visible, row)
+	}
+
+	for other > 0 && other < constants.DEMOGRAPHIC_MIN_CELL_SIZE && len(visible) > 0 {
+		smallest := 0
+		for i := range visible {
+			if visible[i].Count < visible[smallest].Count {
+				smallest = i
+			}
+		}
+
+		other += visible[smallest].Count
+		visible = append(visible[:smallest], visible[smallest+1:]...)
+	}
+
+	cells := []dto.DemographicCell{}
+	for _, row := range visible {
+		cells = append(cells, toDemographicCell(row.Label, row.Count, total))
+	}
+
+	if other > 0 {
+		cells = append(cells, toDemographicCell(constants.DEMOGRAPHIC_LABEL_OTHER, other, total))
+	}
+
+	return cells
+}
+
+func toDemographicCell(label string, count int64, total int64) dto.DemographicCell {
+	cell := dto.DemographicCell{
+		Label: label,
+		Count: count,
+	}
+
+	if total > 0 {
+		cell.Share = float64(count) / float64(total)
+	}
+
+	return cell
+}
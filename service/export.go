@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+)
+
+type (
+	ExportService interface {
+		ExportMainEvent(ctx context.Context, req dto.ExportQuery, w io.Writer) error
+		ExportPreEvent3(ctx context.Context, req dto.ExportQuery, w io.Writer) error
+		ExportPE2RSVP(ctx context.Context, req dto.ExportQuery, w io.Writer) error
+		ExportUser(ctx context.Context, req dto.ExportQuery, w io.Writer) error
+	}
+
+	exportService struct {
+		ticketRepo  repository.TicketRepository
+		pe2RSVPRepo repository.PE2RSVPRepository
+		userRepo    repository.UserRepository
+	}
+
+	exportColumn[T any] struct {
+		key    string
+		header string
+		value  func(T) string
+	}
+)
+
+const EXPORT_DATE_FORMAT = "2006-01-02 15:04:05"
+
+var ticketExportColumns = []exportColumn[entity.Ticket]{
+	{"ticket_id", "Ticket ID", func(t entity.Ticket) string { return t.TicketID }},
+	{"name", "Name", func(t entity.Ticket) string {
+		if t.User == nil {
+			return ""
+		}
+		return t.User.Name
+	}},
+	{"email", "Email", func(t entity.Ticket) string {
+		if t.User == nil {
+			return ""
+		}
+		return t.User.Email
+	}},
+	{"event", "Event", func(t entity.Ticket) string {
+		if t.Event == nil {
+			return ""
+		}
+		return t.Event.Name
+	}},
+	{"price", "Price", func(t entity.Ticket) string {
+		if t.Event == nil {
+			return ""
+		}
+		return strconv.Itoa(t.Event.Price)
+	}},
+	{"handphone", "Handphone", func(t entity.Ticket) string { return t.GetHandphone() }},
+	{"birthdate", "Birthdate", func(t entity.Ticket) string { return formatExportDate(t.GetBirthdate(), "2006-01-02") }},
+	{"seat", "Seat", func(t entity.Ticket) string { return t.Seat }},
+	{"payment", "Payment", func(t entity.Ticket) string { return t.Payment }},
+	{"payment_confirmed", "Payment Confirmed", func(t entity.Ticket) string { return formatExportBool(t.PaymentConfirmed) }},
+	{"checked_in", "Checked In", func(t entity.Ticket) string { return formatExportBool(t.CheckedIn) }},
+	{"complimentary", "Complimentary", func(t entity.Ticket) string { return formatExportBool(t.Complimentary) }},
+	{"guest_category", "Guest Category", func(t entity.Ticket) string { return t.GuestCategory }},
+	{"created_at", "Created At", func(t entity.Ticket) string { return formatExportDate(t.CreatedAt, EXPORT_DATE_FORMAT) }},
+}
+
+var pe2RSVPExportColumns = []exportColumn[entity.PE2RSVP]{
+	{"id", "ID", func(r entity.PE2RSVP) string { return r.ID.String() }},
+	{"name", "Name", func(r entity.PE2RSVP) string { return r.Name }},
+	{"email", "Email", func(r entity.PE2RSVP) string { return r.Email }},
+	{"institute", "Institute", func(r entity.PE2RSVP) string { return r.Institute }},
+	{"department", "Department", func(r entity.PE2RSVP) string { return r.Department }},
+	{"student_id", "Student ID", func(r entity.PE2RSVP) string { return r.StudentID }},
+	{"batch", "Batch", func(r entity.PE2RSVP) string { return r.Batch }},
+	{"willing_to_come", "Willing To Come", func(r entity.PE2RSVP) string { return formatExportBool(r.WillingToCome) }},
+	{"willing_to_be_contacted", "Willing To Be Contacted", func(r entity.PE2RSVP) string { return formatExportBool(r.WillingToBeContacted) }},
+	{"status", "Status", func(r entity.PE2RSVP) string { return r.Status }},
+	{"attended", "Attended", func(r entity.PE2RSVP) string { return formatExportBool(r.Attended) }},
+	{"essay", "Essay", func(r entity.PE2RSVP) string { return r.Essay }},
+}
+
+var userExportColumns = []exportColumn[entity.User]{
+	{"id", "ID", func(u entity.User) string { return u.ID.String() }},
+	{"name", "Name", func(u entity.User) string { return u.Name }},
+	{"email", "Email", func(u entity.User) string { return u.Email }},
+	{"role", "Role", func(u entity.User) string {
+		if u.Role == nil {
+			return ""
+		}
+		return u.Role.Name
+	}},
+	{"verified", "Verified", func(u entity.User) string { return strconv.FormatBool(u.Verified) }},
+	{"created_at", "Created At", func(u entity.User) string { return formatExportDate(u.CreatedAt, EXPORT_DATE_FORMAT) }},
+}
+
+func NewExportService(tRepo repository.TicketRepository, pRepo repository.PE2RSVPRepository, uRepo repository.UserRepository) ExportService {
+	return &exportService{
+		ticketRepo:  tRepo,
+		pe2RSVPRepo: pRepo,
+		userRepo:    uRepo,
+	}
+}
+
+func (s *exportService) ExportMainEvent(ctx context.Context, req dto.ExportQuery, w io.Writer) error {
+	return runExport(req, w, ticketExportColumns, func(fn func([]entity.Ticket) error) error {
+		return s.ticketRepo.StreamME(req.Search, fn)
+	})
+}
+
+func (s *exportService) ExportPreEvent3(ctx context.Context, req dto.ExportQuery, w io.Writer) error {
+	return runExport(req, w, ticketExportColumns, func(fn func([]entity.Ticket) error) error {
+		return s.ticketRepo.StreamPE3(req.Search, fn)
+	})
+}
+
+func (s *exportService) ExportPE2RSVP(ctx context.Context, req dto.ExportQuery, w io.Writer) error {
+	return runExport(req, w, pe2RSVPExportColumns, func(fn func([]entity.PE2RSVP) error) error {
+		return s.pe2RSVPRepo.Stream(req.Search, fn)
+	})
+}
+
+func (s *exportService) ExportUser(ctx context.Context, req dto.ExportQuery, w io.Writer) error {
+	return runExport(req, w, userExportColumns, func(fn func([]entity.User) error) error {
+		return s.userRepo.Stream(req.Search, fn)
+	})
+}
+
+// validates the request before anything is written, then streams every
+// batch from the repository into the chosen format
+func runExport[T any](req dto.ExportQuery, w io.Writer, available []exportColumn[T], stream func(func([]T) error) error) error {
+	if req.Format != "" && req.Format != utils.ENUM_EXPORT_FORMAT_CSV && req.Format != utils.ENUM_EXPORT_FORMAT_XLSX {
+		return dto.ErrExportFormatInvalid
+	}
+
+	columns, err := selectExportColumns(req.Columns, available)
+	if err != nil {
+		return err
+	}
+
+	writer, err := utils.NewExportWriter(req.Format, w)
+	if err != nil {
+		return err
+	}
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.header
+	}
+
+	if err := writer.WriteRow(header); err != nil {
+		return err
+	}
+
+	err = stream(func(batch []T) error {
+		for _, item := range batch {
+			row := make([]string, len(columns))
+			for i, column := range columns {
+				row[i] = column.value(item)
+			}
+
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+
+		return writer.Flush()
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func selectExportColumns[T any](keys string, available []exportColumn[T]) ([]exportColumn[T], error) {
+	if strings.TrimSpace(keys) == "" {
+		return available, nil
+	}
+
+	var columns []exportColumn[T]
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+
+		found := false
+		for _, column := range available {
+			if column.key == key {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, dto.ErrExportColumnInvalid
+		}
+	}
+
+	return columns, nil
+}
+
+func formatExportBool(b *bool) string {
+	return strconv.FormatBool(b != nil && *b)
+}
+
+func formatExportDate(t time.Time, layout string) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(layout)
+}
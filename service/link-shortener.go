@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 
 	"github.com/TEDxITS/website-backend-2024/constants"
 	"github.com/TEDxITS/website-backend-2024/dto"
@@ -29,30 +30,23 @@ func NewLinkShortenerService(repo repository.LinkShortenerRepository) LinkShorte
 }
 
 func (s *linkShortenerService) CreateLinkShortener(ctx context.Context, req dto.LinkShortenerRequest) (dto.LinkShortenerResponse, error) {
-	aliasExist, err := s.linkShortenRepo.CheckAliasExist(req.Alias)
+	linkShorten, err := newLinkShortener(req.Alias, req.Link)
 	if err != nil {
 		return dto.LinkShortenerResponse{}, err
 	}
 
-	if aliasExist {
+	// the repository checks the alias inside the insert transaction
+	res, err := s.linkShortenRepo.CreateLinkShortener(linkShorten)
+	if errors.Is(err, dto.ErrAliasHasBeenTaken) {
 		return dto.LinkShortenerResponse{}, dto.ErrAliasHasBeenTaken
 	}
 
-	if !utils.ValidateLink(req.Link) {
-		return dto.LinkShortenerResponse{}, dto.ErrLinkFormatInvalid
-	}
-
-	linkShorten := entity.LinkShortener{
-		Alias: req.Alias,
-		Link:  req.Link,
-	}
-
-	res, err := s.linkShortenRepo.CreateLinkShortener(linkShorten)
 	if err != nil {
 		return dto.LinkShortenerResponse{}, dto.ErrCreateLinkShortener
 	}
 
 	return dto.LinkShortenerResponse{
+		ID:    res.ID.String(),
 		Alias: res.Alias,
 		Link:  res.Link,
 	}, nil
@@ -64,6 +58,9 @@ func (s *linkShortenerService) RedirectByAlias(ctx context.Context, alias string
 		return dto.LinkShortenerResponse{}, dto.ErrAliasNotFound
 	}
 
+	// a failed counter should never keep the visitor from being redirected
+	_ = s.linkShortenRepo.IncrementClicks(linkShorten.ID.String())
+
 	return dto.LinkShortenerResponse{
 		ID:    linkShorten.ID.String(),
 		Alias: linkShorten.Alias,
@@ -93,9 +90,10 @@ func (s *linkShortenerService) GetAllPagination(ctx context.Context, req dto.Pag
 	var result []dto.LinkShortenerResponse
 	for _, link := range links {
 		result = append(result, dto.LinkShortenerResponse{
-			ID:    link.ID.String(),
-			Alias: link.Alias,
-			Link:  link.Link,
+			ID:     link.ID.String(),
+			Alias:  link.Alias,
+			Link:   link.Link,
+			Clicks: link.Clicks,
 		})
 	}
 
@@ -109,3 +107,16 @@ func (s *linkShortenerService) GetAllPagination(ctx context.Context, req dto.Pag
 		},
 	}, nil
 }
+
+// short links made by admins and the ones made for sponsor placements are
+// both built here, so they follow the same rules
+func newLinkShortener(alias string, link string) (entity.LinkShortener, error) {
+	if !utils.ValidateLink(link) {
+		return entity.LinkShortener{}, dto.ErrLinkFormatInvalid
+	}
+
+	return entity.LinkShortener{
+		Alias: alias,
+		Link:  link,
+	}, nil
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+)
+
+type (
+	LockoutService interface {
+		Check(ctx context.Context, email string, ip string) error
+		RecordFailure(ctx context.Context, email string, ip string)
+		RecordSuccess(ctx context.Context, email string)
+		Unlock(ctx context.Context, token string) error
+		PurgeStale(ctx context.Context) error
+	}
+
+	lockoutService struct {
+		lockoutRepo repository.LoginLockoutRepository
+		userRepo    repository.UserRepository
+	}
+)
+
+func NewLockoutService(lRepo repository.LoginLockoutRepository, uRepo repository.UserRepository) LockoutService {
+	return &lockoutService{
+		lockoutRepo: lRepo,
+		userRepo:    uRepo,
+	}
+}
+
+// unknown emails are locked exactly like real ones, so a lock says nothing about existence
+func (s *lockoutService) Check(ctx context.Context, email string, ip string) error {
+	lockouts, err := s.lockoutRepo.GetByKeys([]string{accountLockoutKey(email), ipLockoutKey(ip)})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, lockout := range lockouts {
+		if lockout.LockedUntil != nil && lockout.LockedUntil.After(now) {
+			return dto.ErrLoginLocked
+		}
+	}
+
+	return nil
+}
+
+// bookkeeping failures must not turn a wrong password into a server error
+func (s *lockoutService) RecordFailure(ctx context.Context, email string, ip string) {
+	var newlyLocked bool
+	lockout, err := s.lockoutRepo.Update(accountLockoutKey(email), constants.ENUM_LOCKOUT_ACCOUNT, func(lockout *entity.LoginLockout) {
+		newlyLocked = applyLoginFailure(lockout, constants.LOGIN_MAX_ACCOUNT_FAILURES)
+	})
+	if err == nil && newlyLocked {
+		go s.notifyLocked(lockout, email)
+	}
+
+	if ip != "" {
+		_, _ = s.lockoutRepo.Update(ipLockoutKey(ip), constants.ENUM_LOCKOUT_IP, func(lockout *entity.LoginLockout) {
+			applyLoginFailure(lockout, constants.LOGIN_MAX_IP_FAILURES)
+		})
+	}
+}
+
+// the ip counter is left alone, one good password does not vouch for a whole network
+func (s *lockoutService) RecordSuccess(ctx context.Context, email string) {
+	_ = s.lockoutRepo.DeleteByKey(accountLockoutKey(email))
+}
+
+func (s *lockoutService) Unlock(ctx context.Context, token string) error {
+	lockout, err := s.lockoutRepo.GetByUnlockTokenHash(utils.HashToken(token))
+	if err != nil {
+		return dto.ErrUnlockTokenInvalid
+	}
+
+	return s.lockoutRepo.Delete(lockout.ID.String())
+}
+
+func (s *lockoutService) PurgeStale(ctx context.Context) error {
+	return s.lockoutRepo.PurgeStale(time.Now().Add(-constants.LOGIN_LOCKOUT_RESET))
+}
+
+// returns true when this failure is the one that locked the key
+func applyLoginFailure(lockout *entity.LoginLockout, maxFailures int) bool {
+	now := time.Now()
+	if now.Sub(lockout.LastFailedAt) > constants.LOGIN_FAILURE_WINDOW {
+		lockout.Failures = 0
+	}
+
+	if lockout.LockedUntil != nil && now.Sub(*lockout.LockedUntil) > constants.LOGIN_LOCKOUT_RESET {
+		lockout.LockCount = 0
+	}
+
+	lockout.Failures++
+	lockout.LastFailedAt = now
+	if lockout.Failures < maxFailures {
+		return false
+	}
+
+	duration := constants.LOGIN_LOCKOUT_BASE
+	for i := 0; i < lockout.LockCount && duration < constants.LOGIN_LOCKOUT_MAX; i++ {
+		duration *= 2
+	}
+	if duration > constants.LOGIN_LOCKOUT_MAX {
+		duration = constants.LOGIN_LOCKOUT_MAX
+	}
+
+	lockedUntil := now.Add(duration)
+	lockout.LockedUntil = &lockedUntil
+	lockout.LockCount++
+	lockout.Failures = 0
+
+	return true
+}
+
+// runs detached from the login request, so the response time does not depend on the email existing
+func (s *lockoutService) notifyLocked(lockout entity.LoginLockout, email string) {
+	user, err := s.userRepo.GetUserByEmail(email)
+	if err != nil {
+		return
+	}
+
+	token, err := utils.GenRandomToken()
+	if err != nil {
+		return
+	}
+
+	if _, err := s.lockoutRepo.Update(lockout.Key, lockout.Kind, func(l *entity.LoginLockout) {
+		l.UnlockTokenHash = utils.HashToken(token)
+	}); err != nil {
+		return
+	}
+
+	readHtml, err := os.ReadFile("./utils/template/mail_account_locked.html")
+	if err != nil {
+		return
+	}
+
+	tmpl, err := template.New("custom").Parse(string(readHtml))
+	if err != nil {
+		return
+	}
+
+	var strMail bytes.Buffer
+	if err := tmpl.Execute(&strMail, struct {
+		Name        string
+		LockedUntil string
+		UnlockLink  string
+	}{
+		Name:        user.Name,
+		LockedUntil: lockout.LockedUntil.Format("2006-01-02 15:04"),
+		UnlockLink:  constants.BASE_URL + "/auth/unlock?token=" + token,
+	}); err != nil {
+		return
+	}
+
+	utils.SendMail(utils.Email{
+		Email:   user.Email,
+		Subject: "Your Account Has Been Locked - TEDxITS",
+		Body:    strMail.String(),
+	})
+}
+
+func accountLockoutKey(email string) string {
+	return constants.ENUM_LOCKOUT_ACCOUNT + ":" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipLockoutKey(ip string) string {
+	return constants.ENUM_LOCKOUT_IP + ":" + ip
+}
+
+func toLockoutResponse(lockout entity.LoginLockout) dto.LockoutResponse {
+	return dto.LockoutResponse{
+		ID:           lockout.ID.String(),
+		Kind:         lockout.Kind,
+		Key:          strings.TrimPrefix(lockout.Key, lockout.Kind+":"),
+		LockCount:    lockout.LockCount,
+		LastFailedAt: lockout.LastFailedAt,
+		LockedUntil:  lockout.LockedUntil,
+	}
+}
@@ -20,12 +20,12 @@ import (
 type (
 	MainEventService interface {
 		RegisterMainEvent(context.Context, dto.MainEventRegister, string) error
-		ConfirmPayment(context.Context, dto.MainEventConfirmPaymentRequest) error
-		CheckIn(context.Context, dto.MainEventCheckInRequest) error
+		ConfirmPayment(context.Context, dto.MainEventConfirmPaymentRequest, []string) error
+		CheckIn(context.Context, dto.MainEventCheckInRequest, []string) error
 		GetStatus(context.Context) (dto.MainEventStatusResponse, error)
-		GetMainEventPaginated(context.Context, dto.PaginationQuery) (dto.TicketPaginationResponse, error)
-		GetMainEventDetail(context.Context, string) (dto.MainEventResponse, error)
-		GetMainEventCounter(context.Context) (dto.TicketCounter, error)
+		GetMainEventPaginated(context.Context, dto.PaginationQuery, []string) (dto.TicketPaginationResponse, error)
+		GetMainEventDetail(context.Context, string, []string) (dto.MainEventResponse, error)
+		GetMainEventCounter(context.Context, []string) (dto.TicketCounter, error)
 	}
 
 	mainEventService struct {
@@ -34,6 +34,8 @@ type (
 		ticketRepo repository.TicketRepository
 		bucketRepo repository.BucketRepository
 		// queueHub   []websocket.QueueHub
+
+		profileSnapshot bool
 	}
 )
 
@@ -43,6 +45,7 @@ func NewMainEventService(
 	eRepo repository.EventRepository,
 	bRepo repository.BucketRepository,
 	// qHub []websocket.QueueHub,
+	profileSnapshot bool,
 ) MainEventService {
 	return &mainEventService{
 		eventRepo:  eRepo,
@@ -50,6 +53,8 @@ func NewMainEventService(
 		ticketRepo: tRepo,
 		bucketRepo: bRepo,
 		// queueHub:   qHub,
+
+		profileSnapshot: profileSnapshot,
 	}
 }
 
@@ -87,6 +92,16 @@ func (s *mainEventService) RegisterMainEvent(ctx context.Context, req dto.MainEv
 		return dto.ErrMainEventFull
 	}
 
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	profile, err := resolveTicketProfile(user, req.Handphone, req.Birthdate)
+	if err != nil {
+		return err
+	}
+
 	// client := hub.GetClientInTransactionByUserID(userID)
 	// if client == nil {
 	// 	return dto.ErrUserNotInTransaction
@@ -146,22 +161,20 @@ func (s *mainEventService) RegisterMainEvent(ctx context.Context, req dto.MainEv
 		TicketID:         code,
 		UserID:           userID,
 		EventID:          req.EventID,
-		Handphone:        req.Handphone,
-		Birthdate:        req.Birthdate,
 		Payment:          getFileEndpoint,
 		PaymentConfirmed: &False,
 		CheckedIn:        &False,
 	}
+	applyTicketProfile(&ticket, profile, s.profileSnapshot)
 
 	if _, err := s.ticketRepo.CreateTicket(ticket); err != nil {
 		return err
 	}
 
+	// the ticket is already stored, a profile that failed to save is only missed next time
+	_ = rememberTicketProfile(s.userRepo, user, profile, s.profileSnapshot)
+
 	// send email
-	user, err := s.userRepo.GetUserById(userID)
-	if err != nil {
-		return err
-	}
 
 	go func() {
 		readHtml, err := os.ReadFile("./utils/template/mail_payment_received.html")
@@ -212,12 +225,17 @@ func (s *mainEventService) RegisterMainEvent(ctx context.Context, req dto.MainEv
 	return nil
 }
 
-func (s *mainEventService) ConfirmPayment(ctx context.Context, req dto.MainEventConfirmPaymentRequest) error {
+// eventIDs are the events an api key is scoped to, empty when there is no restriction
+func (s *mainEventService) ConfirmPayment(ctx context.Context, req dto.MainEventConfirmPaymentRequest, eventIDs []string) error {
 	ticket, err := s.ticketRepo.FindByTicketID(req.Code)
 	if err != nil {
 		return dto.ErrTicketNotFound
 	}
 
+	if !isEventAllowed(ticket.EventID, eventIDs) {
+		return dto.ErrAPIKeyEventNotAllowed
+	}
+
 	// event, err := s.eventRepo.GetByID(ticket.EventID)
 	// if err != nil {
 	// 	return dto.ErrEventNotFound
@@ -272,12 +290,16 @@ func (s *mainEventService) ConfirmPayment(ctx context.Context, req dto.MainEvent
 	return nil
 }
 
-func (s *mainEventService) CheckIn(ctx context.Context, req dto.MainEventCheckInRequest) error {
+func (s *mainEventService) CheckIn(ctx context.Context, req dto.MainEventCheckInRequest, eventIDs []string) error {
 	ticket, err := s.ticketRepo.FindByTicketID(req.Code)
 	if err != nil {
 		return dto.ErrTicketNotFound
 	}
 
+	if !isEventAllowed(ticket.EventID, eventIDs) {
+		return dto.ErrAPIKeyEventNotAllowed
+	}
+
 	checked := true
 	ticket.CheckedIn = &checked
 	_, err = s.ticketRepo.UpdateTicket(ticket)
@@ -376,7 +398,7 @@ func (s *mainEventService) GetStatus(ctx context.Context) (dto.MainEventStatusRe
 	return res, nil
 }
 
-func (s *mainEventService) GetMainEventPaginated(ctx context.Context, req dto.PaginationQuery) (dto.TicketPaginationResponse, error) {
+func (s *mainEventService) GetMainEventPaginated(ctx context.Context, req dto.PaginationQuery, eventIDs []string) (dto.TicketPaginationResponse, error) {
 	var limit int
 	var page int
 
@@ -390,7 +412,7 @@ func (s *mainEventService) GetMainEventPaginated(ctx context.Context, req dto.Pa
 		page = constants.ENUM_PAGINATION_PAGE
 	}
 
-	tickets, maxPage, count, err := s.ticketRepo.JoinGetAllPaginationME(req.Search, limit, page)
+	tickets, maxPage, count, err := s.ticketRepo.JoinGetAllPaginationME(req.Search, limit, page, eventIDs)
 	if err != nil {
 		return dto.TicketPaginationResponse{}, err
 	}
@@ -419,12 +441,16 @@ func (s *mainEventService) GetMainEventPaginated(ctx context.Context, req dto.Pa
 	}, nil
 }
 
-func (s *mainEventService) GetMainEventDetail(ctx context.Context, id string) (dto.MainEventResponse, error) {
+func (s *mainEventService) GetMainEventDetail(ctx context.Context, id string, eventIDs []string) (dto.MainEventResponse, error) {
 	ticket, err := s.ticketRepo.GetTicketById(id)
 	if err != nil {
 		return dto.MainEventResponse{}, dto.ErrTicketNotFound
 	}
 
+	if !isEventAllowed(ticket.EventID, eventIDs) {
+		return dto.MainEventResponse{}, dto.ErrAPIKeyEventNotAllowed
+	}
+
 	event, err := s.eventRepo.GetByID(ticket.EventID)
 	if err != nil {
 		return dto.MainEventResponse{}, dto.ErrEventNotFound
@@ -434,6 +460,7 @@ func (s *mainEventService) GetMainEventDetail(ctx context.Context, id string) (d
 	if err != nil {
 		return dto.MainEventResponse{}, dto.ErrUserNotFound
 	}
+	ticket.User = &user
 
 	return dto.MainEventResponse{
 		ID:        ticket.TicketID,
@@ -444,8 +471,8 @@ func (s *mainEventService) GetMainEventDetail(ctx context.Context, id string) (d
 		EventName: event.Name,
 		Price:     event.Price,
 
-		Handphone:    ticket.Handphone,
-		Birthdate:    ticket.Birthdate,
+		Handphone:    ticket.GetHandphone(),
+		Birthdate:    ticket.GetBirthdate(),
 		Seat:         ticket.Seat,
 		Payment:      ticket.Payment,
 		WithKit:      *event.WithKit,
@@ -453,8 +480,8 @@ func (s *mainEventService) GetMainEventDetail(ctx context.Context, id string) (d
 	}, nil
 }
 
-func (s *mainEventService) GetMainEventCounter(ctx context.Context) (dto.TicketCounter, error) {
-	total, confirmed_payments, checked_ins, err := s.ticketRepo.CountME()
+func (s *mainEventService) GetMainEventCounter(ctx context.Context, eventIDs []string) (dto.TicketCounter, error) {
+	total, confirmed_payments, checked_ins, err := s.ticketRepo.CountME(eventIDs)
 	if err != nil {
 		return dto.TicketCounter{}, err
 	}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+)
+
+func TestGetMainEventDetailScopedKey(t *testing.T) {
+	s := &mainEventService{ticketRepo: stubTicketRepository{tickets: map[string]entity.Ticket{
+		"ticket": {TicketID: "ticket", EventID: constants.MainEventNormalWithMerchID},
+	}}}
+
+	// a key for one tier must not read the tickets of another
+	_, err := s.GetMainEventDetail(context.Background(), "ticket", []string{constants.MainEventNormalNoMerchID})
+	if err != dto.ErrAPIKeyEventNotAllowed {
+		t.Fatalf("err = %v, want %v", err, dto.ErrAPIKeyEventNotAllowed)
+	}
+}
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"gorm.io/gorm"
+)
+
+type (
+	MFAService interface {
+		Challenge(ctx context.Context, user entity.User) (dto.MFAChallengeResponse, error)
+		VerifyLogin(ctx context.Context, req dto.MFAVerifyRequest, ip string) (entity.User, error)
+		SetupLogin(ctx context.Context, req dto.MFATokenRequest) (dto.MFAEnrollResponse, error)
+		ConfirmSetupLogin(ctx context.Context, req dto.MFAVerifyRequest) (entity.User, dto.MFARecoveryCodesResponse, error)
+		GetStatus(ctx context.Context, userID string) (dto.MFAStatusResponse, error)
+		Enroll(ctx context.Context, userID string) (dto.MFAEnrollResponse, error)
+		Activate(ctx context.Context, userID string, req dto.MFACodeRequest) (dto.MFARecoveryCodesResponse, error)
+		Disable(ctx context.Context, userID string, req dto.MFACodeRequest) error
+		RegenerateRecoveryCodes(ctx context.Context, userID string, req dto.MFACodeRequest) (dto.MFARecoveryCodesResponse, error)
+	}
+
+	mfaService struct {
+		userRepo       repository.UserRepository
+		roleRepo       repository.RoleRepository
+		userTokenRepo  repository.UserTokenRepository
+		mfaRepo        repository.MFARepository
+		lockoutService LockoutService
+
+		requiredRoles []string
+	}
+)
+
+func NewMFAService(
+	uRepo repository.UserRepository,
+	rRepo repository.RoleRepository,
+	utRepo repository.UserTokenRepository,
+	mRepo repository.MFARepository,
+	lockoutService LockoutService,
+	requiredRoles []string,
+) MFAService {
+	return &mfaService{
+		userRepo:       uRepo,
+		roleRepo:       rRepo,
+		userTokenRepo:  utRepo,
+		mfaRepo:        mRepo,
+		lockoutService: lockoutService,
+		requiredRoles:  requiredRoles,
+	}
+}
+
+// called once the password (or provider) is verified, an empty token means
+// the account can be signed in straight away
+func (s *mfaService) Challenge(ctx context.Context, user entity.User) (dto.MFAChallengeResponse, error) {
+	mfa, err := s.mfaRepo.GetByUserID(user.ID.String())
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return dto.MFAChallengeResponse{}, err
+	}
+
+	enabled := err == nil && mfa.EnabledAt != nil
+	if !enabled {
+		required, err := s.isRequiredFor(user)
+		if err != nil {
+			return dto.MFAChallengeResponse{}, err
+		}
+
+		if !required {
+			return dto.MFAChallengeResponse{}, nil
+		}
+	}
+
+	token, err := utils.GenRandomToken()
+	if err != nil {
+		return dto.MFAChallengeResponse{}, dto.ErrGenerateToken
+	}
+
+	expiresAt := time.Now().Add(constants.MFA_TOKEN_EXPIRE_TIME)
+	if _, err := s.userTokenRepo.Create(entity.UserToken{
+		UserID:    user.ID,
+		Purpose:   constants.ENUM_TOKEN_PURPOSE_MFA_LOGIN,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return dto.MFAChallengeResponse{}, dto.ErrGenerateToken
+	}
+
+	return dto.MFAChallengeResponse{
+		MFARequired:        enabled,
+		EnrollmentRequired: !enabled,
+		MFAToken:           token,
+		ExpiresAt:          expiresAt,
+	}, nil
+}
+
+// a wrong code keeps the challenge alive but counts as a failed login
+func (s *mfaService) VerifyLogin(ctx context.Context, req dto.MFAVerifyRequest, ip string) (entity.User, error) {
+	userToken, user, err := s.getLoginToken(req.MFAToken)
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	if err := s.lockoutService.Check(ctx, user.Email, ip); err != nil {
+		return entity.User{}, err
+	}
+
+	mfa, err := s.mfaRepo.GetByUserID(user.ID.String())
+	if err != nil || mfa.EnabledAt == nil {
+		return entity.User{}, dto.ErrMFANotEnabled
+	}
+
+	if err := s.verifyCode(mfa, req.Code, true); err != nil {
+		s.lockoutService.RecordFailure(ctx, user.Email, ip)
+		return entity.User{}, err
+	}
+
+	if err := s.userTokenRepo.Consume(userToken); err != nil {
+		return entity.User{}, dto.ErrMFATokenInvalid
+	}
+
+	return user, nil
+}
+
+// enrollment for a role that requires two-factor happens inside the login,
+// the account has no session to enroll with yet
+func (s *mfaService) SetupLogin(ctx context.Context, req dto.MFATokenRequest) (dto.MFAEnrollResponse, error) {
+	_, user, err := s.getLoginToken(req.MFAToken)
+	if err != nil {
+		return dto.MFAEnrollResponse{}, err
+	}
+
+	return s.enroll(user)
+}
+
+func (s *mfaService) ConfirmSetupLogin(ctx context.Context, req dto.MFAVerifyRequest) (entity.User, dto.MFARecoveryCodesResponse, error) {
+	userToken, user, err := s.getLoginToken(req.MFAToken)
+	if err != nil {
+		return entity.User{}, dto.MFARecoveryCodesResponse{}, err
+	}
+
+	codes, err := s.activate(user.ID.String(), req.Code)
+	if err != nil {
+		return entity.User{}, dto.MFARecoveryCodesResponse{}, err
+	}
+
+	if err := s.userTokenRepo.Consume(userToken); err != nil {
+		return entity.User{}, dto.MFARecoveryCodesResponse{}, dto.ErrMFATokenInvalid
+	}
+
+	return user, codes, nil
+}
+
+func (s *mfaService) GetStatus(ctx context.Context, userID string) (dto.MFAStatusResponse, error) {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return dto.MFAStatusResponse{}, dto.ErrUserNotFound
+	}
+
+	required, err := s.isRequiredFor(user)
+	if err != nil {
+		return dto.MFAStatusResponse{}, err
+	}
+
+	res := dto.MFAStatusResponse{
+		Required: required,
+	}
+
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil || mfa.EnabledAt == nil {
+		return res, nil
+	}
+
+	left, err := s.mfaRepo.CountRecoveryCodes(userID)
+	if err != nil {
+		return dto.MFAStatusResponse{}, err
+	}
+
+	res.Enabled = true
+	res.EnabledAt = mfa.EnabledAt
+	res.RecoveryCodesLeft = left
+
+	return res, nil
+}
+
+func (s *mfaService) Enroll(ctx context.Context, userID string) (dto.MFAEnrollResponse, error) {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return dto.MFAEnrollResponse{}, dto.ErrUserNotFound
+	}
+
+	return s.enroll(user)
+}
+
+func (s *mfaService) Activate(ctx context.Context, userID string, req dto.MFACodeRequest) (dto.MFARecoveryCodesResponse, error) {
+	return s.activate(userID, req.Code)
+}
+
+func (s *mfaService) Disable(ctx context.Context, userID string, req dto.MFACodeRequest) error {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	required, err := s.isRequiredFor(user)
+	if err != nil {
+		return err
+	}
+
+	if required {
+		return dto.ErrMFARequiredByRole
+	}
+
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil || mfa.EnabledAt == nil {
+		return dto.ErrMFANotEnabled
+	}
+
+	if err := s.verifyCode(mfa, req.Code, true); err != nil {
+		return err
+	}
+
+	return s.mfaRepo.Delete(userID)
+}
+
+// replaces every code issued before, used or not
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, userID string, req dto.MFACodeRequest) (dto.MFARecoveryCodesResponse, error) {
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil || mfa.EnabledAt == nil {
+		return dto.MFARecoveryCodesResponse{}, dto.ErrMFANotEnabled
+	}
+
+	if err := s.verifyCode(mfa, req.Code, false); err != nil {
+		return dto.MFARecoveryCodesResponse{}, err
+	}
+
+	return s.newRecoveryCodes(mfa.UserID.String())
+}
+
+// a pending secret is simply replaced, it only becomes active once a code is confirmed
+func (s *mfaService) enroll(user entity.User) (dto.MFAEnrollResponse, error) {
+	mfa, err := s.mfaRepo.GetByUserID(user.ID.String())
+	if err == nil && mfa.EnabledAt != nil {
+		return dto.MFAEnrollResponse{}, dto.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := utils.GenTOTPSecret()
+	if err != nil {
+		return dto.MFAEnrollResponse{}, err
+	}
+
+	encrypted, err := utils.AESEncrypt(secret)
+	if err != nil {
+		return dto.MFAEnrollResponse{}, err
+	}
+
+	if _, err := s.mfaRepo.Save(entity.UserMFA{
+		UserID:    user.ID,
+		Secret:    encrypted,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return dto.MFAEnrollResponse{}, err
+	}
+
+	return dto.MFAEnrollResponse{
+		Secret: secret,
+		URI:    utils.TOTPProvisioningURI(secret, constants.MFA_ISSUER, user.Email),
+	}, nil
+}
+
+func (s *mfaService) activate(userID string, code string) (dto.MFARecoveryCodesResponse, error) {
+	mfa, err := s.mfaRepo.GetByUserID(userID)
+	if err != nil {
+		return dto.MFARecoveryCodesResponse{}, dto.ErrMFANotEnrolled
+	}
+
+	if mfa.EnabledAt != nil {
+		return dto.MFARecoveryCodesResponse{}, dto.ErrMFAAlreadyEnabled
+	}
+
+	if err := s.verifyCode(mfa, code, false); err != nil {
+		return dto.MFARecoveryCodesResponse{}, err
+	}
+
+	res, err := s.newRecoveryCodes(userID)
+	if err != nil {
+		return dto.MFARecoveryCodesResponse{}, err
+	}
+
+	if err := s.mfaRepo.Enable(userID); err != nil {
+		return dto.MFARecoveryCodesResponse{}, err
+	}
+
+	return res, nil
+}
+
+// an authenticator code is only accepted once, a recovery code is burned on use
+func (s *mfaService) verifyCode(mfa entity.UserMFA, code string, allowRecovery bool) error {
+	code = strings.TrimSpace(code)
+
+	if len(code) == 6 {
+		secret, err := utils.AESDecrypt(mfa.Secret)
+		if err != nil {
+			return dto.ErrMFACodeInvalid
+		}
+
+		step, ok := utils.ValidateTOTP(secret, code, time.Now())
+		if !ok {
+			return dto.ErrMFACodeInvalid
+		}
+
+		if err := s.mfaRepo.UseStep(mfa.UserID.String(), step); err != nil {
+			return dto.ErrMFACodeInvalid
+		}
+
+		return nil
+	}
+
+	if !allowRecovery {
+		return dto.ErrMFACodeInvalid
+	}
+
+	if err := s.mfaRepo.UseRecoveryCode(mfa.UserID.String(), hashRecoveryCode(code)); err != nil {
+		return dto.ErrMFACodeInvalid
+	}
+
+	return nil
+}
+
+func (s *mfaService) newRecoveryCodes(userID string) (dto.MFARecoveryCodesResponse, error) {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return dto.MFARecoveryCodesResponse{}, dto.ErrUserNotFound
+	}
+
+	codes := make([]string, 0, constants.MFA_RECOVERY_CODE_COUNT)
+	entities := make([]entity.RecoveryCode, 0, constants.MFA_RECOVERY_CODE_COUNT)
+	for i := 0; i < constants.MFA_RECOVERY_CODE_COUNT; i++ {
+		code, err := utils.GenRecoveryCode()
+		if err != nil {
+			return dto.MFARecoveryCodesResponse{}, err
+		}
+
+		codes = append(codes, code)
+		entities = append(entities, entity.RecoveryCode{
+			UserID:   user.ID,
+			CodeHash: hashRecoveryCode(code),
+		})
+	}
+
+	if err := s.mfaRepo.ReplaceRecoveryCodes(userID, entities); err != nil {
+		return dto.MFARecoveryCodesResponse{}, err
+	}
+
+	return dto.MFARecoveryCodesResponse{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// the challenge is looked up without being consumed so a typo can be retried
+func (s *mfaService) getLoginToken(token string) (entity.UserToken, entity.User, error) {
+	userToken, err := s.userTokenRepo.GetByHash(utils.HashToken(token))
+	if err != nil ||
+		userToken.Purpose != constants.ENUM_TOKEN_PURPOSE_MFA_LOGIN ||
+		userToken.ConsumedAt != nil ||
+		time.Now().After(userToken.ExpiresAt) {
+		return entity.UserToken{}, entity.User{}, dto.ErrMFATokenInvalid
+	}
+
+	user, err := s.userRepo.GetUserById(userToken.UserID.String())
+	if err != nil {
+		return entity.UserToken{}, entity.User{}, dto.ErrUserNotFound
+	}
+
+	return userToken, user, nil
+}
+
+func (s *mfaService) isRequiredFor(user entity.User) (bool, error) {
+	role, err := s.roleRepo.GetRolebyId(user.RoleID)
+	if err != nil {
+		return false, dto.ErrUserNotFound
+	}
+
+	return isMFARequired(s.requiredRoles, role.Name), nil
+}
+
+// codes are compared without the dash and case, the way people tend to type them
+func hashRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return utils.HashToken(code)
+}
+
+func isMFARequired(requiredRoles []string, role string) bool {
+	for _, required := range requiredRoles {
+		if required == role {
+			return true
+		}
+	}
+
+	return false
+}
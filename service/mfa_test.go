@@ -0,0 +1,99 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/google/uuid"
+)
+
+// what an authenticator app shows for the secret at the given time
+func totpAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(at.Unix()/30))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", value%1000000)
+}
+
+func newTestMFA(t *testing.T) (*mfaService, entity.UserMFA, string) {
+	t.Helper()
+
+	if err := utils.SetAESKey(strings.Repeat("ab", 32)); err != nil {
+		t.Fatal(err)
+	}
+
+	secret, err := utils.GenTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, err := utils.AESEncrypt(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &mfaService{mfaRepo: stubMFARepository{
+		lastStep:      map[string]int64{},
+		recoveryCodes: map[string]bool{hashRecoveryCode("abcd-efgh"): false},
+	}}
+	return s, entity.UserMFA{UserID: uuid.New(), Secret: encrypted}, secret
+}
+
+func TestVerifyCodeRefusesReplay(t *testing.T) {
+	s, mfa, secret := newTestMFA(t)
+	code := totpAt(t, secret, time.Now())
+
+	if err := s.verifyCode(mfa, code, false); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := s.verifyCode(mfa, code, false); err != dto.ErrMFACodeInvalid {
+		t.Fatalf("replay: err = %v, want %v", err, dto.ErrMFACodeInvalid)
+	}
+}
+
+func TestVerifyCodeRefusesEarlierStep(t *testing.T) {
+	s, mfa, secret := newTestMFA(t)
+	now := time.Now()
+
+	// the previous step is still inside the drift window, but a newer code was already used
+	if err := s.verifyCode(mfa, totpAt(t, secret, now), false); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.verifyCode(mfa, totpAt(t, secret, now.Add(-30*time.Second)), false); err != dto.ErrMFACodeInvalid {
+		t.Fatalf("err = %v, want %v", err, dto.ErrMFACodeInvalid)
+	}
+}
+
+func TestVerifyCodeRecoveryCode(t *testing.T) {
+	s, mfa, _ := newTestMFA(t)
+
+	if err := s.verifyCode(mfa, "abcd-efgh", false); err != dto.ErrMFACodeInvalid {
+		t.Fatalf("recovery code accepted where it is not allowed: %v", err)
+	}
+	if err := s.verifyCode(mfa, " abcd-efgh ", true); err != nil {
+		t.Fatalf("first use: %v", err)
+	}
+	if err := s.verifyCode(mfa, "abcd-efgh", true); err != dto.ErrMFACodeInvalid {
+		t.Fatalf("reuse: err = %v, want %v", err, dto.ErrMFACodeInvalid)
+	}
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/TEDxITS/website-backend-2024/utils/oidc"
+	"gorm.io/gorm"
+)
+
+type (
+	OIDCService interface {
+		GetAuthURL(ctx context.Context, provider string) (dto.OIDCAuthURLResponse, error)
+		Callback(ctx context.Context, provider string, req dto.OIDCCallbackRequest, binding string) (entity.User, error)
+	}
+
+	oidcService struct {
+		providers    map[string]oidc.Provider
+		userRepo     repository.UserRepository
+		identityRepo repository.UserIdentityRepository
+	}
+)
+
+func NewOIDCService(
+	providers map[string]oidc.Provider,
+	uRepo repository.UserRepository,
+	iRepo repository.UserIdentityRepository,
+) OIDCService {
+	return &oidcService{
+		providers:    providers,
+		userRepo:     uRepo,
+		identityRepo: iRepo,
+	}
+}
+
+// the nonce and PKCE verifier ride along inside the encrypted state,
+// so nothing has to be stored between the redirect and the callback.
+// The binding goes to the browser as a cookie, a state lifted from
+// someone else's sign in can not be replayed without it.
+func (s *oidcService) GetAuthURL(ctx context.Context, provider string) (dto.OIDCAuthURLResponse, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return dto.OIDCAuthURLResponse{}, dto.ErrOIDCProviderNotFound
+	}
+
+	nonce, err := utils.GenRandomToken()
+	if err != nil {
+		return dto.OIDCAuthURLResponse{}, err
+	}
+
+	verifier, err := utils.GenRandomToken()
+	if err != nil {
+		return dto.OIDCAuthURLResponse{}, err
+	}
+
+	binding, err := utils.GenRandomToken()
+	if err != nil {
+		return dto.OIDCAuthURLResponse{}, err
+	}
+
+	expired := time.Now().Add(constants.OIDC_STATE_EXPIRE_TIME).Format("2006-01-02 15:04:05")
+	state, err := utils.AESEncrypt(provider + "||" + nonce + "||" + verifier + "||" + expired + "||" + binding)
+	if err != nil {
+		return dto.OIDCAuthURLResponse{}, err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	url, err := p.AuthCodeURL(oidcRedirectURI(provider), state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return dto.OIDCAuthURLResponse{}, err
+	}
+
+	return dto.OIDCAuthURLResponse{
+		URL:     url,
+		Binding: binding,
+	}, nil
+}
+
+func (s *oidcService) Callback(ctx context.Context, provider string, req dto.OIDCCallbackRequest, binding string) (entity.User, error) {
+	p, ok := s.providers[provider]
+	if !ok {
+		return entity.User{}, dto.ErrOIDCProviderNotFound
+	}
+
+	decrypted, err := utils.AESDecrypt(req.State)
+	if err != nil {
+		return entity.User{}, dto.ErrOIDCStateInvalid
+	}
+
+	split := strings.Split(decrypted, "||")
+	if len(split) != 5 || split[0] != provider {
+		return entity.User{}, dto.ErrOIDCStateInvalid
+	}
+
+	if binding == "" || subtle.ConstantTimeCompare([]byte(binding), []byte(split[4])) != 1 {
+		return entity.User{}, dto.ErrOIDCStateInvalid
+	}
+
+	nonce, verifier := split[1], split[2]
+	expired, err := time.Parse("2006-01-02 15:04:05", split[3])
+	if err != nil || time.Now().After(expired) {
+		return entity.User{}, dto.ErrOIDCStateInvalid
+	}
+
+	claims, err := p.Exchange(ctx, oidcRedirectURI(provider), req.Code, verifier, nonce)
+	if err != nil {
+		return entity.User{}, dto.ErrOIDCLogin
+	}
+
+	// tokens are issued by the caller, which may still have to ask for a second factor
+	return s.findOrCreateUser(provider, claims)
+}
+
+// an identity seen before logs straight in, otherwise the verified email is
+// used to link an existing verified account or to create one that needs no
+// verification. An unverified account is never linked, whoever registered
+// it may not own the address and would keep its password and sessions.
+func (s *oidcService) findOrCreateUser(provider string, claims oidc.Claims) (entity.User, error) {
+	identity, err := s.identityRepo.GetByProviderSubject(provider, claims.Subject)
+	if err == nil && identity.User != nil {
+		return *identity.User, nil
+	}
+
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return entity.User{}, dto.ErrOIDCLogin
+	}
+
+	if !claims.EmailVerified || claims.Email == "" {
+		return entity.User{}, dto.ErrOIDCEmailNotVerified
+	}
+
+	user, err := s.userRepo.GetUserByEmail(claims.Email)
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		user, err = s.createUser(claims)
+		if err != nil {
+			return entity.User{}, err
+		}
+	case err != nil:
+		return entity.User{}, dto.ErrOIDCLogin
+	case !user.Verified:
+		return entity.User{}, dto.ErrOIDCAccountNotVerified
+	}
+
+	if _, err := s.identityRepo.Create(entity.UserIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}); err != nil {
+		return entity.User{}, dto.ErrOIDCLogin
+	}
+
+	return user, nil
+}
+
+// the account gets a random password nobody knows, a password
+// can still be set later through the reset password flow
+func (s *oidcService) createUser(claims oidc.Claims) (entity.User, error) {
+	password, err := utils.GenRandomToken()
+	if err != nil {
+		return entity.User{}, err
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = strings.Split(claims.Email, "@")[0]
+	}
+
+	user, err := s.userRepo.RegisterUser(entity.User{
+		Name:     name,
+		Email:    claims.Email,
+		Password: password,
+		Verified: true,
+	})
+	if err != nil {
+		return entity.User{}, dto.ErrCreateUser
+	}
+
+	return user, nil
+}
+
+func oidcRedirectURI(provider string) string {
+	return constants.BASE_URL + "/auth/oauth/" + provider
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/TEDxITS/website-backend-2024/utils/oidc"
+	"github.com/google/uuid"
+)
+
+// stands in for the identity provider, the claims are what the id token would carry
+type mockProvider struct {
+	claims oidc.Claims
+	nonce  string
+}
+
+func (p *mockProvider) Name() string { return "mock" }
+
+func (p *mockProvider) AuthCodeURL(redirectURI string, state string, nonce string, codeChallenge string) (string, error) {
+	p.nonce = nonce
+	return "https://provider.test/auth?" + url.Values{"state": {state}}.Encode(), nil
+}
+
+func (p *mockProvider) Exchange(ctx context.Context, redirectURI string, code string, codeVerifier string, nonce string) (oidc.Claims, error) {
+	if nonce != p.nonce {
+		return oidc.Claims{}, oidc.ErrNonceMismatch
+	}
+	return p.claims, nil
+}
+
+func newTestOIDCService(t *testing.T, claims oidc.Claims, users ...entity.User) (*oidcService, stubUserRepository, *stubIdentityRepository) {
+	t.Helper()
+	if err := utils.SetAESKey(strings.Repeat("ab", 32)); err != nil {
+		t.Fatal(err)
+	}
+
+	userRepo := stubUserRepository{users: map[string]entity.User{}}
+	for _, user := range users {
+		userRepo.users[user.ID.String()] = user
+	}
+	identityRepo := &stubIdentityRepository{}
+
+	return &oidcService{
+		providers:    map[string]oidc.Provider{"mock": &mockProvider{claims: claims}},
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+	}, userRepo, identityRepo
+}
+
+// runs the redirect and hands back what the frontend would post to the callback
+func startOIDCLogin(t *testing.T, s *oidcService) (dto.OIDCCallbackRequest, string) {
+	t.Helper()
+	result, err := s.GetAuthURL(context.Background(), "mock")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := url.Parse(result.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dto.OIDCCallbackRequest{Code: "code", State: parsed.Query().Get("state")}, result.Binding
+}
+
+func TestOIDCCallbackRequiresStateBinding(t *testing.T) {
+	claims := oidc.Claims{Subject: "sub-1", Email: "new@example.com", EmailVerified: true}
+	s, _, _ := newTestOIDCService(t, claims)
+
+	req, binding := startOIDCLogin(t, s)
+	if binding == "" {
+		t.Fatal("no binding for the state cookie")
+	}
+
+	// a state started in another browser carries no cookie, or the wrong one
+	if _, err := s.Callback(context.Background(), "mock", req, ""); err != dto.ErrOIDCStateInvalid {
+		t.Fatalf("missing cookie: got %v", err)
+	}
+
+	_, otherBinding := startOIDCLogin(t, s)
+	if _, err := s.Callback(context.Background(), "mock", req, otherBinding); err != dto.ErrOIDCStateInvalid {
+		t.Fatalf("foreign cookie: got %v", err)
+	}
+
+	// the last redirect set the nonce the mock expects, so start a fresh one
+	req, binding = startOIDCLogin(t, s)
+	user, err := s.Callback(context.Background(), "mock", req, binding)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != claims.Email || !user.Verified {
+		t.Fatalf("got %+v, want a verified account for %s", user, claims.Email)
+	}
+}
+
+func TestOIDCCallbackLinksOnlyVerifiedAccounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		verified bool
+		err      error
+		linked   int
+	}{
+		{"verified account is linked", true, nil, 1},
+		{"unverified account is refused", false, dto.ErrOIDCAccountNotVerified, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := entity.User{ID: uuid.New(), Email: "taken@example.com", Password: "hash", Verified: tt.verified}
+			claims := oidc.Claims{Subject: "sub-2", Email: existing.Email, EmailVerified: true}
+			s, _, identities := newTestOIDCService(t, claims, existing)
+
+			req, binding := startOIDCLogin(t, s)
+			user, err := s.Callback(context.Background(), "mock", req, binding)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err == nil && user.ID != existing.ID {
+				t.Fatalf("logged into %s, want the existing %s", user.ID, existing.ID)
+			}
+			if len(identities.identities) != tt.linked {
+				t.Fatalf("%d identities linked, want %d", len(identities.identities), tt.linked)
+			}
+		})
+	}
+}
+
+func TestOIDCCallbackRejectsUnverifiedProviderEmail(t *testing.T) {
+	claims := oidc.Claims{Subject: "sub-3", Email: "someone@example.com", EmailVerified: false}
+	s, users, _ := newTestOIDCService(t, claims)
+
+	req, binding := startOIDCLogin(t, s)
+	if _, err := s.Callback(context.Background(), "mock", req, binding); err != dto.ErrOIDCEmailNotVerified {
+		t.Fatalf("err = %v, want %v", err, dto.ErrOIDCEmailNotVerified)
+	}
+	if len(users.users) != 0 {
+		t.Fatal("account created from an unverified email")
+	}
+}
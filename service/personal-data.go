@@ -0,0 +1,340 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+)
+
+type (
+	PersonalDataService interface {
+		ExportPersonalData(ctx context.Context, userID string) ([]byte, error)
+		RequestAccountDeletion(ctx context.Context, userID string) error
+		ConfirmAccountDeletion(ctx context.Context, token string) error
+	}
+
+	personalDataService struct {
+		userRepo         repository.UserRepository
+		roleRepo         repository.RoleRepository
+		userTokenRepo    repository.UserTokenRepository
+		mfaRepo          repository.MFARepository
+		personalDataRepo repository.PersonalDataRepository
+		bucketRepo       repository.BucketRepository
+		authService      AuthService
+	}
+)
+
+func NewPersonalDataService(
+	uRepo repository.UserRepository,
+	rRepo repository.RoleRepository,
+	utRepo repository.UserTokenRepository,
+	mRepo repository.MFARepository,
+	pdRepo repository.PersonalDataRepository,
+	bRepo repository.BucketRepository,
+	authService AuthService,
+) PersonalDataService {
+	return &personalDataService{
+		userRepo:         uRepo,
+		roleRepo:         rRepo,
+		userTokenRepo:    utRepo,
+		mfaRepo:          mRepo,
+		personalDataRepo: pdRepo,
+		bucketRepo:       bRepo,
+		authService:      authService,
+	}
+}
+
+// a zip holding data.json and every payment proof the user uploaded
+func (s *personalDataService) ExportPersonalData(ctx context.Context, userID string) ([]byte, error) {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return nil, dto.ErrUserNotFound
+	}
+
+	role, err := s.roleRepo.GetRolebyId(user.RoleID)
+	if err != nil {
+		return nil, dto.ErrUserNotFound
+	}
+
+	export := dto.PersonalDataExport{
+		ExportedAt: time.Now(),
+		Account: dto.PersonalDataAccount{
+			ID:          user.ID.String(),
+			Name:        user.Name,
+			Email:       user.Email,
+			Role:        role.Name,
+			Verified:    user.Verified,
+			Profile:     *toUserProfile(user),
+			SuspendedAt: user.SuspendedAt,
+			CreatedAt:   user.CreatedAt,
+		},
+		Identities:        []dto.PersonalDataIdentity{},
+		Tickets:           []dto.PersonalDataTicket{},
+		PE2RSVPs:          []dto.PersonalDataPE2RSVP{},
+		Certificates:      []dto.PersonalDataCertificate{},
+		SurveyInvitations: []dto.PersonalDataSurveyInvitation{},
+	}
+
+	if mfa, err := s.mfaRepo.GetByUserID(userID); err == nil && mfa.EnabledAt != nil {
+		export.Account.MFAEnabled = true
+	}
+
+	identities, err := s.personalDataRepo.GetIdentities(userID)
+	if err != nil {
+		return nil, dto.ErrExportPersonalData
+	}
+	for _, identity := range identities {
+		export.Identities = append(export.Identities, dto.PersonalDataIdentity{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		})
+	}
+
+	tickets, err := s.personalDataRepo.GetTickets(userID)
+	if err != nil {
+		return nil, dto.ErrExportPersonalData
+	}
+
+	// files are downloaded before anything is written, a missing one fails the whole export
+	names := []string{"data.json"}
+	files := make(map[string][]byte)
+	for _, ticket := range tickets {
+		ticket.User = &user
+		item := dto.PersonalDataTicket{
+			TicketID:         ticket.TicketID,
+			EventID:          ticket.EventID,
+			Handphone:        ticket.GetHandphone(),
+			Birthdate:        ticket.GetBirthdate(),
+			Seat:             ticket.Seat,
+			PaymentConfirmed: isTrue(ticket.PaymentConfirmed),
+			CheckedIn:        isTrue(ticket.CheckedIn),
+			Complimentary:    isTrue(ticket.Complimentary),
+			CreatedAt:        ticket.CreatedAt,
+		}
+		if ticket.Event != nil {
+			item.EventName = ticket.Event.Name
+		}
+
+		if filename, ok := paymentFilename(ticket.Payment); ok {
+			file, err := s.bucketRepo.DownloadFile(dto.ENUM_STORAGE_FOLDER_MAIN_EVENT, filename)
+			if err != nil {
+				return nil, dto.ErrExportPersonalData
+			}
+
+			item.PaymentFile = "files/payments/" + filename
+			names = append(names, item.PaymentFile)
+			files[item.PaymentFile] = file
+		}
+
+		export.Tickets = append(export.Tickets, item)
+	}
+
+	rsvps, err := s.personalDataRepo.GetPE2RSVPs(user.Email)
+	if err != nil {
+		return nil, dto.ErrExportPersonalData
+	}
+	for _, rsvp := range rsvps {
+		export.PE2RSVPs = append(export.PE2RSVPs, dto.PersonalDataPE2RSVP{
+			ID:                   rsvp.ID.String(),
+			Name:                 rsvp.Name,
+			Email:                rsvp.Email,
+			Institute:            rsvp.Institute,
+			Department:           rsvp.Department,
+			StudentID:            rsvp.StudentID,
+			Batch:                rsvp.Batch,
+			WillingToCome:        isTrue(rsvp.WillingToCome),
+			WillingToBeContacted: isTrue(rsvp.WillingToBeContacted),
+			Attended:             isTrue(rsvp.Attended),
+			Essay:                rsvp.Essay,
+			Status:               rsvp.Status,
+			WithdrawnAt:          rsvp.WithdrawnAt,
+		})
+	}
+
+	certificates, err := s.personalDataRepo.GetCertificates(userID, user.Email)
+	if err != nil {
+		return nil, dto.ErrExportPersonalData
+	}
+	for _, certificate := range certificates {
+		export.Certificates = append(export.Certificates, dto.PersonalDataCertificate{
+			Code:      certificate.Code,
+			Name:      certificate.Name,
+			EventName: certificate.EventName,
+			EventDate: certificate.EventDate,
+			CreatedAt: certificate.CreatedAt,
+		})
+	}
+
+	invitations, err := s.personalDataRepo.GetSurveyInvitations(user.Email)
+	if err != nil {
+		return nil, dto.ErrExportPersonalData
+	}
+	for _, invitation := range invitations {
+		item := dto.PersonalDataSurveyInvitation{
+			EventID:     invitation.EventID,
+			SentAt:      invitation.SentAt,
+			CompletedAt: invitation.CompletedAt,
+		}
+		if invitation.Survey != nil {
+			item.Survey = invitation.Survey.Title
+		}
+		export.SurveyInvitations = append(export.SurveyInvitations, item)
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return nil, dto.ErrExportPersonalData
+	}
+
+	var archive bytes.Buffer
+	writer := zip.NewWriter(&archive)
+
+	files["data.json"] = data
+	for _, name := range names {
+		w, err := writer.Create(name)
+		if err != nil {
+			return nil, dto.ErrExportPersonalData
+		}
+
+		if _, err := w.Write(files[name]); err != nil {
+			return nil, dto.ErrExportPersonalData
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, dto.ErrExportPersonalData
+	}
+
+	return archive.Bytes(), nil
+}
+
+func (s *personalDataService) RequestAccountDeletion(ctx context.Context, userID string) error {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	// otherwise the last admin could lock everyone out of the dashboard
+	role, err := s.roleRepo.GetRolebyId(user.RoleID)
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	if role.Name == constants.ENUM_ROLE_ADMIN {
+		return dto.ErrAdminNotAllowedDeletion
+	}
+
+	emailData, err := s.generateDeletionEmail(user)
+	if err != nil {
+		return dto.ErrGenerateDeletionEmail
+	}
+
+	if err := utils.SendMail(emailData); err != nil {
+		return dto.ErrSendEmail
+	}
+
+	return nil
+}
+
+func (s *personalDataService) ConfirmAccountDeletion(ctx context.Context, token string) error {
+	userToken, err := consumeUserToken(s.userTokenRepo, token, constants.ENUM_TOKEN_PURPOSE_DELETE_ACCOUNT)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetUserById(userToken.UserID.String())
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	tickets, err := s.personalDataRepo.GetTickets(user.ID.String())
+	if err != nil {
+		return dto.ErrDeleteAccount
+	}
+
+	// must run while the refresh tokens still exist, their access tokens are denied through them
+	if err := s.authService.RevokeUserSessions(ctx, user.ID.String()); err != nil {
+		return dto.ErrDeleteAccount
+	}
+
+	if err := s.personalDataRepo.Anonymise(user, accountLockoutKey(user.Email)); err != nil {
+		return dto.ErrDeleteAccount
+	}
+
+	// the account is already gone, a file left behind is only logged for a manual cleanup
+	for _, ticket := range tickets {
+		if filename, ok := paymentFilename(ticket.Payment); ok {
+			if err := s.bucketRepo.DeleteFile(dto.ENUM_STORAGE_FOLDER_MAIN_EVENT, filename); err != nil {
+				log.Printf("error deleting payment file %s: %v", filename, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *personalDataService) generateDeletionEmail(user entity.User) (utils.Email, error) {
+	token, err := issueUserToken(s.userTokenRepo, user, constants.ENUM_TOKEN_PURPOSE_DELETE_ACCOUNT, "")
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	readHtml, err := os.ReadFile("./utils/template/mail_delete_account.html")
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	tmpl, err := template.New("custom").Parse(string(readHtml))
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	var strMail bytes.Buffer
+	if err := tmpl.Execute(&strMail, struct {
+		Name        string
+		ConfirmLink string
+	}{
+		Name:        user.Name,
+		ConfirmLink: constants.BASE_URL + "/auth/delete-account?token=" + token,
+	}); err != nil {
+		return utils.Email{}, err
+	}
+
+	return utils.Email{
+		Email:   user.Email,
+		Subject: "Confirm Account Deletion - TEDxITS",
+		Body:    strMail.String(),
+	}, nil
+}
+
+// only uploads made through the main event registration live in our bucket
+func paymentFilename(payment string) (string, bool) {
+	if !strings.HasPrefix(payment, dto.STORAGE_ENDPOINT_MAIN_EVENT) {
+		return "", false
+	}
+
+	filename := path.Base(payment)
+	if filename == "" || filename == "." || filename == "/" {
+		return "", false
+	}
+
+	return filename, true
+}
+
+func isTrue(b *bool) bool {
+	return b != nil && *b
+}
@@ -0,0 +1,444 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"regexp"
+	"sort"
+	"text/template"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/google/uuid"
+)
+
+type (
+	PreEvent2ReviewService interface {
+		GetRubric(ctx context.Context) []constants.RubricCriterion
+		AssignReviewers(ctx context.Context, req dto.PE2ReviewAssignRequest) (dto.PE2ReviewAssignResponse, error)
+		GetMyReviews(ctx context.Context, reviewerID string) ([]dto.PE2ReviewResponse, error)
+		SubmitReview(ctx context.Context, id string, reviewerID string, req dto.PE2ReviewSubmitRequest) (dto.PE2ReviewResponse, error)
+		GetRSVPReviews(ctx context.Context, rsvpID string) ([]dto.PE2ReviewResponse, error)
+		GetReviewSummary(ctx context.Context, req dto.PaginationQuery) (dto.PE2ReviewSummaryPaginationResponse, error)
+		DecideRSVP(ctx context.Context, rsvpID string, req dto.PE2RSVPDecisionRequest) (dto.PE2RSVPResponse, error)
+		GetEssaySimilarities(ctx context.Context, req dto.PE2EssaySimilarityQuery) ([]dto.PE2EssaySimilarityResponse, error)
+	}
+
+	preEvent2ReviewService struct {
+		reviewRepo  repository.PE2ReviewRepository
+		pe2RSVPRepo repository.PE2RSVPRepository
+		userRepo    repository.UserRepository
+		roleRepo    repository.RoleRepository
+	}
+)
+
+func NewPreEvent2ReviewService(
+	rvRepo repository.PE2ReviewRepository,
+	pRepo repository.PE2RSVPRepository,
+	uRepo repository.UserRepository,
+	rRepo repository.RoleRepository,
+) PreEvent2ReviewService {
+	return &preEvent2ReviewService{
+		reviewRepo:  rvRepo,
+		pe2RSVPRepo: pRepo,
+		userRepo:    uRepo,
+		roleRepo:    rRepo,
+	}
+}
+
+func (s *preEvent2ReviewService) GetRubric(ctx context.Context) []constants.RubricCriterion {
+	return constants.PE2_ESSAY_RUBRIC
+}
+
+func (s *preEvent2ReviewService) AssignReviewers(ctx context.Context, req dto.PE2ReviewAssignRequest) (dto.PE2ReviewAssignResponse, error) {
+	var reviewers []entity.User
+	for _, id := range req.ReviewerIDs {
+		user, err := s.userRepo.GetUserById(id)
+		if err != nil {
+			return dto.PE2ReviewAssignResponse{}, dto.ErrReviewerNotFound
+		}
+
+		// admins can open every RSVP, only a dedicated reviewer role keeps the round blind
+		role, err := s.roleRepo.GetRolebyId(user.RoleID)
+		if err != nil || role.Name == constants.ENUM_ROLE_ADMIN {
+			return dto.PE2ReviewAssignResponse{}, dto.ErrReviewerNotAllowed
+		}
+
+		allowed, err := s.roleRepo.HasPermission(role.ID.String(), constants.PERMISSION_PE2_REVIEW)
+		if err != nil || !allowed {
+			return dto.PE2ReviewAssignResponse{}, dto.ErrReviewerNotAllowed
+		}
+
+		reviewers = append(reviewers, user)
+	}
+
+	if len(reviewers) == 0 {
+		return dto.PE2ReviewAssignResponse{}, dto.ErrReviewerNotFound
+	}
+
+	var rsvps []entity.PE2RSVP
+	var err error
+	if len(req.RSVPIDs) > 0 {
+		rsvps, err = s.pe2RSVPRepo.GetByIds(req.RSVPIDs)
+	} else {
+		rsvps, err = s.pe2RSVPRepo.GetAll()
+	}
+	if err != nil {
+		return dto.PE2ReviewAssignResponse{}, err
+	}
+
+	if len(rsvps) == 0 {
+		return dto.PE2ReviewAssignResponse{}, dto.ErrNoRSVPToAssign
+	}
+
+	perRSVP := req.ReviewsPerRSVP
+	if perRSVP <= 0 {
+		perRSVP = 1
+	}
+
+	if perRSVP > len(reviewers) {
+		perRSVP = len(reviewers)
+	}
+
+	// round robin so every reviewer gets an even share of essays
+	var reviews []entity.PE2Review
+	for i, rsvp := range rsvps {
+		for k := 0; k < perRSVP; k++ {
+			reviewer := reviewers[(i*perRSVP+k)%len(reviewers)]
+			reviews = append(reviews, entity.PE2Review{
+				RSVPID:     rsvp.ID,
+				ReviewerID: reviewer.ID,
+			})
+		}
+	}
+
+	assigned, err := s.reviewRepo.CreateAssignments(reviews)
+	if err != nil {
+		return dto.PE2ReviewAssignResponse{}, err
+	}
+
+	return dto.PE2ReviewAssignResponse{
+		Assigned: assigned,
+	}, nil
+}
+
+func (s *preEvent2ReviewService) GetMyReviews(ctx context.Context, reviewerID string) ([]dto.PE2ReviewResponse, error) {
+	reviews, err := s.reviewRepo.GetByReviewerID(reviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []dto.PE2ReviewResponse
+	for _, review := range reviews {
+		result = append(result, toBlindPE2ReviewResponse(review))
+	}
+
+	return result, nil
+}
+
+func (s *preEvent2ReviewService) SubmitReview(ctx context.Context, id string, reviewerID string, req dto.PE2ReviewSubmitRequest) (dto.PE2ReviewResponse, error) {
+	review, err := s.reviewRepo.GetByID(id)
+	if err != nil {
+		return dto.PE2ReviewResponse{}, dto.ErrReviewNotFound
+	}
+
+	if review.ReviewerID.String() != reviewerID {
+		return dto.PE2ReviewResponse{}, dto.ErrReviewNotAssignedToYou
+	}
+
+	rubric := make(map[string]constants.RubricCriterion)
+	for _, criterion := range constants.PE2_ESSAY_RUBRIC {
+		rubric[criterion.Key] = criterion
+	}
+
+	for key := range req.Scores {
+		if _, ok := rubric[key]; !ok {
+			return dto.PE2ReviewResponse{}, dto.ErrReviewCriterionInvalid
+		}
+	}
+
+	var total int
+	var scores []entity.PE2ReviewScore
+	for _, criterion := range constants.PE2_ESSAY_RUBRIC {
+		score, ok := req.Scores[criterion.Key]
+		if !ok {
+			return dto.PE2ReviewResponse{}, dto.ErrReviewCriterionMissing
+		}
+
+		if score < 0 || score > criterion.MaxScore {
+			return dto.PE2ReviewResponse{}, dto.ErrReviewScoreOutOfRange
+		}
+
+		total += score
+		scores = append(scores, entity.PE2ReviewScore{
+			ReviewID:  review.ID,
+			Criterion: criterion.Key,
+			Score:     score,
+		})
+	}
+
+	now := time.Now()
+	review.Scores = scores
+	review.TotalScore = total
+	review.Comment = req.Comment
+	review.SubmittedAt = &now
+
+	review, err = s.reviewRepo.SubmitReview(review)
+	if err != nil {
+		return dto.PE2ReviewResponse{}, err
+	}
+
+	return toBlindPE2ReviewResponse(review), nil
+}
+
+func (s *preEvent2ReviewService) GetRSVPReviews(ctx context.Context, rsvpID string) ([]dto.PE2ReviewResponse, error) {
+	if _, err := uuid.Parse(rsvpID); err != nil {
+		return nil, dto.ErrTicketNotFound
+	}
+
+	reviews, err := s.reviewRepo.GetByRSVPID(rsvpID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []dto.PE2ReviewResponse
+	for _, review := range reviews {
+		res := toPE2ReviewResponse(review)
+		res.ReviewerID = review.ReviewerID.String()
+		if review.Reviewer != nil {
+			res.Reviewer = review.Reviewer.Name
+		}
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+func (s *preEvent2ReviewService) GetReviewSummary(ctx context.Context, req dto.PaginationQuery) (dto.PE2ReviewSummaryPaginationResponse, error) {
+	var limit int
+	var page int
+
+	limit = req.PerPage
+	if limit <= 0 {
+		limit = constants.ENUM_PAGINATION_LIMIT
+	}
+
+	page = req.Page
+	if page <= 0 {
+		page = constants.ENUM_PAGINATION_PAGE
+	}
+
+	summaries, maxPage, count, err := s.reviewRepo.GetSummaryPagination(req.Search, limit, page)
+	if err != nil {
+		return dto.PE2ReviewSummaryPaginationResponse{}, err
+	}
+
+	return dto.PE2ReviewSummaryPaginationResponse{
+		Data: summaries,
+		PaginationMetadata: dto.PaginationMetadata{
+			Page:    page,
+			PerPage: limit,
+			MaxPage: maxPage,
+			Count:   count,
+		},
+	}, nil
+}
+
+func (s *preEvent2ReviewService) DecideRSVP(ctx context.Context, rsvpID string, req dto.PE2RSVPDecisionRequest) (dto.PE2RSVPResponse, error) {
+	if req.Status != constants.ENUM_PE2_STATUS_ACCEPTED &&
+		req.Status != constants.ENUM_PE2_STATUS_REJECTED &&
+		req.Status != constants.ENUM_PE2_STATUS_WAITLISTED {
+		return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPStatusInvalid
+	}
+
+	rsvp, err := s.pe2RSVPRepo.GetById(rsvpID)
+	if err != nil {
+		return dto.PE2RSVPResponse{}, dto.ErrTicketNotFound
+	}
+
+	if rsvp.Status == constants.ENUM_PE2_STATUS_WITHDRAWN {
+		return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPWithdrawn
+	}
+
+	// the same decision again is how a failed email is retried
+	if rsvp.Status == req.Status {
+		if rsvp.DecisionNotifiedAt != nil {
+			return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPAlreadyDecidedAs
+		}
+
+		if err := s.sendDecisionEmail(rsvp); err != nil {
+			return dto.PE2RSVPResponse{}, err
+		}
+
+		return toPE2RSVPResponse(rsvp), nil
+	}
+
+	if req.Status == constants.ENUM_PE2_STATUS_ACCEPTED && (rsvp.WillingToCome == nil || !*rsvp.WillingToCome) {
+		return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPNotWillingCome
+	}
+
+	rsvp, err = s.pe2RSVPRepo.UpdateStatus(rsvpID, req.Status)
+	if err != nil {
+		return dto.PE2RSVPResponse{}, err
+	}
+
+	// the decision stands even if the email fails, sending the same decision again retries it
+	if err := s.sendDecisionEmail(rsvp); err != nil {
+		return dto.PE2RSVPResponse{}, err
+	}
+
+	return toPE2RSVPResponse(rsvp), nil
+}
+
+func (s *preEvent2ReviewService) sendDecisionEmail(rsvp entity.PE2RSVP) error {
+	readHtml, err := os.ReadFile("./utils/template/mail_pe2_" + rsvp.Status + ".html")
+	if err != nil {
+		return dto.ErrGenerateDecisionEmail
+	}
+
+	tmpl, err := template.New("custom").Parse(string(readHtml))
+	if err != nil {
+		return dto.ErrGenerateDecisionEmail
+	}
+
+	var strMail bytes.Buffer
+	if err := tmpl.Execute(&strMail, struct {
+		Name string
+	}{
+		Name: rsvp.Name,
+	}); err != nil {
+		return dto.ErrGenerateDecisionEmail
+	}
+
+	if err := utils.SendMail(utils.Email{
+		Email:   rsvp.Email,
+		Subject: "Pre-event 2 Application Result - TEDxITS",
+		Body:    strMail.String(),
+	}); err != nil {
+		return dto.ErrSendEmail
+	}
+
+	return s.pe2RSVPRepo.MarkDecisionNotified(rsvp.ID.String())
+}
+
+func toPE2ReviewResponse(review entity.PE2Review) dto.PE2ReviewResponse {
+	res := dto.PE2ReviewResponse{
+		ID:          review.ID.String(),
+		RSVPID:      review.RSVPID.String(),
+		Scores:      make(map[string]int),
+		TotalScore:  review.TotalScore,
+		Comment:     review.Comment,
+		SubmittedAt: review.SubmittedAt,
+	}
+
+	if review.RSVP != nil {
+		res.Name = review.RSVP.Name
+		res.Institute = review.RSVP.Institute
+		res.Department = review.RSVP.Department
+		res.Batch = review.RSVP.Batch
+		res.Essay = review.RSVP.Essay
+	}
+
+	for _, score := range review.Scores {
+		res.Scores[score.Criterion] = score.Score
+	}
+
+	return res
+}
+
+// reviewers only get to see the essay, anything that could identify
+// the applicant is stripped so the judging stays blind
+func toBlindPE2ReviewResponse(review entity.PE2Review) dto.PE2ReviewResponse {
+	res := toPE2ReviewResponse(review)
+	res.Applicant = "Applicant " + utils.HashToken(review.RSVPID.String())[:8]
+	res.Name = ""
+	res.Institute = ""
+	res.Department = ""
+	res.Batch = ""
+
+	if review.RSVP != nil {
+		res.Essay = redactIdentity(review.RSVP.Essay, review.RSVP.Name, review.RSVP.Institute, review.RSVP.StudentID, review.RSVP.Email)
+	}
+
+	return res
+}
+
+func redactIdentity(text string, identities ...string) string {
+	for _, identity := range identities {
+		if len(identity) < 3 {
+			continue
+		}
+
+		pattern := regexp.MustCompile("(?i)" + regexp.QuoteMeta(identity))
+		text = pattern.ReplaceAllString(text, "[redacted]")
+	}
+
+	return text
+}
+
+func (s *preEvent2ReviewService) GetEssaySimilarities(ctx context.Context, req dto.PE2EssaySimilarityQuery) ([]dto.PE2EssaySimilarityResponse, error) {
+	threshold := req.Threshold
+	if threshold == 0 {
+		threshold = dto.PE2_ESSAY_SIMILARITY_THRESHOLD
+	}
+
+	if threshold < 0 || threshold > 1 {
+		return nil, dto.ErrSimilarityThreshold
+	}
+
+	rsvps, err := s.pe2RSVPRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]utils.ShingledText, len(rsvps))
+	for i, rsvp := range rsvps {
+		docs[i] = utils.Shingle(rsvp.Essay, utils.SIMILARITY_SHINGLE_SIZE)
+	}
+
+	result := []dto.PE2EssaySimilarityResponse{}
+	for i := 0; i < len(docs); i++ {
+		for j := i + 1; j < len(docs); j++ {
+			similarity := docs[i].Similarity(docs[j])
+			if similarity < threshold {
+				continue
+			}
+
+			first, second := docs[i].Overlaps(docs[j], utils.SIMILARITY_SHINGLE_SIZE)
+			result = append(result, dto.PE2EssaySimilarityResponse{
+				Similarity: similarity,
+				First:      toPE2EssaySimilarityEssay(rsvps[i], first),
+				Second:     toPE2EssaySimilarityEssay(rsvps[j], second),
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Similarity > result[j].Similarity
+	})
+
+	return result, nil
+}
+
+func toPE2EssaySimilarityEssay(rsvp entity.PE2RSVP, segments []utils.TextSegment) dto.PE2EssaySimilarityEssay {
+	essay := dto.PE2EssaySimilarityEssay{
+		RSVPID:    rsvp.ID.String(),
+		Name:      rsvp.Name,
+		Institute: rsvp.Institute,
+		Status:    rsvp.Status,
+	}
+
+	for _, segment := range segments {
+		essay.Segments = append(essay.Segments, dto.PE2EssaySegment{
+			Text:    segment.Text,
+			Overlap: segment.Overlap,
+		})
+	}
+
+	return essay
+}
@@ -1,53 +1,68 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"log"
+	"os"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/TEDxITS/website-backend-2024/constants"
 	"github.com/TEDxITS/website-backend-2024/dto"
 	"github.com/TEDxITS/website-backend-2024/entity"
 	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
 )
 
 type (
 	PreEvent2Service interface {
-		CreatePE2RSVP(context.Context, dto.PE2RSVPRequest) (dto.PE2RSVPResponse, error)
+		CreatePE2RSVP(ctx context.Context, req dto.PE2RSVPRequest, userID string) (dto.PE2RSVPResponse, error)
 		GetPE2RSVPPaginated(context.Context, dto.PaginationQuery) (dto.PE2RSVPPaginationResponse, error)
 		GetPE2RSVPDetail(context.Context, string) (dto.PE2RSVPResponse, error)
 		GetPE2RSVPCounter(context.Context) (dto.PE2RSVPCounter, error)
 		GetPE2RSVPStatus(context.Context) (bool, error)
+		CheckInPE2RSVP(context.Context, dto.PE2RSVPCheckInRequest) error
+		GetMyPE2RSVP(ctx context.Context, token string) (dto.PE2RSVPResponse, error)
+		UpdateMyPE2RSVP(ctx context.Context, token string, req dto.PE2RSVPUpdateRequest) (dto.PE2RSVPResponse, error)
+		WithdrawMyPE2RSVP(ctx context.Context, token string) (dto.PE2RSVPResponse, error)
+		ResendPE2RSVPLink(ctx context.Context, req dto.PE2RSVPResendLinkRequest)
 	}
 
 	preEvent2Service struct {
 		eventRepo   repository.EventRepository
 		pe2RSVPRepo repository.PE2RSVPRepository
+		userRepo    repository.UserRepository
 	}
 )
 
-func NewPreEvent2Service(eventRepo repository.EventRepository, pe2RSVPRepo repository.PE2RSVPRepository) PreEvent2Service {
+func NewPreEvent2Service(eventRepo repository.EventRepository, pe2RSVPRepo repository.PE2RSVPRepository, userRepo repository.UserRepository) PreEvent2Service {
 	return &preEvent2Service{
 		eventRepo:   eventRepo,
 		pe2RSVPRepo: pe2RSVPRepo,
+		userRepo:    userRepo,
 	}
 }
 
-func (s *preEvent2Service) CreatePE2RSVP(ctx context.Context, req dto.PE2RSVPRequest) (dto.PE2RSVPResponse, error) {
-	event, err := s.eventRepo.GetPE2Detail()
-	if err != nil {
+// userID is empty for visitors who are not signed in
+func (s *preEvent2Service) CreatePE2RSVP(ctx context.Context, req dto.PE2RSVPRequest, userID string) (dto.PE2RSVPResponse, error) {
+	if err := s.checkPE2RSVPOpen(); err != nil {
 		return dto.PE2RSVPResponse{}, err
 	}
 
-	if event.Registers >= event.Capacity {
-		return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPFull
-	}
-
-	if time.Now().Before(event.StartDate) {
-		return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPNotOpen
+	var user *entity.User
+	if userID != "" {
+		found, err := s.userRepo.GetUserById(userID)
+		if err != nil {
+			return dto.PE2RSVPResponse{}, dto.ErrUserNotFound
+		}
+		user = &found
+		prefillPE2RSVP(found, &req)
 	}
 
-	if time.Now().After(event.EndDate) {
-		return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPClosed
+	if err := validatePE2RSVPRequest(&req); err != nil {
+		return dto.PE2RSVPResponse{}, err
 	}
 
 	exist, err := s.pe2RSVPRepo.CheckEmailExist(req.Email)
@@ -71,23 +86,35 @@ func (s *preEvent2Service) CreatePE2RSVP(ctx context.Context, req dto.PE2RSVPReq
 		Essay:                req.Essay,
 	}
 
+	token, err := utils.GenRandomToken()
+	if err != nil {
+		return dto.PE2RSVPResponse{}, err
+	}
+	rsvp.ManageTokenHash = utils.HashToken(token)
+
 	res, err := s.pe2RSVPRepo.Create(rsvp)
 	if err != nil {
 		return dto.PE2RSVPResponse{}, err
 	}
 
-	return dto.PE2RSVPResponse{
-		ID:                   res.ID,
-		Name:                 res.Name,
-		Email:                res.Email,
-		Institute:            res.Institute,
-		Department:           res.Department,
-		StudentID:            res.StudentID,
-		Batch:                res.Batch,
-		WillingToCome:        *res.WillingToCome,
-		WillingToBeContacted: *res.WillingToBeContacted,
-		Essay:                res.Essay,
-	}, nil
+	if user != nil {
+		_ = rememberPE2Profile(s.userRepo, *user, res)
+	}
+
+	// the RSVP is already stored, a lost email can be requested again through the resend link
+	go func() {
+		emailData, err := generatePE2RSVPManageEmail(res, token)
+		if err != nil {
+			log.Printf("error sending RSVP link: %v", err)
+			return
+		}
+
+		if err := utils.SendMail(emailData); err != nil {
+			log.Printf("error sending RSVP link: %v", err)
+		}
+	}()
+
+	return toPE2RSVPResponse(res), nil
 }
 
 func (s *preEvent2Service) GetPE2RSVPPaginated(ctx context.Context, req dto.PaginationQuery) (dto.PE2RSVPPaginationResponse, error) {
@@ -138,18 +165,7 @@ func (s *preEvent2Service) GetPE2RSVPDetail(ctx context.Context, id string) (dto
 		return dto.PE2RSVPResponse{}, err
 	}
 
-	return dto.PE2RSVPResponse{
-		ID:                   attendee.ID,
-		Name:                 attendee.Name,
-		Email:                attendee.Email,
-		Institute:            attendee.Institute,
-		Department:           attendee.Department,
-		StudentID:            attendee.StudentID,
-		Batch:                attendee.Batch,
-		WillingToCome:        *attendee.WillingToCome,
-		WillingToBeContacted: *attendee.WillingToBeContacted,
-		Essay:                attendee.Essay,
-	}, nil
+	return toPE2RSVPResponse(attendee), nil
 }
 
 func (s *preEvent2Service) GetPE2RSVPCounter(ctx context.Context) (dto.PE2RSVPCounter, error) {
@@ -163,9 +179,15 @@ func (s *preEvent2Service) GetPE2RSVPCounter(ctx context.Context) (dto.PE2RSVPCo
 		return dto.PE2RSVPCounter{}, err
 	}
 
+	accepted, err := s.pe2RSVPRepo.CountByStatus(constants.ENUM_PE2_STATUS_ACCEPTED)
+	if err != nil {
+		return dto.PE2RSVPCounter{}, err
+	}
+
 	return dto.PE2RSVPCounter{
-		Total:   total,
-		Attends: attends,
+		Total:    total,
+		Attends:  attends,
+		Accepted: accepted,
 	}, nil
 }
 
@@ -175,10 +197,6 @@ func (s *preEvent2Service) GetPE2RSVPStatus(context.Context) (bool, error) {
 		return false, err
 	}
 
-	if event.Registers >= event.Capacity {
-		return false, nil
-	}
-
 	if time.Now().Before(event.StartDate.Add(-7 * time.Hour)) {
 		return false, nil
 	}
@@ -189,3 +207,245 @@ func (s *preEvent2Service) GetPE2RSVPStatus(context.Context) (bool, error) {
 
 	return true, nil
 }
+
+func (s *preEvent2Service) CheckInPE2RSVP(ctx context.Context, req dto.PE2RSVPCheckInRequest) error {
+	rsvp, err := s.pe2RSVPRepo.GetById(req.ID)
+	if err != nil {
+		return dto.ErrTicketNotFound
+	}
+
+	if rsvp.WillingToCome == nil || !*rsvp.WillingToCome {
+		return dto.ErrPE2RSVPNotWillingCome
+	}
+
+	if rsvp.Status != constants.ENUM_PE2_STATUS_ACCEPTED {
+		return dto.ErrPE2RSVPNotAccepted
+	}
+
+	attended := true
+	rsvp.Attended = &attended
+	if _, err := s.pe2RSVPRepo.Update(rsvp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *preEvent2Service) GetMyPE2RSVP(ctx context.Context, token string) (dto.PE2RSVPResponse, error) {
+	rsvp, err := s.pe2RSVPRepo.GetByManageTokenHash(utils.HashToken(token))
+	if err != nil || token == "" {
+		return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPLinkInvalid
+	}
+
+	return toPE2RSVPResponse(rsvp), nil
+}
+
+func (s *preEvent2Service) UpdateMyPE2RSVP(ctx context.Context, token string, req dto.PE2RSVPUpdateRequest) (dto.PE2RSVPResponse, error) {
+	rsvp, err := s.getManageablePE2RSVP(token)
+	if err != nil {
+		return dto.PE2RSVPResponse{}, err
+	}
+
+	req.StudentID = strings.TrimSpace(req.StudentID)
+	req.Batch = strings.TrimSpace(req.Batch)
+	if err := validateStudentProfile(req.StudentID, req.Batch); err != nil {
+		return dto.PE2RSVPResponse{}, err
+	}
+
+	// reviewers have already judged the essay, it has to stay as it was
+	if rsvp.Status != constants.ENUM_PE2_STATUS_PENDING && rsvp.Essay != req.Essay {
+		return dto.PE2RSVPResponse{}, dto.ErrPE2RSVPEssayLocked
+	}
+
+	rsvp.Name = req.Name
+	rsvp.Institute = req.Institute
+	rsvp.Department = req.Department
+	rsvp.StudentID = req.StudentID
+	rsvp.Batch = req.Batch
+	rsvp.WillingToCome = &req.WillingToCome
+	rsvp.WillingToBeContacted = &req.WillingToBeContacted
+	rsvp.Essay = req.Essay
+
+	rsvp, err = s.pe2RSVPRepo.UpdateWithRegisters(rsvp)
+	if err != nil {
+		return dto.PE2RSVPResponse{}, err
+	}
+
+	return toPE2RSVPResponse(rsvp), nil
+}
+
+func (s *preEvent2Service) WithdrawMyPE2RSVP(ctx context.Context, token string) (dto.PE2RSVPResponse, error) {
+	rsvp, err := s.getManageablePE2RSVP(token)
+	if err != nil {
+		return dto.PE2RSVPResponse{}, err
+	}
+
+	now := time.Now()
+	willingToCome := false
+	rsvp.WillingToCome = &willingToCome
+	rsvp.Status = constants.ENUM_PE2_STATUS_WITHDRAWN
+	rsvp.WithdrawnAt = &now
+
+	rsvp, err = s.pe2RSVPRepo.UpdateWithRegisters(rsvp)
+	if err != nil {
+		return dto.PE2RSVPResponse{}, err
+	}
+
+	return toPE2RSVPResponse(rsvp), nil
+}
+
+// nothing is reported back, neither the answer nor how long it took
+// may reveal whether the email has an RSVP
+func (s *preEvent2Service) ResendPE2RSVPLink(ctx context.Context, req dto.PE2RSVPResendLinkRequest) {
+	ctx, cancel := context.WithTimeout(utils.DetachContext(ctx), constants.BACKGROUND_TASK_TIMEOUT)
+
+	go func() {
+		defer cancel()
+
+		rsvp, err := s.pe2RSVPRepo.GetByEmail(req.Email)
+		if err != nil {
+			return
+		}
+
+		// the old link keeps working when there is no time left to send a new one
+		if err := ctx.Err(); err != nil {
+			log.Printf("error resending RSVP link: %v", err)
+			return
+		}
+
+		// a new token invalidates the link sent before
+		token, err := utils.GenRandomToken()
+		if err != nil {
+			log.Printf("error resending RSVP link: %v", err)
+			return
+		}
+
+		rsvp.ManageTokenHash = utils.HashToken(token)
+		if err := s.pe2RSVPRepo.SetManageTokenHash(rsvp.ID.String(), rsvp.ManageTokenHash); err != nil {
+			log.Printf("error resending RSVP link: %v", err)
+			return
+		}
+
+		emailData, err := generatePE2RSVPManageEmail(rsvp, token)
+		if err != nil {
+			log.Printf("error resending RSVP link: %v", err)
+			return
+		}
+
+		if err := utils.SendMail(emailData); err != nil {
+			log.Printf("error resending RSVP link: %v", err)
+		}
+	}()
+}
+
+func (s *preEvent2Service) getManageablePE2RSVP(token string) (entity.PE2RSVP, error) {
+	rsvp, err := s.pe2RSVPRepo.GetByManageTokenHash(utils.HashToken(token))
+	if err != nil || token == "" {
+		return entity.PE2RSVP{}, dto.ErrPE2RSVPLinkInvalid
+	}
+
+	if rsvp.Status == constants.ENUM_PE2_STATUS_WITHDRAWN {
+		return entity.PE2RSVP{}, dto.ErrPE2RSVPWithdrawn
+	}
+
+	if err := s.checkPE2RSVPOpen(); err != nil {
+		return entity.PE2RSVP{}, err
+	}
+
+	return rsvp, nil
+}
+
+func (s *preEvent2Service) checkPE2RSVPOpen() error {
+	event, err := s.eventRepo.GetPE2Detail()
+	if err != nil {
+		return err
+	}
+
+	if time.Now().Before(event.StartDate) {
+		return dto.ErrPE2RSVPNotOpen
+	}
+
+	if time.Now().After(event.EndDate) {
+		return dto.ErrPE2RSVPClosed
+	}
+
+	return nil
+}
+
+func generatePE2RSVPManageEmail(rsvp entity.PE2RSVP, token string) (utils.Email, error) {
+	readHtml, err := os.ReadFile("./utils/template/mail_pe2_rsvp_manage.html")
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	data := struct {
+		Name   string
+		Manage string
+	}{
+		Name:   rsvp.Name,
+		Manage: constants.BASE_URL + "/pre-event-2/rsvp?token=" + token,
+	}
+
+	tmpl, err := template.New("custom").Parse(string(readHtml))
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	var strMail bytes.Buffer
+	if err := tmpl.Execute(&strMail, data); err != nil {
+		return utils.Email{}, err
+	}
+
+	return utils.Email{
+		Email:   rsvp.Email,
+		Subject: "Your Pre-event 2 RSVP - TEDxITS",
+		Body:    strMail.String(),
+	}, nil
+}
+
+func toPE2RSVPResponse(rsvp entity.PE2RSVP) dto.PE2RSVPResponse {
+	return dto.PE2RSVPResponse{
+		ID:                   rsvp.ID,
+		Name:                 rsvp.Name,
+		Email:                rsvp.Email,
+		Institute:            rsvp.Institute,
+		Department:           rsvp.Department,
+		StudentID:            rsvp.StudentID,
+		Batch:                rsvp.Batch,
+		WillingToCome:        rsvp.WillingToCome != nil && *rsvp.WillingToCome,
+		WillingToBeContacted: rsvp.WillingToBeContacted != nil && *rsvp.WillingToBeContacted,
+		Attended:             rsvp.Attended != nil && *rsvp.Attended,
+		Essay:                rsvp.Essay,
+		Status:               rsvp.Status,
+		DecidedAt:            rsvp.DecidedAt,
+		DecisionNotifiedAt:   rsvp.DecisionNotifiedAt,
+		WithdrawnAt:          rsvp.WithdrawnAt,
+	}
+}
+
+func validatePE2RSVPRequest(req *dto.PE2RSVPRequest) error {
+	req.Name = strings.TrimSpace(req.Name)
+	req.Email = strings.TrimSpace(req.Email)
+	req.Institute = strings.TrimSpace(req.Institute)
+	req.Department = strings.TrimSpace(req.Department)
+	req.StudentID = strings.TrimSpace(req.StudentID)
+	req.Batch = strings.TrimSpace(req.Batch)
+
+	if req.Name == "" {
+		return dto.ErrNameRequired
+	}
+
+	if req.Email == "" {
+		return dto.ErrEmailRequired
+	}
+
+	if !utils.ValidateEmail(req.Email) {
+		return dto.ErrEmailFormatInvalid
+	}
+
+	if req.Institute == "" {
+		return dto.ErrInstituteRequired
+	}
+
+	return validateStudentProfile(req.StudentID, req.Batch)
+}
@@ -29,6 +29,8 @@ type (
 		userRepo   repository.UserRepository
 		ticketRepo repository.TicketRepository
 		bucketRepo repository.BucketRepository
+
+		profileSnapshot bool
 	}
 )
 
@@ -37,12 +39,14 @@ func NewPreEvent3Service(
 	tRepo repository.TicketRepository,
 	eRepo repository.EventRepository,
 	bRepo repository.BucketRepository,
+	profileSnapshot bool,
 ) PreEvent3Service {
 	return &preEvent3Service{
-		eventRepo:  eRepo,
-		userRepo:   uRepo,
-		ticketRepo: tRepo,
-		bucketRepo: bRepo,
+		eventRepo:       eRepo,
+		userRepo:        uRepo,
+		ticketRepo:      tRepo,
+		bucketRepo:      bRepo,
+		profileSnapshot: profileSnapshot,
 	}
 }
 
@@ -60,6 +64,16 @@ func (s *preEvent3Service) RegisterPE3(req dto.PE3RSVPRegister, userID string) e
 		return dto.ErrPreEvent3Closed
 	}
 
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	profile, err := resolveTicketProfile(user, req.Handphone, req.Birthdate)
+	if err != nil {
+		return err
+	}
+
 	// validating uploaded file
 	if req.PaymentFile.Size > dto.MB*5 {
 		return dto.ErrMaxFileSize5MB
@@ -110,22 +124,20 @@ func (s *preEvent3Service) RegisterPE3(req dto.PE3RSVPRegister, userID string) e
 		TicketID:         code,
 		UserID:           userID,
 		EventID:          constants.PreEvent3ID,
-		Handphone:        req.Handphone,
-		Birthdate:        req.Birthdate,
 		Payment:          getFileEndpoint,
 		PaymentConfirmed: &False,
 		CheckedIn:        &False,
 	}
+	applyTicketProfile(&ticket, profile, s.profileSnapshot)
 
 	if _, err := s.ticketRepo.CreateTicket(ticket); err != nil {
 		return err
 	}
 
+	// the ticket is already stored, a profile that failed to save is only missed next time
+	_ = rememberTicketProfile(s.userRepo, user, profile, s.profileSnapshot)
+
 	// send email
-	user, err := s.userRepo.GetUserById(userID)
-	if err != nil {
-		return err
-	}
 
 	go func() {
 		readHtml, err := os.ReadFile("./utils/template/mail_payment_received.html")
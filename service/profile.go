@@ -0,0 +1,182 @@
+package service
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+)
+
+// what a ticket registration needs from the person registering
+type ticketProfile struct {
+	Handphone string
+	Birthdate time.Time
+}
+
+// values sent with the registration win, anything left out comes from the profile
+func resolveTicketProfile(user entity.User, handphone string, birthdate time.Time) (ticketProfile, error) {
+	profile := ticketProfile{
+		Handphone: utils.NormalizeHandphone(handphone),
+		Birthdate: birthdate,
+	}
+
+	if profile.Handphone == "" {
+		profile.Handphone = user.Handphone
+	}
+
+	if profile.Birthdate.IsZero() && user.Birthdate != nil {
+		profile.Birthdate = *user.Birthdate
+	}
+
+	if profile.Handphone == "" {
+		return ticketProfile{}, dto.ErrHandphoneRequired
+	}
+
+	if profile.Birthdate.IsZero() {
+		return ticketProfile{}, dto.ErrBirthdateRequired
+	}
+
+	if err := validateHandphone(profile.Handphone); err != nil {
+		return ticketProfile{}, err
+	}
+
+	if err := validateBirthdate(profile.Birthdate); err != nil {
+		return ticketProfile{}, err
+	}
+
+	return profile, nil
+}
+
+// the ticket only keeps its own copy while snapshots are on, otherwise it follows the profile
+func applyTicketProfile(ticket *entity.Ticket, profile ticketProfile, snapshot bool) {
+	if !snapshot {
+		return
+	}
+
+	ticket.Handphone = profile.Handphone
+	ticket.Birthdate = profile.Birthdate
+}
+
+// empty profile fields are filled in so the next registration is prefilled. Without
+// snapshots the ticket reads the profile, so it has to hold what was just registered.
+func rememberTicketProfile(userRepo repository.UserRepository, user entity.User, profile ticketProfile, snapshot bool) error {
+	overwrite := !snapshot
+	changed := false
+
+	if user.Handphone == "" || overwrite && user.Handphone != profile.Handphone {
+		user.Handphone = profile.Handphone
+		changed = true
+	}
+
+	if user.Birthdate == nil || overwrite && !user.Birthdate.Equal(profile.Birthdate) {
+		birthdate := profile.Birthdate
+		user.Birthdate = &birthdate
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return userRepo.UpdateProfile(user)
+}
+
+// an RSVP is not tied to an account, signing in only saves typing
+func prefillPE2RSVP(user entity.User, req *dto.PE2RSVPRequest) {
+	fill := func(field *string, value string) {
+		if strings.TrimSpace(*field) == "" {
+			*field = value
+		}
+	}
+
+	fill(&req.Name, user.Name)
+	fill(&req.Email, user.Email)
+	fill(&req.Institute, user.Institute)
+	fill(&req.Department, user.Department)
+	fill(&req.StudentID, user.StudentID)
+	fill(&req.Batch, user.Batch)
+}
+
+// fields the RSVP brought that the profile is missing are kept for next time
+func rememberPE2Profile(userRepo repository.UserRepository, user entity.User, rsvp entity.PE2RSVP) error {
+	changed := false
+	fill := func(field *string, value string) {
+		if *field == "" && value != "" {
+			*field = value
+			changed = true
+		}
+	}
+
+	fill(&user.Institute, rsvp.Institute)
+	fill(&user.Department, rsvp.Department)
+	fill(&user.StudentID, rsvp.StudentID)
+	fill(&user.Batch, rsvp.Batch)
+
+	if !changed {
+		return nil
+	}
+
+	return userRepo.UpdateProfile(user)
+}
+
+func toUserProfile(user entity.User) *dto.UserProfile {
+	return &dto.UserProfile{
+		Handphone:  user.Handphone,
+		Birthdate:  user.Birthdate,
+		Institute:  user.Institute,
+		Department: user.Department,
+		StudentID:  user.StudentID,
+		Batch:      user.Batch,
+	}
+}
+
+// only fields that are set are checked, the profile may stay partly empty
+func validateProfile(user entity.User) error {
+	if user.Handphone != "" {
+		if err := validateHandphone(user.Handphone); err != nil {
+			return err
+		}
+	}
+
+	if user.Birthdate != nil {
+		if err := validateBirthdate(*user.Birthdate); err != nil {
+			return err
+		}
+	}
+
+	return validateStudentProfile(user.StudentID, user.Batch)
+}
+
+func validateStudentProfile(studentID string, batch string) error {
+	if studentID != "" && (len(studentID) > constants.PROFILE_STUDENT_ID_MAX || !utils.ValidateAlphanumeric(studentID)) {
+		return dto.ErrStudentIDInvalid
+	}
+
+	if batch != "" {
+		year, err := strconv.Atoi(batch)
+		if err != nil || len(batch) != 4 || year < constants.PROFILE_MIN_BATCH_YEAR || year > time.Now().Year()+1 {
+			return dto.ErrBatchInvalid
+		}
+	}
+
+	return nil
+}
+
+func validateHandphone(handphone string) error {
+	if !utils.ValidateHandphone(handphone, constants.PROFILE_HANDPHONE_MIN_DIGITS, constants.PROFILE_HANDPHONE_MAX_DIGITS) {
+		return dto.ErrHandphoneInvalid
+	}
+	return nil
+}
+
+func validateBirthdate(birthdate time.Time) error {
+	if birthdate.Year() < constants.PROFILE_MIN_BIRTH_YEAR || birthdate.After(time.Now()) {
+		return dto.ErrBirthdateInvalid
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/google/uuid"
+)
+
+func TestResolveTicketProfile(t *testing.T) {
+	saved := time.Date(2000, time.May, 1, 0, 0, 0, 0, time.UTC)
+	sent := time.Date(2001, time.June, 2, 0, 0, 0, 0, time.UTC)
+	withProfile := entity.User{Handphone: "081200000000", Birthdate: &saved}
+
+	tests := []struct {
+		name      string
+		user      entity.User
+		handphone string
+		birthdate time.Time
+		want      ticketProfile
+		err       error
+	}{
+		{"sent values win", withProfile, "0813-1111 1111", sent, ticketProfile{"081311111111", sent}, nil},
+		{"falls back to the profile", withProfile, "", time.Time{}, ticketProfile{"081200000000", saved}, nil},
+		{"missing handphone", entity.User{Birthdate: &saved}, "", time.Time{}, ticketProfile{}, dto.ErrHandphoneRequired},
+		{"missing birthdate", entity.User{}, "081311111111", time.Time{}, ticketProfile{}, dto.ErrBirthdateRequired},
+		{"invalid handphone", withProfile, "call me", sent, ticketProfile{}, dto.ErrHandphoneInvalid},
+		{"birthdate in the future", withProfile, "", time.Now().AddDate(1, 0, 0), ticketProfile{}, dto.ErrBirthdateInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveTicketProfile(tt.user, tt.handphone, tt.birthdate)
+			if err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got.Handphone != tt.want.Handphone || !got.Birthdate.Equal(tt.want.Birthdate) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyTicketProfile(t *testing.T) {
+	profile := ticketProfile{Handphone: "081311111111", Birthdate: time.Date(2000, time.May, 1, 0, 0, 0, 0, time.UTC)}
+
+	var snapshot entity.Ticket
+	applyTicketProfile(&snapshot, profile, true)
+	if snapshot.Handphone != profile.Handphone || !snapshot.Birthdate.Equal(profile.Birthdate) {
+		t.Fatalf("snapshot ticket %+v does not hold the profile", snapshot)
+	}
+
+	// without snapshots the ticket reads the profile, so it keeps no copy
+	var live entity.Ticket
+	applyTicketProfile(&live, profile, false)
+	if live.Handphone != "" || !live.Birthdate.IsZero() {
+		t.Fatalf("live ticket %+v holds a copy", live)
+	}
+}
+
+func TestRememberTicketProfile(t *testing.T) {
+	saved := time.Date(2000, time.May, 1, 0, 0, 0, 0, time.UTC)
+	profile := ticketProfile{Handphone: "081311111111", Birthdate: time.Date(2001, time.June, 2, 0, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name      string
+		user      entity.User
+		snapshot  bool
+		handphone string
+		birthdate time.Time
+	}{
+		{"empty profile is filled", entity.User{}, true, profile.Handphone, profile.Birthdate},
+		{"snapshot keeps the profile", entity.User{Handphone: "081200000000", Birthdate: &saved}, true, "081200000000", saved},
+		{"live tickets overwrite the profile", entity.User{Handphone: "081200000000", Birthdate: &saved}, false, profile.Handphone, profile.Birthdate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.user.ID = uuid.New()
+			userRepo := stubUserRepository{users: map[string]entity.User{tt.user.ID.String(): tt.user}}
+
+			if err := rememberTicketProfile(userRepo, tt.user, profile, tt.snapshot); err != nil {
+				t.Fatal(err)
+			}
+
+			stored := userRepo.users[tt.user.ID.String()]
+			if stored.Handphone != tt.handphone || stored.Birthdate == nil || !stored.Birthdate.Equal(tt.birthdate) {
+				t.Fatalf("stored %s %v, want %s %s", stored.Handphone, stored.Birthdate, tt.handphone, tt.birthdate)
+			}
+		})
+	}
+}
+
+func TestRememberCompHandphone(t *testing.T) {
+	for _, snapshot := range []bool{true, false} {
+		user := entity.User{ID: uuid.New(), Handphone: "081200000000"}
+		userRepo := stubUserRepository{users: map[string]entity.User{user.ID.String(): user}}
+
+		if err := rememberCompHandphone(userRepo, user, "081311111111", snapshot); err != nil {
+			t.Fatal(err)
+		}
+
+		want := "081200000000"
+		if !snapshot {
+			want = "081311111111"
+		}
+		if stored := userRepo.users[user.ID.String()]; stored.Handphone != want {
+			t.Fatalf("snapshot %v: stored handphone %q, want %q", snapshot, stored.Handphone, want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+)
+
+type (
+	RoleService interface {
+		GetAllRole(ctx context.Context) ([]dto.RoleResponse, error)
+		GetRoleDetail(ctx context.Context, id string) (dto.RoleResponse, error)
+		CreateRole(ctx context.Context, req dto.RoleRequest) (dto.RoleResponse, error)
+		UpdateRole(ctx context.Context, id string, req dto.RoleRequest) (dto.RoleResponse, error)
+		DeleteRole(ctx context.Context, id string) error
+		GetPermissions(ctx context.Context) []string
+	}
+
+	roleService struct {
+		roleRepo repository.RoleRepository
+	}
+)
+
+func NewRoleService(rRepo repository.RoleRepository) RoleService {
+	return &roleService{
+		roleRepo: rRepo,
+	}
+}
+
+func (s *roleService) GetAllRole(ctx context.Context) ([]dto.RoleResponse, error) {
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.RoleResponse{}
+	for _, role := range roles {
+		result = append(result, toRoleResponse(role))
+	}
+
+	return result, nil
+}
+
+func (s *roleService) GetRoleDetail(ctx context.Context, id string) (dto.RoleResponse, error) {
+	role, err := s.roleRepo.GetByIDWithPermissions(id)
+	if err != nil {
+		return dto.RoleResponse{}, dto.ErrRoleNotFound
+	}
+
+	return toRoleResponse(role), nil
+}
+
+func (s *roleService) CreateRole(ctx context.Context, req dto.RoleRequest) (dto.RoleResponse, error) {
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if exist, err := s.roleRepo.CheckNameExist(name); err != nil || exist {
+		return dto.RoleResponse{}, dto.ErrRoleNameTaken
+	}
+
+	permissions, err := toRolePermissions(req.Permissions)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	role, err := s.roleRepo.Create(entity.Role{
+		Name:        name,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	return toRoleResponse(role), nil
+}
+
+func (s *roleService) UpdateRole(ctx context.Context, id string, req dto.RoleRequest) (dto.RoleResponse, error) {
+	role, err := s.roleRepo.GetByIDWithPermissions(id)
+	if err != nil {
+		return dto.RoleResponse{}, dto.ErrRoleNotFound
+	}
+
+	if isBuiltInRole(role.Name) {
+		return dto.RoleResponse{}, dto.ErrRoleBuiltIn
+	}
+
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if name != role.Name {
+		if exist, err := s.roleRepo.CheckNameExist(name); err != nil || exist {
+			return dto.RoleResponse{}, dto.ErrRoleNameTaken
+		}
+	}
+
+	permissions, err := toRolePermissions(req.Permissions)
+	if err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	for i := range permissions {
+		permissions[i].RoleID = role.ID
+	}
+
+	role.Name = name
+	role.Permissions = permissions
+	if _, err := s.roleRepo.Update(role); err != nil {
+		return dto.RoleResponse{}, err
+	}
+
+	return toRoleResponse(role), nil
+}
+
+func (s *roleService) DeleteRole(ctx context.Context, id string) error {
+	role, err := s.roleRepo.GetRolebyId(id)
+	if err != nil {
+		return dto.ErrRoleNotFound
+	}
+
+	if isBuiltInRole(role.Name) {
+		return dto.ErrRoleBuiltIn
+	}
+
+	count, err := s.roleRepo.CountUsers(id)
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		return dto.ErrRoleInUse
+	}
+
+	return s.roleRepo.Delete(id)
+}
+
+func (s *roleService) GetPermissions(ctx context.Context) []string {
+	return constants.PERMISSIONS
+}
+
+// admin holds every permission and user holds none,
+// both are referenced by name across the codebase
+func isBuiltInRole(name string) bool {
+	return name == constants.ENUM_ROLE_ADMIN || name == constants.ENUM_ROLE_USER
+}
+
+func toRolePermissions(permissions []string) ([]entity.RolePermission, error) {
+	seen := make(map[string]bool)
+	var result []entity.RolePermission
+	for _, permission := range permissions {
+		if !isPermission(permission) {
+			return nil, fmt.Errorf(dto.ErrPermissionInvalid.Error(), permission)
+		}
+
+		if seen[permission] {
+			continue
+		}
+		seen[permission] = true
+
+		result = append(result, entity.RolePermission{
+			Permission: permission,
+		})
+	}
+
+	if seen[constants.PERMISSION_PE2_REVIEW] {
+		for _, excluded := range constants.PE2_REVIEW_EXCLUDED_PERMISSIONS {
+			if seen[excluded] {
+				return nil, fmt.Errorf(dto.ErrPermissionConflict.Error(), constants.PERMISSION_PE2_REVIEW, excluded)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func isPermission(permission string) bool {
+	for _, p := range constants.PERMISSIONS {
+		if p == permission {
+			return true
+		}
+	}
+
+	return false
+}
+
+func toRoleResponse(role entity.Role) dto.RoleResponse {
+	res := dto.RoleResponse{
+		ID:          role.ID.String(),
+		Name:        role.Name,
+		Permissions: []string{},
+		BuiltIn:     isBuiltInRole(role.Name),
+	}
+
+	if role.Name == constants.ENUM_ROLE_ADMIN {
+		res.Permissions = constants.PERMISSIONS
+		return res
+	}
+
+	for _, permission := range role.Permissions {
+		res.Permissions = append(res.Permissions, permission.Permission)
+	}
+
+	return res
+}
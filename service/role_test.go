@@ -0,0 +1,28 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+)
+
+func TestToRolePermissionsKeepsReviewersBlind(t *testing.T) {
+	if _, err := toRolePermissions([]string{constants.PERMISSION_PE2_REVIEW}); err != nil {
+		t.Fatalf("reviewer role rejected: %v", err)
+	}
+
+	if _, err := toRolePermissions([]string{constants.PERMISSION_PE2_REVIEW, constants.PERMISSION_TICKET_READ}); err == nil {
+		t.Fatal("reviewer role was allowed to read RSVPs")
+	}
+}
+
+func TestToRolePermissionsRejectsUnknown(t *testing.T) {
+	if _, err := toRolePermissions([]string{"ticket.delete"}); err == nil {
+		t.Fatal("unknown permission accepted")
+	}
+
+	permissions, err := toRolePermissions([]string{constants.PERMISSION_TICKET_READ, constants.PERMISSION_TICKET_READ})
+	if err != nil || len(permissions) != 1 {
+		t.Fatalf("got %v %v, want one deduplicated permission", permissions, err)
+	}
+}
@@ -0,0 +1,311 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+)
+
+type (
+	ScheduleService interface {
+		CreateSession(ctx context.Context, req dto.SessionRequest) (dto.SessionResponse, error)
+		UpdateSession(ctx context.Context, id string, req dto.SessionRequest) (dto.SessionResponse, error)
+		DeleteSession(ctx context.Context, id string) error
+		GetSchedule(ctx context.Context, eventID string) ([]dto.SessionResponse, error)
+		GetEventCalendar(ctx context.Context, eventID string) ([]byte, error)
+		CreateCalendarFeed(ctx context.Context, userID string) (dto.CalendarFeedResponse, error)
+		GetPersonalCalendar(ctx context.Context, token string) ([]byte, error)
+	}
+
+	scheduleService struct {
+		sessionRepo repository.SessionRepository
+		eventRepo   repository.EventRepository
+		speakerRepo repository.SpeakerRepository
+		ticketRepo  repository.TicketRepository
+		pe2RSVPRepo repository.PE2RSVPRepository
+		userRepo    repository.UserRepository
+	}
+)
+
+func NewScheduleService(
+	sRepo repository.SessionRepository,
+	eRepo repository.EventRepository,
+	spRepo repository.SpeakerRepository,
+	tRepo repository.TicketRepository,
+	pRepo repository.PE2RSVPRepository,
+	uRepo repository.UserRepository,
+) ScheduleService {
+	return &scheduleService{
+		sessionRepo: sRepo,
+		eventRepo:   eRepo,
+		speakerRepo: spRepo,
+		ticketRepo:  tRepo,
+		pe2RSVPRepo: pRepo,
+		userRepo:    uRepo,
+	}
+}
+
+func (s *scheduleService) CreateSession(ctx context.Context, req dto.SessionRequest) (dto.SessionResponse, error) {
+	var session entity.Session
+	if err := s.applySessionRequest(&session, req); err != nil {
+		return dto.SessionResponse{}, err
+	}
+
+	session, err := s.sessionRepo.Create(session)
+	if err != nil {
+		return dto.SessionResponse{}, err
+	}
+
+	session, err = s.sessionRepo.GetByID(session.ID.String())
+	if err != nil {
+		return dto.SessionResponse{}, err
+	}
+
+	return toSessionResponse(session, false), nil
+}
+
+func (s *scheduleService) UpdateSession(ctx context.Context, id string, req dto.SessionRequest) (dto.SessionResponse, error) {
+	session, err := s.sessionRepo.GetByID(id)
+	if err != nil {
+		return dto.SessionResponse{}, dto.ErrSessionNotFound
+	}
+
+	if err := s.applySessionRequest(&session, req); err != nil {
+		return dto.SessionResponse{}, err
+	}
+
+	if _, err := s.sessionRepo.Update(session); err != nil {
+		return dto.SessionResponse{}, err
+	}
+
+	session, err = s.sessionRepo.GetByID(id)
+	if err != nil {
+		return dto.SessionResponse{}, err
+	}
+
+	return toSessionResponse(session, false), nil
+}
+
+func (s *scheduleService) DeleteSession(ctx context.Context, id string) error {
+	if _, err := s.sessionRepo.GetByID(id); err != nil {
+		return dto.ErrSessionNotFound
+	}
+
+	return s.sessionRepo.Delete(id)
+}
+
+func (s *scheduleService) GetSchedule(ctx context.Context, eventID string) ([]dto.SessionResponse, error) {
+	if _, err := s.eventRepo.GetByID(eventID); err != nil {
+		return nil, dto.ErrEventNotFound
+	}
+
+	sessions, err := s.sessionRepo.GetByEventIDs(expandMainEventIDs(eventID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.SessionResponse{}
+	for _, session := range sessions {
+		result = append(result, toSessionResponse(session, true))
+	}
+
+	return result, nil
+}
+
+func (s *scheduleService) GetEventCalendar(ctx context.Context, eventID string) ([]byte, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, dto.ErrEventNotFound
+	}
+
+	sessions, err := s.sessionRepo.GetByEventIDs(expandMainEventIDs(eventID))
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.BuildICS("TEDxITS - "+certificateEventName(event), toICSEvents(sessions)), nil
+}
+
+func (s *scheduleService) CreateCalendarFeed(ctx context.Context, userID string) (dto.CalendarFeedResponse, error) {
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return dto.CalendarFeedResponse{}, dto.ErrUserNotFound
+	}
+
+	// a new link replaces the previous one, in case it was shared by accident
+	token, err := utils.GenRandomToken()
+	if err != nil {
+		return dto.CalendarFeedResponse{}, err
+	}
+
+	user.CalendarTokenHash = utils.HashToken(token)
+	if _, err := s.userRepo.UpdateUser(user); err != nil {
+		return dto.CalendarFeedResponse{}, err
+	}
+
+	return dto.CalendarFeedResponse{
+		URL: constants.BASE_URL + "/api/schedule/feed/" + token + ".ics",
+	}, nil
+}
+
+func (s *scheduleService) GetPersonalCalendar(ctx context.Context, token string) ([]byte, error) {
+	token = strings.TrimSuffix(token, ".ics")
+	if token == "" {
+		return nil, dto.ErrCalendarFeedInvalid
+	}
+
+	user, err := s.userRepo.GetUserByCalendarTokenHash(utils.HashToken(token))
+	if err != nil {
+		return nil, dto.ErrCalendarFeedInvalid
+	}
+
+	tickets, err := s.ticketRepo.FindConfirmedByUserID(user.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var eventIDs []string
+	addEvent := func(eventID string) {
+		for _, id := range expandMainEventIDs(eventID) {
+			if !seen[id] {
+				seen[id] = true
+				eventIDs = append(eventIDs, id)
+			}
+		}
+	}
+
+	for _, ticket := range tickets {
+		addEvent(ticket.EventID)
+	}
+
+	// pre-event 2 attendees RSVP without an account, match them by email
+	if rsvp, err := s.pe2RSVPRepo.GetByEmail(user.Email); err == nil &&
+		rsvp.Status == constants.ENUM_PE2_STATUS_ACCEPTED && rsvp.WillingToCome != nil && *rsvp.WillingToCome {
+		addEvent(constants.PreEvent2ID)
+	}
+
+	var sessions []entity.Session
+	if len(eventIDs) > 0 {
+		sessions, err = s.sessionRepo.GetByEventIDs(eventIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return utils.BuildICS("My TEDxITS Schedule", toICSEvents(sessions)), nil
+}
+
+func (s *scheduleService) applySessionRequest(session *entity.Session, req dto.SessionRequest) error {
+	if !isSessionType(req.Type) {
+		return dto.ErrSessionTypeInvalid
+	}
+
+	if !req.EndTime.After(req.StartTime) {
+		return dto.ErrSessionTimeInvalid
+	}
+
+	event, err := s.eventRepo.GetByID(req.EventID)
+	if err != nil {
+		return dto.ErrEventNotFound
+	}
+
+	session.SpeakerID = nil
+	if req.SpeakerID != "" {
+		speaker, err := s.speakerRepo.GetByID(req.SpeakerID)
+		if err != nil {
+			return dto.ErrSpeakerNotFound
+		}
+		session.SpeakerID = &speaker.ID
+	}
+
+	session.EventID = event.ID
+	session.Title = req.Title
+	session.Description = req.Description
+	session.Type = req.Type
+	session.Stage = req.Stage
+	session.StartTime = req.StartTime
+	session.EndTime = req.EndTime
+
+	return nil
+}
+
+// any main event tier stands for the whole main event
+func expandMainEventIDs(eventID string) []string {
+	for _, id := range constants.MainEventIDs {
+		if id == eventID {
+			return constants.MainEventIDs
+		}
+	}
+
+	return []string{eventID}
+}
+
+func isSessionType(sessionType string) bool {
+	switch sessionType {
+	case constants.ENUM_SESSION_TALK,
+		constants.ENUM_SESSION_PERFORMANCE,
+		constants.ENUM_SESSION_CEREMONY,
+		constants.ENUM_SESSION_BREAK,
+		constants.ENUM_SESSION_NETWORKING:
+		return true
+	}
+
+	return false
+}
+
+// public views leave out speakers whose lineup reveal is still scheduled
+func toSessionResponse(session entity.Session, public bool) dto.SessionResponse {
+	res := dto.SessionResponse{
+		ID:          session.ID.String(),
+		EventID:     session.EventID.String(),
+		Title:       session.Title,
+		Description: session.Description,
+		Type:        session.Type,
+		Stage:       session.Stage,
+		StartTime:   session.StartTime,
+		EndTime:     session.EndTime,
+	}
+
+	if session.Event != nil {
+		res.EventName = certificateEventName(*session.Event)
+	}
+
+	if session.Speaker != nil && (!public || isSpeakerPublished(*session.Speaker)) {
+		res.Speaker = &dto.SessionSpeaker{
+			ID:       session.Speaker.ID.String(),
+			Name:     session.Speaker.Name,
+			Headline: session.Speaker.Headline,
+			Photo:    session.Speaker.Photo,
+		}
+	}
+
+	return res
+}
+
+func toICSEvents(sessions []entity.Session) []utils.ICSEvent {
+	var events []utils.ICSEvent
+	for _, session := range sessions {
+		summary := session.Title
+		if session.Speaker != nil && isSpeakerPublished(*session.Speaker) {
+			summary += " - " + session.Speaker.Name
+		}
+
+		events = append(events, utils.ICSEvent{
+			UID:         session.ID.String() + "@tedxits",
+			Summary:     summary,
+			Description: session.Description,
+			Location:    session.Stage,
+			Start:       session.StartTime,
+			End:         session.EndTime,
+			Updated:     session.UpdatedAt,
+		})
+	}
+
+	return events
+}
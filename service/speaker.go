@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"mime/multipart"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/google/uuid"
+)
+
+type (
+	SpeakerService interface {
+		CreateSpeaker(ctx context.Context, req dto.SpeakerRequest) (dto.SpeakerResponse, error)
+		UpdateSpeaker(ctx context.Context, id string, req dto.SpeakerRequest) (dto.SpeakerResponse, error)
+		DeleteSpeaker(ctx context.Context, id string) error
+		GetAllSpeaker(ctx context.Context) ([]dto.SpeakerResponse, error)
+		GetSpeakerDetail(ctx context.Context, id string) (dto.SpeakerResponse, error)
+		GetPublishedSpeakers(ctx context.Context) ([]dto.SpeakerResponse, error)
+		GetPublishedSpeakerDetail(ctx context.Context, id string) (dto.SpeakerResponse, error)
+		CreateTalk(ctx context.Context, req dto.TalkRequest) (dto.TalkResponse, error)
+		UpdateTalk(ctx context.Context, id string, req dto.TalkRequest) (dto.TalkResponse, error)
+		DeleteTalk(ctx context.Context, id string) error
+	}
+
+	speakerService struct {
+		speakerRepo repository.SpeakerRepository
+		bucketRepo  repository.BucketRepository
+	}
+)
+
+func NewSpeakerService(sRepo repository.SpeakerRepository, bRepo repository.BucketRepository) SpeakerService {
+	return &speakerService{
+		speakerRepo: sRepo,
+		bucketRepo:  bRepo,
+	}
+}
+
+func (s *speakerService) CreateSpeaker(ctx context.Context, req dto.SpeakerRequest) (dto.SpeakerResponse, error) {
+	speaker := entity.Speaker{
+		ID: uuid.New(),
+	}
+	applySpeakerRequest(&speaker, req)
+
+	if req.Photo != nil {
+		photo, err := s.uploadPhoto(speaker.ID, req.Photo)
+		if err != nil {
+			return dto.SpeakerResponse{}, err
+		}
+		speaker.Photo = photo
+	}
+
+	created, err := s.speakerRepo.Create(speaker)
+	if err != nil {
+		s.deletePhoto(speaker.Photo)
+		return dto.SpeakerResponse{}, err
+	}
+
+	return toSpeakerResponse(created), nil
+}
+
+func (s *speakerService) UpdateSpeaker(ctx context.Context, id string, req dto.SpeakerRequest) (dto.SpeakerResponse, error) {
+	speaker, err := s.speakerRepo.GetByID(id)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+	}
+	applySpeakerRequest(&speaker, req)
+
+	// keep the current photo unless a new one is uploaded
+	previous := speaker.Photo
+	if req.Photo != nil {
+		photo, err := s.uploadPhoto(speaker.ID, req.Photo)
+		if err != nil {
+			return dto.SpeakerResponse{}, err
+		}
+		speaker.Photo = photo
+	}
+
+	updated, err := s.speakerRepo.Update(speaker)
+	if err != nil {
+		if speaker.Photo != previous {
+			s.deletePhoto(speaker.Photo)
+		}
+		return dto.SpeakerResponse{}, err
+	}
+	speaker = updated
+
+	if speaker.Photo != previous {
+		s.deletePhoto(previous)
+	}
+
+	return toSpeakerResponse(speaker), nil
+}
+
+func (s *speakerService) DeleteSpeaker(ctx context.Context, id string) error {
+	if _, err := s.speakerRepo.GetByID(id); err != nil {
+		return dto.ErrSpeakerNotFound
+	}
+
+	return s.speakerRepo.Delete(id)
+}
+
+func (s *speakerService) GetAllSpeaker(ctx context.Context) ([]dto.SpeakerResponse, error) {
+	speakers, err := s.speakerRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.SpeakerResponse{}
+	for _, speaker := range speakers {
+		result = append(result, toSpeakerResponse(speaker))
+	}
+
+	return result, nil
+}
+
+func (s *speakerService) GetSpeakerDetail(ctx context.Context, id string) (dto.SpeakerResponse, error) {
+	speaker, err := s.speakerRepo.GetByID(id)
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+	}
+
+	return toSpeakerResponse(speaker), nil
+}
+
+func (s *speakerService) GetPublishedSpeakers(ctx context.Context) ([]dto.SpeakerResponse, error) {
+	speakers, err := s.speakerRepo.GetAllPublished(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.SpeakerResponse{}
+	for _, speaker := range speakers {
+		res := toSpeakerResponse(speaker)
+		res.PublishAt = nil
+		result = append(result, res)
+	}
+
+	return result, nil
+}
+
+func (s *speakerService) GetPublishedSpeakerDetail(ctx context.Context, id string) (dto.SpeakerResponse, error) {
+	speaker, err := s.speakerRepo.GetPublishedByID(id, time.Now())
+	if err != nil {
+		return dto.SpeakerResponse{}, dto.ErrSpeakerNotFound
+	}
+
+	res := toSpeakerResponse(speaker)
+	res.PublishAt = nil
+
+	return res, nil
+}
+
+func (s *speakerService) CreateTalk(ctx context.Context, req dto.TalkRequest) (dto.TalkResponse, error) {
+	speaker, err := s.speakerRepo.GetByID(req.SpeakerID)
+	if err != nil {
+		return dto.TalkResponse{}, dto.ErrSpeakerNotFound
+	}
+
+	talk, err := s.speakerRepo.CreateTalk(entity.Talk{
+		SpeakerID: speaker.ID,
+		Title:     req.Title,
+		Abstract:  req.Abstract,
+	})
+	if err != nil {
+		return dto.TalkResponse{}, err
+	}
+
+	return toTalkResponse(talk), nil
+}
+
+func (s *speakerService) UpdateTalk(ctx context.Context, id string, req dto.TalkRequest) (dto.TalkResponse, error) {
+	talk, err := s.speakerRepo.GetTalkByID(id)
+	if err != nil {
+		return dto.TalkResponse{}, dto.ErrTalkNotFound
+	}
+
+	speaker, err := s.speakerRepo.GetByID(req.SpeakerID)
+	if err != nil {
+		return dto.TalkResponse{}, dto.ErrSpeakerNotFound
+	}
+
+	talk.SpeakerID = speaker.ID
+	talk.Title = req.Title
+	talk.Abstract = req.Abstract
+
+	talk, err = s.speakerRepo.UpdateTalk(talk)
+	if err != nil {
+		return dto.TalkResponse{}, err
+	}
+
+	return toTalkResponse(talk), nil
+}
+
+func (s *speakerService) DeleteTalk(ctx context.Context, id string) error {
+	if _, err := s.speakerRepo.GetTalkByID(id); err != nil {
+		return dto.ErrTalkNotFound
+	}
+
+	return s.speakerRepo.DeleteTalk(id)
+}
+
+func (s *speakerService) uploadPhoto(id uuid.UUID, photo *multipart.FileHeader) (string, error) {
+	return uploadImage(s.bucketRepo, dto.ENUM_STORAGE_FOLDER_SPEAKER, dto.STORAGE_ENDPOINT_SPEAKER, id, photo, dto.ErrFailedToStoreSpeakerPhoto)
+}
+
+func (s *speakerService) deletePhoto(photo string) {
+	deleteImage(s.bucketRepo, dto.ENUM_STORAGE_FOLDER_SPEAKER, dto.STORAGE_ENDPOINT_SPEAKER, photo)
+}
+
+func applySpeakerRequest(speaker *entity.Speaker, req dto.SpeakerRequest) {
+	speaker.Name = req.Name
+	speaker.Headline = req.Headline
+	speaker.Bio = req.Bio
+	speaker.Position = req.Position
+	speaker.Instagram = req.Instagram
+	speaker.LinkedIn = req.LinkedIn
+	speaker.Twitter = req.Twitter
+	speaker.Website = req.Website
+	speaker.PublishAt = req.PublishAt
+}
+
+func toSpeakerResponse(speaker entity.Speaker) dto.SpeakerResponse {
+	res := dto.SpeakerResponse{
+		ID:       speaker.ID.String(),
+		Name:     speaker.Name,
+		Headline: speaker.Headline,
+		Bio:      speaker.Bio,
+		Photo:    speaker.Photo,
+		Position: speaker.Position,
+		Socials: dto.SpeakerSocials{
+			Instagram: speaker.Instagram,
+			LinkedIn:  speaker.LinkedIn,
+			Twitter:   speaker.Twitter,
+			Website:   speaker.Website,
+		},
+		PublishAt: speaker.PublishAt,
+		Published: isSpeakerPublished(speaker),
+		Talks:     []dto.TalkResponse{},
+	}
+
+	for _, talk := range speaker.Talks {
+		res.Talks = append(res.Talks, toTalkResponse(talk))
+	}
+
+	return res
+}
+
+func isSpeakerPublished(speaker entity.Speaker) bool {
+	return speaker.PublishAt != nil && !speaker.PublishAt.After(time.Now())
+}
+
+func toTalkResponse(talk entity.Talk) dto.TalkResponse {
+	return dto.TalkResponse{
+		ID:        talk.ID.String(),
+		SpeakerID: talk.SpeakerID.String(),
+		Title:     talk.Title,
+		Abstract:  talk.Abstract,
+	}
+}
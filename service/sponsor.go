@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"regexp"
+	"strings"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/google/uuid"
+)
+
+const SPONSOR_ALIAS_ATTEMPTS = 3
+
+var sponsorSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+type (
+	SponsorService interface {
+		CreateSponsor(ctx context.Context, req dto.SponsorRequest) (dto.SponsorResponse, error)
+		UpdateSponsor(ctx context.Context, id string, req dto.SponsorRequest) (dto.SponsorResponse, error)
+		DeleteSponsor(ctx context.Context, id string) error
+		GetAllSponsor(ctx context.Context, req dto.SponsorQuery) ([]dto.SponsorResponse, error)
+		GetSponsorDetail(ctx context.Context, id string) (dto.SponsorResponse, error)
+		GetPublicSponsors(ctx context.Context, req dto.SponsorQuery) ([]dto.SponsorTierResponse, error)
+		GetPublicSponsorDetail(ctx context.Context, id string) (dto.SponsorResponse, error)
+		CreatePlacement(ctx context.Context, id string, req dto.SponsorPlacementRequest) (dto.SponsorPlacementResponse, error)
+		GetSponsorReport(ctx context.Context, id string) (dto.SponsorReportResponse, error)
+		GetAllSponsorReport(ctx context.Context) ([]dto.SponsorReportResponse, error)
+	}
+
+	sponsorService struct {
+		sponsorRepo repository.SponsorRepository
+		bucketRepo  repository.BucketRepository
+	}
+)
+
+func NewSponsorService(sRepo repository.SponsorRepository, bRepo repository.BucketRepository) SponsorService {
+	return &sponsorService{
+		sponsorRepo: sRepo,
+		bucketRepo:  bRepo,
+	}
+}
+
+func (s *sponsorService) CreateSponsor(ctx context.Context, req dto.SponsorRequest) (dto.SponsorResponse, error) {
+	if err := validateSponsorRequest(req); err != nil {
+		return dto.SponsorResponse{}, err
+	}
+
+	sponsor := entity.Sponsor{
+		ID: uuid.New(),
+	}
+	applySponsorRequest(&sponsor, req)
+
+	if req.Logo != nil {
+		logo, err := s.uploadLogo(sponsor.ID, req.Logo)
+		if err != nil {
+			return dto.SponsorResponse{}, err
+		}
+		sponsor.Logo = logo
+	}
+
+	// the website placement backs the public listing, so every sponsor starts with one
+	_, err := storePlacement(sponsor, constants.ENUM_SPONSOR_PLACEMENT_WEBSITE, func(placement entity.SponsorPlacement) (entity.SponsorPlacement, error) {
+		_, err := s.sponsorRepo.Create(sponsor, placement)
+		return placement, err
+	})
+	if err != nil {
+		s.deleteLogo(sponsor.Logo)
+		return dto.SponsorResponse{}, err
+	}
+
+	return s.GetSponsorDetail(ctx, sponsor.ID.String())
+}
+
+func (s *sponsorService) UpdateSponsor(ctx context.Context, id string, req dto.SponsorRequest) (dto.SponsorResponse, error) {
+	if err := validateSponsorRequest(req); err != nil {
+		return dto.SponsorResponse{}, err
+	}
+
+	sponsor, err := s.sponsorRepo.GetByID(id)
+	if err != nil {
+		return dto.SponsorResponse{}, dto.ErrSponsorNotFound
+	}
+	applySponsorRequest(&sponsor, req)
+
+	// keep the current logo unless a new one is uploaded
+	previous := sponsor.Logo
+	if req.Logo != nil {
+		logo, err := s.uploadLogo(sponsor.ID, req.Logo)
+		if err != nil {
+			return dto.SponsorResponse{}, err
+		}
+		sponsor.Logo = logo
+	}
+
+	if _, err := s.sponsorRepo.Update(sponsor); err != nil {
+		if sponsor.Logo != previous {
+			s.deleteLogo(sponsor.Logo)
+		}
+		return dto.SponsorResponse{}, err
+	}
+
+	if sponsor.Logo != previous {
+		s.deleteLogo(previous)
+	}
+
+	return s.GetSponsorDetail(ctx, id)
+}
+
+func (s *sponsorService) DeleteSponsor(ctx context.Context, id string) error {
+	if _, err := s.sponsorRepo.GetByID(id); err != nil {
+		return dto.ErrSponsorNotFound
+	}
+
+	return s.sponsorRepo.Delete(id)
+}
+
+func (s *sponsorService) GetAllSponsor(ctx context.Context, req dto.SponsorQuery) ([]dto.SponsorResponse, error) {
+	sponsors, err := s.sponsorRepo.GetAll(req.Tier)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.SponsorResponse{}
+	for _, sponsor := range sponsors {
+		result = append(result, toSponsorResponse(sponsor, false))
+	}
+
+	return result, nil
+}
+
+func (s *sponsorService) GetSponsorDetail(ctx context.Context, id string) (dto.SponsorResponse, error) {
+	sponsor, err := s.sponsorRepo.GetByID(id)
+	if err != nil {
+		return dto.SponsorResponse{}, dto.ErrSponsorNotFound
+	}
+
+	return toSponsorResponse(sponsor, false), nil
+}
+
+func (s *sponsorService) GetPublicSponsors(ctx context.Context, req dto.SponsorQuery) ([]dto.SponsorTierResponse, error) {
+	if req.Tier != "" && !isSponsorTier(req.Tier) {
+		return nil, dto.ErrSponsorTierInvalid
+	}
+
+	sponsors, err := s.sponsorRepo.GetAll(req.Tier)
+	if err != nil {
+		return nil, err
+	}
+
+	byTier := make(map[string][]dto.SponsorResponse)
+	for _, sponsor := range sponsors {
+		byTier[sponsor.Tier] = append(byTier[sponsor.Tier], toSponsorResponse(sponsor, true))
+	}
+
+	result := []dto.SponsorTierResponse{}
+	for _, tier := range constants.SPONSOR_TIERS {
+		if len(byTier[tier]) == 0 {
+			continue
+		}
+
+		result = append(result, dto.SponsorTierResponse{
+			Tier:     tier,
+			Sponsors: byTier[tier],
+		})
+	}
+
+	return result, nil
+}
+
+func (s *sponsorService) GetPublicSponsorDetail(ctx context.Context, id string) (dto.SponsorResponse, error) {
+	sponsor, err := s.sponsorRepo.GetByID(id)
+	if err != nil {
+		return dto.SponsorResponse{}, dto.ErrSponsorNotFound
+	}
+
+	return toSponsorResponse(sponsor, true), nil
+}
+
+func (s *sponsorService) CreatePlacement(ctx context.Context, id string, req dto.SponsorPlacementRequest) (dto.SponsorPlacementResponse, error) {
+	if !isSponsorPlacement(req.Placement) {
+		return dto.SponsorPlacementResponse{}, dto.ErrSponsorPlacementInvalid
+	}
+
+	sponsor, err := s.sponsorRepo.GetByID(id)
+	if err != nil {
+		return dto.SponsorPlacementResponse{}, dto.ErrSponsorNotFound
+	}
+
+	exist, err := s.sponsorRepo.CheckPlacementExist(id, req.Placement)
+	if err != nil {
+		return dto.SponsorPlacementResponse{}, err
+	}
+
+	if exist {
+		return dto.SponsorPlacementResponse{}, dto.ErrSponsorPlacementExist
+	}
+
+	created, err := storePlacement(sponsor, req.Placement, s.sponsorRepo.CreatePlacement)
+	if err != nil {
+		return dto.SponsorPlacementResponse{}, err
+	}
+
+	return dto.SponsorPlacementResponse{
+		ID:        created.ID.String(),
+		Placement: created.Placement,
+		Alias:     created.LinkShortener.Alias,
+		URL:       constants.BASE_URL + dto.SHORT_LINK_PATH + created.LinkShortener.Alias,
+	}, nil
+}
+
+func (s *sponsorService) GetSponsorReport(ctx context.Context, id string) (dto.SponsorReportResponse, error) {
+	sponsor, err := s.sponsorRepo.GetByID(id)
+	if err != nil {
+		return dto.SponsorReportResponse{}, dto.ErrSponsorNotFound
+	}
+
+	return toSponsorReportResponse(sponsor), nil
+}
+
+func (s *sponsorService) GetAllSponsorReport(ctx context.Context) ([]dto.SponsorReportResponse, error) {
+	sponsors, err := s.sponsorRepo.GetAll("")
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.SponsorReportResponse{}
+	for _, sponsor := range sponsors {
+		result = append(result, toSponsorReportResponse(sponsor))
+	}
+
+	return result, nil
+}
+
+// the placement comes with its short link, both are stored together by the
+// repository which checks the alias in the same transaction. When the readable
+// alias is taken the insert is retried with a random suffix.
+func storePlacement(sponsor entity.Sponsor, placement string, store func(entity.SponsorPlacement) (entity.SponsorPlacement, error)) (entity.SponsorPlacement, error) {
+	alias := sponsorAlias(sponsor.Name, placement)
+	for attempt := 0; attempt < SPONSOR_ALIAS_ATTEMPTS; attempt++ {
+		link, err := newLinkShortener(alias, sponsor.Website)
+		if err != nil {
+			return entity.SponsorPlacement{}, err
+		}
+
+		created, err := store(entity.SponsorPlacement{
+			SponsorID:     sponsor.ID,
+			Placement:     placement,
+			LinkShortener: &link,
+		})
+		if err == nil {
+			return created, nil
+		}
+
+		if !errors.Is(err, dto.ErrAliasHasBeenTaken) {
+			return entity.SponsorPlacement{}, dto.ErrCreateSponsorTrackingLink
+		}
+
+		alias = sponsorAlias(sponsor.Name, placement) + "-" + strings.ToLower(utils.GenUniqueCode())
+	}
+
+	return entity.SponsorPlacement{}, dto.ErrCreateSponsorTrackingLink
+}
+
+func (s *sponsorService) uploadLogo(id uuid.UUID, logo *multipart.FileHeader) (string, error) {
+	return uploadImage(s.bucketRepo, dto.ENUM_STORAGE_FOLDER_SPONSOR, dto.STORAGE_ENDPOINT_SPONSOR, id, logo, dto.ErrFailedToStoreSponsorLogo)
+}
+
+func (s *sponsorService) deleteLogo(logo string) {
+	deleteImage(s.bucketRepo, dto.ENUM_STORAGE_FOLDER_SPONSOR, dto.STORAGE_ENDPOINT_SPONSOR, logo)
+}
+
+func validateSponsorRequest(req dto.SponsorRequest) error {
+	if !isSponsorTier(req.Tier) {
+		return dto.ErrSponsorTierInvalid
+	}
+
+	if !utils.ValidateLink(req.Website) {
+		return dto.ErrLinkFormatInvalid
+	}
+
+	return nil
+}
+
+func applySponsorRequest(sponsor *entity.Sponsor, req dto.SponsorRequest) {
+	sponsor.Name = req.Name
+	sponsor.Tier = req.Tier
+	sponsor.Description = req.Description
+	sponsor.DisplayOrder = req.DisplayOrder
+	sponsor.Website = req.Website
+	sponsor.Instagram = req.Instagram
+}
+
+func isSponsorTier(tier string) bool {
+	for _, t := range constants.SPONSOR_TIERS {
+		if t == tier {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSponsorPlacement(placement string) bool {
+	for _, p := range constants.SPONSOR_PLACEMENTS {
+		if p == placement {
+			return true
+		}
+	}
+
+	return false
+}
+
+// e.g. "PT Maju Jaya" on instagram becomes "pt-maju-jaya-instagram"
+func sponsorAlias(name string, placement string) string {
+	slug := strings.Trim(sponsorSlugPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(slug) > 32 {
+		slug = strings.TrimRight(slug[:32], "-")
+	}
+
+	return slug + "-" + placement
+}
+
+// the public listing only exposes the tracked website link,
+// the raw website and every other placement stay with the admins
+func toSponsorResponse(sponsor entity.Sponsor, public bool) dto.SponsorResponse {
+	res := dto.SponsorResponse{
+		ID:           sponsor.ID.String(),
+		Name:         sponsor.Name,
+		Tier:         sponsor.Tier,
+		Description:  sponsor.Description,
+		Logo:         sponsor.Logo,
+		DisplayOrder: sponsor.DisplayOrder,
+		Link:         sponsor.Website,
+		Instagram:    sponsor.Instagram,
+	}
+
+	for _, placement := range sponsor.Placements {
+		if placement.Placement == constants.ENUM_SPONSOR_PLACEMENT_WEBSITE && placement.LinkShortener != nil {
+			res.Link = constants.BASE_URL + dto.SHORT_LINK_PATH + placement.LinkShortener.Alias
+		}
+	}
+
+	if public {
+		return res
+	}
+
+	res.Website = sponsor.Website
+	res.Placements = toSponsorPlacementResponses(sponsor.Placements)
+
+	return res
+}
+
+func toSponsorReportResponse(sponsor entity.Sponsor) dto.SponsorReportResponse {
+	res := dto.SponsorReportResponse{
+		ID:         sponsor.ID.String(),
+		Name:       sponsor.Name,
+		Tier:       sponsor.Tier,
+		Placements: toSponsorPlacementResponses(sponsor.Placements),
+	}
+
+	for _, placement := range res.Placements {
+		res.TotalClicks += placement.Clicks
+	}
+
+	return res
+}
+
+func toSponsorPlacementResponses(placements []entity.SponsorPlacement) []dto.SponsorPlacementResponse {
+	result := []dto.SponsorPlacementResponse{}
+	for _, placement := range placements {
+		res := dto.SponsorPlacementResponse{
+			ID:        placement.ID.String(),
+			Placement: placement.Placement,
+		}
+
+		if placement.LinkShortener != nil {
+			res.Alias = placement.LinkShortener.Alias
+			res.URL = constants.BASE_URL + dto.SHORT_LINK_PATH + placement.LinkShortener.Alias
+			res.Clicks = placement.LinkShortener.Clicks
+		}
+
+		result = append(result, res)
+	}
+
+	return result
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/google/uuid"
+)
+
+func TestStorePlacementRetriesTakenAlias(t *testing.T) {
+	sponsor := entity.Sponsor{ID: uuid.New(), Name: "Acme", Website: "https://acme.example"}
+
+	var aliases []string
+	created, err := storePlacement(sponsor, constants.ENUM_SPONSOR_PLACEMENT_WEBSITE, func(placement entity.SponsorPlacement) (entity.SponsorPlacement, error) {
+		aliases = append(aliases, placement.LinkShortener.Alias)
+		if len(aliases) == 1 {
+			return entity.SponsorPlacement{}, dto.ErrAliasHasBeenTaken
+		}
+		return placement, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(aliases) != 2 {
+		t.Fatalf("expected 2 attempts, got %d", len(aliases))
+	}
+
+	if created.LinkShortener.Alias == aliases[0] || !strings.HasPrefix(created.LinkShortener.Alias, aliases[0]+"-") {
+		t.Fatalf("expected a suffixed alias, got %q after %q", created.LinkShortener.Alias, aliases[0])
+	}
+}
+
+func TestStorePlacementGivesUpAfterAttempts(t *testing.T) {
+	sponsor := entity.Sponsor{ID: uuid.New(), Name: "Acme", Website: "https://acme.example"}
+
+	attempts := 0
+	_, err := storePlacement(sponsor, constants.ENUM_SPONSOR_PLACEMENT_WEBSITE, func(placement entity.SponsorPlacement) (entity.SponsorPlacement, error) {
+		attempts++
+		return entity.SponsorPlacement{}, dto.ErrAliasHasBeenTaken
+	})
+	if err != dto.ErrCreateSponsorTrackingLink {
+		t.Fatalf("expected ErrCreateSponsorTrackingLink, got %v", err)
+	}
+
+	if attempts != SPONSOR_ALIAS_ATTEMPTS {
+		t.Fatalf("expected %d attempts, got %d", SPONSOR_ALIAS_ATTEMPTS, attempts)
+	}
+}
@@ -1,10 +1,15 @@
 package service
 
-import "github.com/TEDxITS/website-backend-2024/repository"
+import (
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/repository"
+)
 
 type (
 	StorageService interface {
 		GetMainEventPaymentFile(string) ([]byte, error)
+		GetSpeakerPhoto(string) ([]byte, error)
+		GetSponsorLogo(string) ([]byte, error)
 	}
 
 	storageService struct {
@@ -21,3 +26,11 @@ func NewStorageService(bRepo repository.BucketRepository) StorageService {
 func (s *storageService) GetMainEventPaymentFile(id string) ([]byte, error) {
 	return s.bucketRepo.DownloadFile("main-event", id)
 }
+
+func (s *storageService) GetSpeakerPhoto(id string) ([]byte, error) {
+	return s.bucketRepo.DownloadFile(dto.ENUM_STORAGE_FOLDER_SPEAKER, id)
+}
+
+func (s *storageService) GetSponsorLogo(id string) ([]byte, error) {
+	return s.bucketRepo.DownloadFile(dto.ENUM_STORAGE_FOLDER_SPONSOR, id)
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"mime/multipart"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// only the methods a test calls are implemented, anything else panics
+type stubUserRepository struct {
+	repository.UserRepository
+	users map[string]entity.User
+}
+
+func (r stubUserRepository) GetUserById(userId string) (entity.User, error) {
+	user, ok := r.users[userId]
+	if !ok {
+		return entity.User{}, dto.ErrUserNotFound
+	}
+	return user, nil
+}
+
+type stubRoleRepository struct {
+	repository.RoleRepository
+	roles  map[string]entity.Role
+	grants map[string][]string
+}
+
+func (r stubRoleRepository) GetRolebyId(roleId string) (entity.Role, error) {
+	role, ok := r.roles[roleId]
+	if !ok {
+		return entity.Role{}, dto.ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (r stubRoleRepository) HasPermission(roleId string, permission string) (bool, error) {
+	for _, granted := range r.grants[roleId] {
+		if granted == permission {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r stubUserRepository) GetUserByEmail(email string) (entity.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return entity.User{}, gorm.ErrRecordNotFound
+}
+
+func (r stubUserRepository) RegisterUser(user entity.User) (entity.User, error) {
+	user.ID = uuid.New()
+	r.users[user.ID.String()] = user
+	return user, nil
+}
+
+type stubIdentityRepository struct {
+	identities []entity.UserIdentity
+}
+
+func (r *stubIdentityRepository) Create(identity entity.UserIdentity) (entity.UserIdentity, error) {
+	r.identities = append(r.identities, identity)
+	return identity, nil
+}
+
+func (r *stubIdentityRepository) GetByProviderSubject(provider string, subject string) (entity.UserIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return entity.UserIdentity{}, gorm.ErrRecordNotFound
+}
+
+func (r stubUserRepository) UpdateUser(user entity.User) (entity.User, error) {
+	stored, ok := r.users[user.ID.String()]
+	if !ok {
+		return entity.User{}, gorm.ErrRecordNotFound
+	}
+	if user.Password != "" {
+		stored.Password = user.Password
+	}
+	r.users[user.ID.String()] = stored
+	return stored, nil
+}
+
+type stubUserTokenRepository struct {
+	repository.UserTokenRepository
+	tokens map[string]entity.UserToken
+}
+
+func (r stubUserTokenRepository) GetByHash(hash string) (entity.UserToken, error) {
+	token, ok := r.tokens[hash]
+	if !ok {
+		return entity.UserToken{}, gorm.ErrRecordNotFound
+	}
+	return token, nil
+}
+
+func (r stubUserTokenRepository) Consume(token entity.UserToken) error {
+	now := time.Now()
+	token.ConsumedAt = &now
+	r.tokens[token.TokenHash] = token
+	return nil
+}
+
+// records whose sessions were revoked
+type stubAuthService struct {
+	AuthService
+	revoked []string
+}
+
+func (s *stubAuthService) RevokeUserSessions(ctx context.Context, userID string) error {
+	s.revoked = append(s.revoked, userID)
+	return nil
+}
+
+func (r stubUserRepository) UpdateProfile(user entity.User) error {
+	r.users[user.ID.String()] = user
+	return nil
+}
+
+type stubTicketRepository struct {
+	repository.TicketRepository
+	tickets map[string]entity.Ticket
+}
+
+func (r stubTicketRepository) GetTicketById(id string) (entity.Ticket, error) {
+	ticket, ok := r.tickets[id]
+	if !ok {
+		return entity.Ticket{}, gorm.ErrRecordNotFound
+	}
+	return ticket, nil
+}
+
+func (r stubTicketRepository) CreateCompTicket(ticket entity.Ticket) (entity.Ticket, error) {
+	r.tickets[ticket.TicketID] = ticket
+	return ticket, nil
+}
+
+func (r stubTicketRepository) CheckUserHasTicket(userID string, eventID string) (bool, error) {
+	for _, ticket := range r.tickets {
+		if ticket.UserID == userID && ticket.EventID == eventID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// records what the services put into and remove from storage
+type stubBucketRepository struct {
+	repository.BucketRepository
+	uploaded []string
+	deleted  []string
+}
+
+func (s *stubBucketRepository) UploadFile(folder string, file *multipart.FileHeader) error {
+	s.uploaded = append(s.uploaded, folder+"/"+file.Filename)
+	return nil
+}
+
+func (s *stubBucketRepository) DeleteFile(folder string, name string) error {
+	s.deleted = append(s.deleted, folder+"/"+name)
+	return nil
+}
+
+// keeps the audit log each change was written with
+type stubUserManagementRepository struct {
+	repository.UserManagementRepository
+	logs []entity.AuditLog
+}
+
+func (r *stubUserManagementRepository) Delete(userId string, log entity.AuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *stubUserManagementRepository) Verify(userId string, log entity.AuditLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+// mirrors the conditional updates of the real repository
+type stubMFARepository struct {
+	repository.MFARepository
+	lastStep      map[string]int64
+	recoveryCodes map[string]bool
+}
+
+func (r stubMFARepository) UseStep(userID string, step int64) error {
+	if r.lastStep[userID] >= step {
+		return gorm.ErrRecordNotFound
+	}
+	r.lastStep[userID] = step
+	return nil
+}
+
+func (r stubMFARepository) UseRecoveryCode(userID string, hash string) error {
+	if used, ok := r.recoveryCodes[hash]; !ok || used {
+		return gorm.ErrRecordNotFound
+	}
+	r.recoveryCodes[hash] = true
+	return nil
+}
+
+// refresh tokens keyed by hash, rotation and revocation behave like the real repository
+type stubAuthTokenRepository struct {
+	repository.AuthTokenRepository
+	tokens map[string]entity.RefreshToken
+
+	// returns tokens as they were before any revocation, like a read racing another refresh
+	staleReads bool
+}
+
+func (r *stubAuthTokenRepository) CreateRefreshToken(token entity.RefreshToken) (entity.RefreshToken, error) {
+	token.ID = uuid.New()
+	r.tokens[token.TokenHash] = token
+	return token, nil
+}
+
+func (r *stubAuthTokenRepository) GetRefreshTokenByHash(hash string) (entity.RefreshToken, error) {
+	token, ok := r.tokens[hash]
+	if !ok {
+		return entity.RefreshToken{}, gorm.ErrRecordNotFound
+	}
+	if r.staleReads {
+		token.RevokedAt = nil
+	}
+	return token, nil
+}
+
+func (r *stubAuthTokenRepository) RotateRefreshToken(old entity.RefreshToken, next entity.RefreshToken) (entity.RefreshToken, error) {
+	stored := r.tokens[old.TokenHash]
+	if stored.RevokedAt != nil {
+		return entity.RefreshToken{}, dto.ErrRefreshTokenReused
+	}
+
+	now := time.Now()
+	stored.RevokedAt = &now
+	r.tokens[old.TokenHash] = stored
+	return r.CreateRefreshToken(next)
+}
+
+func (r *stubAuthTokenRepository) RevokeFamily(familyID string) error {
+	now := time.Now()
+	for hash, token := range r.tokens {
+		if token.FamilyID.String() == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &now
+			r.tokens[hash] = token
+		}
+	}
+	return nil
+}
+
+func (r stubMFARepository) GetByUserID(userID string) (entity.UserMFA, error) {
+	return entity.UserMFA{}, gorm.ErrRecordNotFound
+}
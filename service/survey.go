@@ -0,0 +1,476 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"text/template"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+)
+
+type (
+	SurveyService interface {
+		CreateSurvey(ctx context.Context, req dto.SurveyRequest) (dto.SurveyDetailResponse, error)
+		GetAllSurvey(ctx context.Context) ([]dto.SurveyDetailResponse, error)
+		GetSurveyDetail(ctx context.Context, id string) (dto.SurveyDetailResponse, error)
+		InviteAttendees(ctx context.Context, id string) (dto.SurveyInviteResponse, error)
+		GetSurveyByToken(ctx context.Context, token string) (dto.SurveyDetailResponse, error)
+		SubmitSurvey(ctx context.Context, token string, req dto.SurveySubmitRequest) error
+		GetSurveyResult(ctx context.Context, id string) (dto.SurveyResultResponse, error)
+	}
+
+	surveyService struct {
+		surveyRepo  repository.SurveyRepository
+		eventRepo   repository.EventRepository
+		ticketRepo  repository.TicketRepository
+		pe2RSVPRepo repository.PE2RSVPRepository
+	}
+)
+
+func NewSurveyService(
+	sRepo repository.SurveyRepository,
+	eRepo repository.EventRepository,
+	tRepo repository.TicketRepository,
+	pRepo repository.PE2RSVPRepository,
+) SurveyService {
+	return &surveyService{
+		surveyRepo:  sRepo,
+		eventRepo:   eRepo,
+		ticketRepo:  tRepo,
+		pe2RSVPRepo: pRepo,
+	}
+}
+
+func (s *surveyService) CreateSurvey(ctx context.Context, req dto.SurveyRequest) (dto.SurveyDetailResponse, error) {
+	if len(req.EventIDs) == 0 {
+		return dto.SurveyDetailResponse{}, dto.ErrSurveyNoEvent
+	}
+
+	if len(req.Questions) == 0 {
+		return dto.SurveyDetailResponse{}, dto.ErrSurveyNoQuestion
+	}
+
+	var events []entity.Event
+	for _, id := range req.EventIDs {
+		event, err := s.eventRepo.GetByID(id)
+		if err != nil {
+			return dto.SurveyDetailResponse{}, dto.ErrEventNotFound
+		}
+		events = append(events, event)
+	}
+
+	var questions []entity.SurveyQuestion
+	for i, q := range req.Questions {
+		scale := q.Scale
+		switch q.Type {
+		case dto.ENUM_SURVEY_QUESTION_RATING:
+			if scale <= 1 {
+				scale = dto.SURVEY_DEFAULT_RATING_SCALE
+			}
+		case dto.ENUM_SURVEY_QUESTION_NPS:
+			scale = dto.SURVEY_NPS_SCALE
+		case dto.ENUM_SURVEY_QUESTION_TEXT:
+			scale = 0
+		default:
+			return dto.SurveyDetailResponse{}, dto.ErrSurveyQuestionTypeInvalid
+		}
+
+		required := q.Required
+		questions = append(questions, entity.SurveyQuestion{
+			Position: i + 1,
+			Type:     q.Type,
+			Prompt:   q.Prompt,
+			Scale:    scale,
+			Required: &required,
+		})
+	}
+
+	survey, err := s.surveyRepo.Create(entity.Survey{
+		Title:                  req.Title,
+		Description:            req.Description,
+		RequiredForCertificate: &req.RequiredForCertificate,
+		ClosedAt:               req.ClosedAt,
+		Events:                 events,
+		Questions:              questions,
+	})
+	if err != nil {
+		return dto.SurveyDetailResponse{}, err
+	}
+
+	return toSurveyDetailResponse(survey), nil
+}
+
+func (s *surveyService) GetAllSurvey(ctx context.Context) ([]dto.SurveyDetailResponse, error) {
+	surveys, err := s.surveyRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []dto.SurveyDetailResponse
+	for _, survey := range surveys {
+		result = append(result, toSurveyDetailResponse(survey))
+	}
+
+	return result, nil
+}
+
+func (s *surveyService) GetSurveyDetail(ctx context.Context, id string) (dto.SurveyDetailResponse, error) {
+	survey, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return dto.SurveyDetailResponse{}, dto.ErrSurveyNotFound
+	}
+
+	return toSurveyDetailResponse(survey), nil
+}
+
+func (s *surveyService) InviteAttendees(ctx context.Context, id string) (dto.SurveyInviteResponse, error) {
+	survey, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return dto.SurveyInviteResponse{}, dto.ErrSurveyNotFound
+	}
+
+	if isSurveyClosed(survey) {
+		return dto.SurveyInviteResponse{}, dto.ErrSurveyClosed
+	}
+
+	// invitations are only created once per attendee,
+	// so calling this again only reaches the newly checked in
+	invited, err := s.surveyRepo.GetInvitedAttendees(id)
+	if err != nil {
+		return dto.SurveyInviteResponse{}, err
+	}
+
+	alreadyInvited := make(map[string]bool)
+	for _, invitation := range invited {
+		alreadyInvited[invitation.TicketID+invitation.RSVPID] = true
+	}
+
+	var newInvitations []entity.SurveyInvitation
+	for _, event := range survey.Events {
+		tickets, err := s.ticketRepo.FindCheckedInByEventID(event.ID.String())
+		if err != nil {
+			return dto.SurveyInviteResponse{}, err
+		}
+
+		for _, ticket := range tickets {
+			if alreadyInvited[ticket.TicketID] || ticket.User == nil {
+				continue
+			}
+
+			newInvitations = append(newInvitations, entity.SurveyInvitation{
+				SurveyID: survey.ID,
+				EventID:  event.ID.String(),
+				TicketID: ticket.TicketID,
+				Email:    ticket.User.Email,
+				Name:     ticket.User.Name,
+			})
+		}
+
+		// pre-event 2 attendees RSVP without an account
+		if event.ID.String() == constants.PreEvent2ID {
+			rsvps, err := s.pe2RSVPRepo.GetAllAttended()
+			if err != nil {
+				return dto.SurveyInviteResponse{}, err
+			}
+
+			for _, rsvp := range rsvps {
+				if alreadyInvited[rsvp.ID.String()] {
+					continue
+				}
+
+				newInvitations = append(newInvitations, entity.SurveyInvitation{
+					SurveyID: survey.ID,
+					EventID:  event.ID.String(),
+					RSVPID:   rsvp.ID.String(),
+					Email:    rsvp.Email,
+					Name:     rsvp.Name,
+				})
+			}
+		}
+	}
+
+	if len(newInvitations) == 0 {
+		return dto.SurveyInviteResponse{}, dto.ErrSurveyNoAttendee
+	}
+
+	tokens := make([]string, len(newInvitations))
+	for i := range newInvitations {
+		token, err := utils.GenRandomToken()
+		if err != nil {
+			return dto.SurveyInviteResponse{}, err
+		}
+
+		newInvitations[i].TokenHash = utils.HashToken(token)
+		newInvitations[i], err = s.surveyRepo.CreateInvitation(newInvitations[i])
+		if err != nil {
+			return dto.SurveyInviteResponse{}, err
+		}
+		tokens[i] = token
+	}
+
+	go func() {
+		readHtml, err := os.ReadFile("./utils/template/mail_survey_invitation.html")
+		if err != nil {
+			return
+		}
+
+		tmpl, err := template.New("custom").Parse(string(readHtml))
+		if err != nil {
+			return
+		}
+
+		for i, invitation := range newInvitations {
+			var strMail bytes.Buffer
+			if err := tmpl.Execute(&strMail, struct {
+				Name   string
+				Title  string
+				Survey string
+			}{
+				Name:   invitation.Name,
+				Title:  survey.Title,
+				Survey: constants.BASE_URL + "/survey?token=" + tokens[i],
+			}); err != nil {
+				continue
+			}
+
+			if err := utils.SendMail(utils.Email{
+				Email:   invitation.Email,
+				Subject: survey.Title + " - TEDxITS",
+				Body:    strMail.String(),
+			}); err != nil {
+				continue
+			}
+
+			now := time.Now()
+			invitation.SentAt = &now
+			s.surveyRepo.UpdateInvitation(invitation)
+		}
+	}()
+
+	return dto.SurveyInviteResponse{
+		Invited: len(newInvitations),
+	}, nil
+}
+
+func (s *surveyService) GetSurveyByToken(ctx context.Context, token string) (dto.SurveyDetailResponse, error) {
+	invitation, err := s.getOpenInvitation(token)
+	if err != nil {
+		return dto.SurveyDetailResponse{}, err
+	}
+
+	return toSurveyDetailResponse(*invitation.Survey), nil
+}
+
+func (s *surveyService) SubmitSurvey(ctx context.Context, token string, req dto.SurveySubmitRequest) error {
+	invitation, err := s.getOpenInvitation(token)
+	if err != nil {
+		return err
+	}
+
+	answers := make(map[string]dto.SurveyAnswerRequest)
+	for _, answer := range req.Answers {
+		answers[answer.QuestionID] = answer
+	}
+
+	var result []entity.SurveyAnswer
+	for _, question := range invitation.Survey.Questions {
+		answer, ok := answers[question.ID.String()]
+		answered := ok && (answer.Score != nil || (question.Type == dto.ENUM_SURVEY_QUESTION_TEXT && answer.Text != ""))
+		if !answered {
+			if question.Required != nil && *question.Required {
+				return dto.ErrSurveyAnswerMissing
+			}
+			continue
+		}
+
+		if question.Type == dto.ENUM_SURVEY_QUESTION_TEXT {
+			result = append(result, entity.SurveyAnswer{
+				QuestionID: question.ID,
+				Text:       answer.Text,
+			})
+			continue
+		}
+
+		// nps goes from 0 to 10, ratings start from 1
+		min := 1
+		if question.Type == dto.ENUM_SURVEY_QUESTION_NPS {
+			min = 0
+		}
+
+		if answer.Score == nil || *answer.Score < min || *answer.Score > question.Scale {
+			return dto.ErrSurveyAnswerOutOfScale
+		}
+
+		result = append(result, entity.SurveyAnswer{
+			QuestionID: question.ID,
+			Score:      answer.Score,
+		})
+	}
+
+	return s.surveyRepo.SubmitResponse(invitation, entity.SurveyResponse{
+		SurveyID: invitation.SurveyID,
+		EventID:  invitation.EventID,
+		Answers:  result,
+	})
+}
+
+func (s *surveyService) GetSurveyResult(ctx context.Context, id string) (dto.SurveyResultResponse, error) {
+	survey, err := s.surveyRepo.GetByID(id)
+	if err != nil {
+		return dto.SurveyResultResponse{}, dto.ErrSurveyNotFound
+	}
+
+	invited, completed, err := s.surveyRepo.CountInvitations(id)
+	if err != nil {
+		return dto.SurveyResultResponse{}, err
+	}
+
+	counts, err := s.surveyRepo.CountResponsesPerEvent(id)
+	if err != nil {
+		return dto.SurveyResultResponse{}, err
+	}
+
+	rows, err := s.surveyRepo.AggregateAnswers(id)
+	if err != nil {
+		return dto.SurveyResultResponse{}, err
+	}
+
+	result := dto.SurveyResultResponse{
+		SurveyID:  survey.ID.String(),
+		Invited:   invited,
+		Completed: completed,
+		Questions: aggregateSurveyQuestions(survey.Questions, rows, ""),
+	}
+
+	responses := make(map[string]int64)
+	for _, count := range counts {
+		responses[count.EventID] = count.Total
+	}
+
+	for _, event := range survey.Events {
+		result.PerTier = append(result.PerTier, dto.SurveyTierResult{
+			EventID:   event.ID.String(),
+			EventName: event.Name,
+			Responses: responses[event.ID.String()],
+			Questions: aggregateSurveyQuestions(survey.Questions, rows, event.ID.String()),
+		})
+	}
+
+	return result, nil
+}
+
+func (s *surveyService) getOpenInvitation(token string) (entity.SurveyInvitation, error) {
+	invitation, err := s.surveyRepo.GetInvitationByTokenHash(utils.HashToken(token))
+	if err != nil || invitation.Survey == nil {
+		return entity.SurveyInvitation{}, dto.ErrSurveyInvitationNotFound
+	}
+
+	if invitation.CompletedAt != nil {
+		return entity.SurveyInvitation{}, dto.ErrSurveyAlreadyCompleted
+	}
+
+	if isSurveyClosed(*invitation.Survey) {
+		return entity.SurveyInvitation{}, dto.ErrSurveyClosed
+	}
+
+	return invitation, nil
+}
+
+func isSurveyClosed(survey entity.Survey) bool {
+	return !survey.ClosedAt.IsZero() && time.Now().After(survey.ClosedAt)
+}
+
+// aggregate the grouped answer rows for every question,
+// an empty eventID means all tiers are taken into account
+func aggregateSurveyQuestions(questions []entity.SurveyQuestion, rows []dto.SurveyAnswerAggregate, eventID string) []dto.SurveyQuestionResult {
+	var result []dto.SurveyQuestionResult
+	for _, question := range questions {
+		res := dto.SurveyQuestionResult{
+			QuestionID: question.ID.String(),
+			Prompt:     question.Prompt,
+			Type:       question.Type,
+		}
+
+		var sum int64
+		for _, row := range rows {
+			if row.QuestionID != question.ID.String() || (eventID != "" && row.EventID != eventID) {
+				continue
+			}
+
+			res.Answers += row.Total
+			if row.Score == nil {
+				continue
+			}
+
+			if res.Distribution == nil {
+				res.Distribution = make(map[int]int64)
+			}
+			res.Distribution[*row.Score] += row.Total
+			sum += int64(*row.Score) * row.Total
+		}
+
+		if question.Type != dto.ENUM_SURVEY_QUESTION_TEXT && res.Answers > 0 {
+			res.Average = float64(sum) / float64(res.Answers)
+		}
+
+		// https://en.wikipedia.org/wiki/Net_promoter_score
+		if question.Type == dto.ENUM_SURVEY_QUESTION_NPS {
+			nps := dto.SurveyNPSResult{}
+			for score, total := range res.Distribution {
+				switch {
+				case score >= 9:
+					nps.Promoters += total
+				case score >= 7:
+					nps.Passives += total
+				default:
+					nps.Detractors += total
+				}
+			}
+
+			if res.Answers > 0 {
+				nps.Score = float64(nps.Promoters-nps.Detractors) / float64(res.Answers) * 100
+			}
+			res.NPS = &nps
+		}
+
+		result = append(result, res)
+	}
+
+	return result
+}
+
+func toSurveyDetailResponse(survey entity.Survey) dto.SurveyDetailResponse {
+	res := dto.SurveyDetailResponse{
+		ID:                     survey.ID.String(),
+		Title:                  survey.Title,
+		Description:            survey.Description,
+		RequiredForCertificate: survey.RequiredForCertificate != nil && *survey.RequiredForCertificate,
+		ClosedAt:               survey.ClosedAt,
+	}
+
+	for _, event := range survey.Events {
+		res.Events = append(res.Events, dto.EventResponse{
+			ID:   event.ID.String(),
+			Name: event.Name,
+		})
+	}
+
+	for _, question := range survey.Questions {
+		res.Questions = append(res.Questions, dto.SurveyQuestionResponse{
+			ID:       question.ID.String(),
+			Position: question.Position,
+			Type:     question.Type,
+			Prompt:   question.Prompt,
+			Scale:    question.Scale,
+			Required: question.Required != nil && *question.Required,
+		})
+	}
+
+	return res
+}
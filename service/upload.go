@@ -0,0 +1,59 @@
+package service
+
+import (
+	"log"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/google/uuid"
+)
+
+// images shown on the public site, stored under the owner's id. The returned
+// path is what the storage routes serve, storeErr is reported when the bucket fails.
+func uploadImage(bucketRepo repository.BucketRepository, folder string, endpoint string, id uuid.UUID, image *multipart.FileHeader, storeErr error) (string, error) {
+	if image.Size > dto.MB*5 {
+		return "", dto.ErrMaxFileSize5MB
+	}
+
+	file, err := image.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	fileBuffer := make([]byte, 512)
+	if _, err := file.Read(fileBuffer); err != nil {
+		return "", err
+	}
+
+	// only allow for jpeg/jpg/png
+	fileType := http.DetectContentType(fileBuffer)
+	if fileType != dto.ENUM_FILE_TYPE_JPEG && fileType != dto.ENUM_FILE_TYPE_PNG {
+		return "", dto.ErrFileMustBeImage
+	}
+
+	// a fresh name on every upload, the bucket does not overwrite existing files
+	image.Filename = id.String() + "-" + strconv.FormatInt(time.Now().Unix(), 10) + "." + utils.GetExtensions(image.Filename)
+	if err := bucketRepo.UploadFile(folder, image); err != nil {
+		return "", storeErr
+	}
+
+	return endpoint + image.Filename, nil
+}
+
+// a file left behind only costs storage, so a failure is logged and not returned
+func deleteImage(bucketRepo repository.BucketRepository, folder string, endpoint string, path string) {
+	if !strings.HasPrefix(path, endpoint) {
+		return
+	}
+
+	if err := bucketRepo.DeleteFile(folder, strings.TrimPrefix(path, endpoint)); err != nil {
+		log.Printf("error deleting %s: %v", path, err)
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/google/uuid"
+)
+
+var errTestStore = dto.ErrFailedToStoreSpeakerPhoto
+
+func newFileHeader(t *testing.T, name string, content []byte) *multipart.FileHeader {
+	t.Helper()
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if err := req.ParseMultipartForm(dto.MB); err != nil {
+		t.Fatal(err)
+	}
+	return req.MultipartForm.File["file"][0]
+}
+
+func pngBytes(t *testing.T) []byte {
+	t.Helper()
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 1, 1))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUploadImage(t *testing.T) {
+	bucket := &stubBucketRepository{}
+	id := uuid.New()
+
+	path, err := uploadImage(bucket, "speaker", "/storage/speaker/", id, newFileHeader(t, "photo.png", pngBytes(t)), errTestStore)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(path, "/storage/speaker/"+id.String()+"-") || !strings.HasSuffix(path, ".png") {
+		t.Fatalf("unexpected path %s", path)
+	}
+	if len(bucket.uploaded) != 1 || bucket.uploaded[0] != "speaker/"+strings.TrimPrefix(path, "/storage/speaker/") {
+		t.Fatalf("uploaded %v", bucket.uploaded)
+	}
+}
+
+func TestUploadImageRejectsNonImage(t *testing.T) {
+	bucket := &stubBucketRepository{}
+
+	_, err := uploadImage(bucket, "speaker", "/storage/speaker/", uuid.New(), newFileHeader(t, "photo.png", []byte("plain text, not an image")), errTestStore)
+	if err != dto.ErrFileMustBeImage {
+		t.Fatalf("err = %v, want %v", err, dto.ErrFileMustBeImage)
+	}
+	if len(bucket.uploaded) != 0 {
+		t.Fatalf("uploaded %v", bucket.uploaded)
+	}
+}
+
+func TestDeleteImage(t *testing.T) {
+	bucket := &stubBucketRepository{}
+
+	deleteImage(bucket, "speaker", "/storage/speaker/", "/storage/speaker/old.png")
+	// paths outside the endpoint, like an external url or no photo at all, are left alone
+	deleteImage(bucket, "speaker", "/storage/speaker/", "https://cdn.example.com/old.png")
+	deleteImage(bucket, "speaker", "/storage/speaker/", "")
+
+	if len(bucket.deleted) != 1 || bucket.deleted[0] != "speaker/old.png" {
+		t.Fatalf("deleted %v", bucket.deleted)
+	}
+}
@@ -0,0 +1,372 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/helpers"
+	"github.com/TEDxITS/website-backend-2024/repository"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/google/uuid"
+)
+
+type (
+	UserManagementService interface {
+		ChangeRole(ctx context.Context, actorID string, userID string, req dto.UserRoleRequest) (dto.UserResponse, error)
+		Suspend(ctx context.Context, actorID string, userID string, req dto.UserSuspendRequest) error
+		Reactivate(ctx context.Context, actorID string, userID string) error
+		Delete(ctx context.Context, actorID string, userID string) error
+		ForceVerify(ctx context.Context, actorID string, userID string) error
+		ForceResetPassword(ctx context.Context, actorID string, userID string) error
+		GetAuditLogs(ctx context.Context, req dto.AuditLogQuery) (dto.AuditLogPaginationResponse, error)
+		GetLockouts(ctx context.Context) ([]dto.LockoutResponse, error)
+		ClearLockout(ctx context.Context, actorID string, id string) error
+	}
+
+	userManagementService struct {
+		userRepo     repository.UserRepository
+		roleRepo     repository.RoleRepository
+		auditLogRepo repository.AuditLogRepository
+		manageRepo   repository.UserManagementRepository
+		lockoutRepo  repository.LoginLockoutRepository
+		userService  UserService
+		authService  AuthService
+	}
+)
+
+func NewUserManagementService(
+	uRepo repository.UserRepository,
+	rRepo repository.RoleRepository,
+	aRepo repository.AuditLogRepository,
+	mRepo repository.UserManagementRepository,
+	lRepo repository.LoginLockoutRepository,
+	userService UserService,
+	authService AuthService,
+) UserManagementService {
+	return &userManagementService{
+		userRepo:     uRepo,
+		roleRepo:     rRepo,
+		auditLogRepo: aRepo,
+		manageRepo:   mRepo,
+		lockoutRepo:  lRepo,
+		userService:  userService,
+		authService:  authService,
+	}
+}
+
+// sessions are revoked so the old role can not be used until the access token expires
+func (s *userManagementService) ChangeRole(ctx context.Context, actorID string, userID string, req dto.UserRoleRequest) (dto.UserResponse, error) {
+	user, actorIsAdmin, err := s.getTarget(actorID, userID)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	if user.RoleID == req.RoleID {
+		return dto.UserResponse{}, dto.ErrUserAlreadyHasRole
+	}
+
+	role, err := s.roleRepo.GetRolebyId(req.RoleID)
+	if err != nil {
+		return dto.UserResponse{}, dto.ErrRoleNotFound
+	}
+
+	// user.manage alone must not be a way to become admin
+	if role.Name == constants.ENUM_ROLE_ADMIN && !actorIsAdmin {
+		return dto.UserResponse{}, dto.ErrManageAdminNotAllowed
+	}
+
+	previous := user.RoleID
+	if user.Role != nil {
+		previous = user.Role.Name
+	}
+
+	log, err := newAuditLog(actorID, constants.ENUM_AUDIT_USER_ROLE_CHANGE, constants.AUDIT_TARGET_USER, userID, previous+" -> "+role.Name)
+	if err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	if err := s.manageRepo.ChangeRole(userID, role.ID.String(), log); err != nil {
+		return dto.UserResponse{}, dto.ErrUpdateUser
+	}
+
+	if err := s.authService.RevokeUserSessions(ctx, userID); err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	return dto.UserResponse{
+		ID:          user.ID.String(),
+		Name:        user.Name,
+		Email:       user.Email,
+		RoleID:      role.ID.String(),
+		Role:        role.Name,
+		IsVerified:  user.Verified,
+		IsSuspended: user.SuspendedAt != nil,
+	}, nil
+}
+
+func (s *userManagementService) Suspend(ctx context.Context, actorID string, userID string, req dto.UserSuspendRequest) error {
+	user, _, err := s.getTarget(actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.SuspendedAt != nil {
+		return dto.ErrUserAlreadySuspended
+	}
+
+	log, err := newAuditLog(actorID, constants.ENUM_AUDIT_USER_SUSPEND, constants.AUDIT_TARGET_USER, userID, req.Reason)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if err := s.manageRepo.SetSuspension(userID, &now, req.Reason, log); err != nil {
+		return dto.ErrUpdateUser
+	}
+
+	return s.authService.RevokeUserSessions(ctx, userID)
+}
+
+func (s *userManagementService) Reactivate(ctx context.Context, actorID string, userID string) error {
+	user, _, err := s.getTarget(actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.SuspendedAt == nil {
+		return dto.ErrUserNotSuspended
+	}
+
+	log, err := newAuditLog(actorID, constants.ENUM_AUDIT_USER_REACTIVATE, constants.AUDIT_TARGET_USER, userID, user.SuspendReason)
+	if err != nil {
+		return err
+	}
+
+	if err := s.manageRepo.SetSuspension(userID, nil, "", log); err != nil {
+		return dto.ErrUpdateUser
+	}
+
+	// nothing to revoke, only the cached suspension has to go
+	return s.authService.RevokeUserSessions(ctx, userID)
+}
+
+// soft delete only, tickets and certificates keep pointing at the row
+func (s *userManagementService) Delete(ctx context.Context, actorID string, userID string) error {
+	if _, _, err := s.getTarget(actorID, userID); err != nil {
+		return err
+	}
+
+	// the target id already names the account, the email is not copied into the log
+	log, err := newAuditLog(actorID, constants.ENUM_AUDIT_USER_DELETE, constants.AUDIT_TARGET_USER, userID, "")
+	if err != nil {
+		return err
+	}
+
+	if err := s.manageRepo.Delete(userID, log); err != nil {
+		return dto.ErrUpdateUser
+	}
+
+	return s.authService.RevokeUserSessions(ctx, userID)
+}
+
+func (s *userManagementService) ForceVerify(ctx context.Context, actorID string, userID string) error {
+	user, _, err := s.getTarget(actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Verified {
+		return dto.ErrAccountAlreadyVerified
+	}
+
+	log, err := newAuditLog(actorID, constants.ENUM_AUDIT_USER_FORCE_VERIFY, constants.AUDIT_TARGET_USER, userID, "")
+	if err != nil {
+		return err
+	}
+
+	if err := s.manageRepo.Verify(userID, log); err != nil {
+		return dto.ErrVerifyEmail
+	}
+
+	return nil
+}
+
+// the current password stops working right away, the owner
+// has to pick a new one through the emailed reset link
+func (s *userManagementService) ForceResetPassword(ctx context.Context, actorID string, userID string) error {
+	user, _, err := s.getTarget(actorID, userID)
+	if err != nil {
+		return err
+	}
+
+	if user.Role == nil || user.Role.Name != constants.ENUM_ROLE_USER {
+		return dto.ErrAdminNotAllowedResetPassword
+	}
+
+	password, err := utils.GenRandomToken()
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := helpers.HashPassword(password)
+	if err != nil {
+		return dto.ErrHashPassword
+	}
+
+	log, err := newAuditLog(actorID, constants.ENUM_AUDIT_USER_FORCE_RESET, constants.AUDIT_TARGET_USER, userID, "")
+	if err != nil {
+		return err
+	}
+
+	if err := s.manageRepo.SetPassword(userID, hashedPassword, log); err != nil {
+		return dto.ErrUpdateUser
+	}
+
+	if err := s.authService.RevokeUserSessions(ctx, userID); err != nil {
+		return err
+	}
+
+	return s.userService.SendResetPasswordEmail(ctx, user.Email)
+}
+
+func (s *userManagementService) GetAuditLogs(ctx context.Context, req dto.AuditLogQuery) (dto.AuditLogPaginationResponse, error) {
+	limit := req.PerPage
+	if limit <= 0 {
+		limit = constants.ENUM_PAGINATION_LIMIT
+	}
+
+	page := req.Page
+	if page <= 0 {
+		page = constants.ENUM_PAGINATION_PAGE
+	}
+
+	logs, maxPage, count, err := s.auditLogRepo.GetAllPagination(req.TargetID, limit, page)
+	if err != nil {
+		return dto.AuditLogPaginationResponse{}, err
+	}
+
+	result := []dto.AuditLogResponse{}
+	for _, log := range logs {
+		res := dto.AuditLogResponse{
+			ID:         log.ID.String(),
+			ActorID:    log.ActorID.String(),
+			Action:     log.Action,
+			TargetType: log.TargetType,
+			TargetID:   log.TargetID,
+			Detail:     log.Detail,
+			CreatedAt:  log.CreatedAt,
+		}
+
+		if log.Actor != nil {
+			res.ActorName = log.Actor.Name
+		}
+
+		result = append(result, res)
+	}
+
+	return dto.AuditLogPaginationResponse{
+		Data: result,
+		PaginationMetadata: dto.PaginationMetadata{
+			Page:    page,
+			PerPage: limit,
+			MaxPage: maxPage,
+			Count:   count,
+		},
+	}, nil
+}
+
+func (s *userManagementService) GetLockouts(ctx context.Context) ([]dto.LockoutResponse, error) {
+	lockouts, err := s.lockoutRepo.GetActive()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []dto.LockoutResponse{}
+	for _, lockout := range lockouts {
+		result = append(result, toLockoutResponse(lockout))
+	}
+
+	return result, nil
+}
+
+func (s *userManagementService) ClearLockout(ctx context.Context, actorID string, id string) error {
+	lockout, err := s.lockoutRepo.GetByID(id)
+	if err != nil {
+		return dto.ErrLockoutNotFound
+	}
+
+	log, err := newAuditLog(actorID, constants.ENUM_AUDIT_LOCKOUT_CLEAR, constants.AUDIT_TARGET_LOCKOUT, id, lockout.Key)
+	if err != nil {
+		return err
+	}
+
+	return s.manageRepo.ClearLockout(id, log)
+}
+
+// admins can not lock themselves out by acting on their own account, and only
+// admins may act on other admins. The target is returned with its role loaded.
+func (s *userManagementService) getTarget(actorID string, userID string) (entity.User, bool, error) {
+	if actorID == userID {
+		return entity.User{}, false, dto.ErrManageSelf
+	}
+
+	actor, err := s.userRepo.GetUserById(actorID)
+	if err != nil {
+		return entity.User{}, false, dto.ErrUserNotFound
+	}
+
+	actorRole, err := s.roleRepo.GetRolebyId(actor.RoleID)
+	if err != nil {
+		return entity.User{}, false, dto.ErrRoleNotFound
+	}
+	actorIsAdmin := actorRole.Name == constants.ENUM_ROLE_ADMIN
+
+	user, err := s.userRepo.GetUserById(userID)
+	if err != nil {
+		return entity.User{}, false, dto.ErrUserNotFound
+	}
+
+	role, err := s.roleRepo.GetRolebyId(user.RoleID)
+	if err != nil {
+		return entity.User{}, false, dto.ErrRoleNotFound
+	}
+	user.Role = &role
+
+	if role.Name == constants.ENUM_ROLE_ADMIN && !actorIsAdmin {
+		return entity.User{}, false, dto.ErrManageAdminNotAllowed
+	}
+
+	return user, actorIsAdmin, nil
+}
+
+// shared by every service making privileged changes
+func writeAuditLog(repo repository.AuditLogRepository, actorID string, action string, targetType string, targetID string, detail string) error {
+	log, err := newAuditLog(actorID, action, targetType, targetID, detail)
+	if err != nil {
+		return err
+	}
+
+	if _, err := repo.Create(log); err != nil {
+		return dto.ErrAuditLog
+	}
+
+	return nil
+}
+
+func newAuditLog(actorID string, action string, targetType string, targetID string, detail string) (entity.AuditLog, error) {
+	actor, err := uuid.Parse(actorID)
+	if err != nil {
+		return entity.AuditLog{}, dto.ErrAuditLog
+	}
+
+	return entity.AuditLog{
+		ActorID:    actor,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Detail:     detail,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/google/uuid"
+)
+
+func TestAuditLogOmitsTargetEmail(t *testing.T) {
+	admin := entity.User{ID: uuid.New(), RoleID: "role-admin", Email: "admin@example.com"}
+	target := entity.User{ID: uuid.New(), RoleID: "role-user", Email: "target@example.com"}
+
+	manageRepo := &stubUserManagementRepository{}
+	service := NewUserManagementService(
+		stubUserRepository{users: map[string]entity.User{
+			admin.ID.String():  admin,
+			target.ID.String(): target,
+		}},
+		stubRoleRepository{roles: map[string]entity.Role{
+			"role-admin": {Name: constants.ENUM_ROLE_ADMIN},
+			"role-user":  {Name: constants.ENUM_ROLE_USER},
+		}},
+		nil,
+		manageRepo,
+		nil,
+		nil,
+		&stubAuthService{},
+	)
+
+	if err := service.ForceVerify(context.Background(), admin.ID.String(), target.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.Delete(context.Background(), admin.ID.String(), target.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(manageRepo.logs) != 2 {
+		t.Fatalf("got %d audit logs, want 2", len(manageRepo.logs))
+	}
+	// anonymising an account would otherwise leave the email behind in the log
+	for _, log := range manageRepo.logs {
+		if log.TargetID != target.ID.String() || strings.Contains(log.Detail, target.Email) {
+			t.Fatalf("unexpected audit log %+v", log)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"os"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
@@ -20,50 +21,49 @@ import (
 type (
 	UserService interface {
 		RegisterUser(ctx context.Context, req dto.UserRequest) (dto.UserResponse, error)
-		VerifyLogin(ctx context.Context, email string, password string) (entity.User, error)
-		UpdateUser(ctx context.Context, req dto.UserRequest, userId string) (dto.UserResponse, error)
+		VerifyLogin(ctx context.Context, email string, password string, ip string) (entity.User, error)
+		UpdateUser(ctx context.Context, req dto.UserUpdateRequest, userId string) (dto.UserResponse, error)
+		ChangePassword(ctx context.Context, userId string, req dto.UserChangePasswordRequest) (entity.User, error)
+		RequestEmailChange(ctx context.Context, userId string, req dto.UserChangeEmailRequest) error
+		ConfirmEmailChange(ctx context.Context, token string) error
 		Me(ctx context.Context, userId string, userRole string) (dto.UserResponse, error)
 		GetAllPagination(ctx context.Context, req dto.PaginationQuery) (dto.UserPaginationResponse, error)
-		generateVerificationEmail(userEmail string) (utils.Email, error)
+		generateVerificationEmail(user entity.User) (utils.Email, error)
 		SendVerifyEmail(ctx context.Context, email string) error
 		VerifyEmail(ctx context.Context, token string) error
-		generateResetPasswordEmail(userEmail string) (utils.Email, error)
+		generateResetPasswordEmail(user entity.User) (utils.Email, error)
 		SendResetPasswordEmail(ctx context.Context, email string) error
 		ResetPassword(ctx context.Context, token string, req dto.UserResetPasswordRequest) error
+		UnlockAccount(ctx context.Context, token string) error
+		PurgeExpiredTokens(ctx context.Context) error
 	}
 
 	userService struct {
-		userRepo repository.UserRepository
-		roleRepo repository.RoleRepository
+		userRepo       repository.UserRepository
+		roleRepo       repository.RoleRepository
+		userTokenRepo  repository.UserTokenRepository
+		lockoutService LockoutService
+		authService    AuthService
 	}
 )
 
-func NewUserService(ur repository.UserRepository, rr repository.RoleRepository) UserService {
+func NewUserService(ur repository.UserRepository, rr repository.RoleRepository, utr repository.UserTokenRepository, ls LockoutService, as AuthService) UserService {
 	return &userService{
-		userRepo: ur,
-		roleRepo: rr,
+		userRepo:       ur,
+		roleRepo:       rr,
+		userTokenRepo:  utr,
+		lockoutService: ls,
+		authService:    as,
 	}
 }
 
 func (s *userService) VerifyEmail(ctx context.Context, token string) error {
-	decrypted, err := utils.AESDecrypt(token)
+	userToken, err := consumeUserToken(s.userTokenRepo, token, constants.ENUM_TOKEN_PURPOSE_VERIFY_EMAIL)
 	if err != nil {
-		return dto.ErrDecryptToken
-	}
-
-	split := strings.Split(decrypted, "||")
-	if len(split) != 2 {
-		return dto.ErrInvalidToken
-	}
-
-	email := split[0]
-	expired := split[1]
-	expiredTime, _ := time.Parse("2006-01-02 15:04:05", expired)
-	if time.Now().After(expiredTime) {
-		return dto.ErrTokenExpired
+		return err
 	}
 
-	user, err := s.userRepo.GetUserByEmail(email)
+	user, err := s.userRepo.GetUserById(userToken.UserID.String())
 	if err != nil {
 		return dto.ErrUserNotFound
 	}
@@ -72,8 +72,7 @@ func (s *userService) VerifyEmail(ctx context.Context, token string) error {
 		return dto.ErrAccountAlreadyVerified
 	}
 
-	user.Verified = true
-	_, err = s.userRepo.UpdateUser(user)
+	_, err = s.userRepo.UpdateUser(entity.User{ID: user.ID, Verified: true})
 	if err != nil {
 		return dto.ErrVerifyEmail
 	}
@@ -91,7 +90,7 @@ func (s *userService) SendVerifyEmail(ctx context.Context, email string) error {
 		return dto.ErrAccountAlreadyVerified
 	}
 
-	emailData, err := s.generateVerificationEmail(email)
+	emailData, err := s.generateVerificationEmail(user)
 	if err != nil {
 		return dto.ErrGenerateVerificationEmail
 	}
@@ -119,7 +118,7 @@ func (s *userService) SendResetPasswordEmail(ctx context.Context, email string)
 		return dto.ErrAdminNotAllowedResetPassword
 	}
 
-	emailData, err := s.generateResetPasswordEmail(email)
+	emailData, err := s.generateResetPasswordEmail(user)
 	if err != nil {
 		return dto.ErrGenerateResetPasswordEmail
 	}
@@ -167,9 +166,9 @@ func (s *userService) RegisterUser(ctx context.Context, req dto.UserRequest) (dt
 	}, nil
 }
 
-func (s *userService) generateResetPasswordEmail(userEmail string) (utils.Email, error) {
-	expired := time.Now().Add(24 * time.Hour).Format("2006-01-02 15:04:05")
-	token, err := utils.AESEncrypt(userEmail + "||" + expired)
+func (s *userService) generateResetPasswordEmail(user entity.User) (utils.Email, error) {
+	userEmail := user.Email
+	token, err := issueUserToken(s.userTokenRepo, user, constants.ENUM_TOKEN_PURPOSE_RESET_PASSWORD, "")
 	if err != nil {
 		return utils.Email{}, err
 	}
@@ -206,9 +205,9 @@ func (s *userService) generateResetPasswordEmail(userEmail string) (utils.Email,
 	}, nil
 }
 
-func (s *userService) generateVerificationEmail(userEmail string) (utils.Email, error) {
-	expired := time.Now().Add(24 * time.Hour).Format("2006-01-02 15:04:05")
-	token, err := utils.AESEncrypt(userEmail + "||" + expired)
+func (s *userService) generateVerificationEmail(user entity.User) (utils.Email, error) {
+	userEmail := user.Email
+	token, err := issueUserToken(s.userTokenRepo, user, constants.ENUM_TOKEN_PURPOSE_VERIFY_EMAIL, "")
 	if err != nil {
 		return utils.Email{}, err
 	}
@@ -245,52 +244,194 @@ func (s *userService) generateVerificationEmail(userEmail string) (utils.Email,
 	}, nil
 }
 
-func (s *userService) VerifyLogin(ctx context.Context, email string, password string) (entity.User, error) {
+// every failure path costs one bcrypt comparison and returns the same error,
+// the verified and suspended states are only revealed to the right password
+func (s *userService) VerifyLogin(ctx context.Context, email string, password string, ip string) (entity.User, error) {
+	if err := s.lockoutService.Check(ctx, email, ip); err != nil {
+		return entity.User{}, err
+	}
+
 	user, err := s.userRepo.GetUserByEmail(email)
 	if err != nil {
+		helpers.CheckPassword(dummyPasswordHash(), []byte(password))
+		s.lockoutService.RecordFailure(ctx, email, ip)
 		return entity.User{}, dto.ErrCredentialsNotMatched
 	}
 
+	checkPassword, err := helpers.CheckPassword(user.Password, []byte(password))
+	if err != nil || !checkPassword {
+		s.lockoutService.RecordFailure(ctx, email, ip)
+		return entity.User{}, dto.ErrCredentialsNotMatched
+	}
+
+	s.lockoutService.RecordSuccess(ctx, email)
+
 	if !user.Verified {
 		return entity.User{}, dto.ErrAccountNotVerified
 	}
 
-	checkPassword, err := helpers.CheckPassword(user.Password, []byte(password))
-	if err != nil || !checkPassword {
-		return entity.User{}, dto.ErrCredentialsNotMatched
+	if user.SuspendedAt != nil {
+		return entity.User{}, dto.ErrAccountSuspended
 	}
 
 	return user, nil
 }
 
-func (s *userService) UpdateUser(ctx context.Context, req dto.UserRequest, userId string) (dto.UserResponse, error) {
+var (
+	dummyHash     string
+	dummyHashOnce sync.Once
+)
+
+// compared against when the email is unknown, so the response takes as long as a real check
+func dummyPasswordHash() string {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = helpers.HashPassword("TEDxITS dummy password")
+	})
+	return dummyHash
+}
+
+// only the profile, password and email each have their own verified flow
+func (s *userService) UpdateUser(ctx context.Context, req dto.UserUpdateRequest, userId string) (dto.UserResponse, error) {
 	user, err := s.userRepo.GetUserById(userId)
 	if err != nil {
 		return dto.UserResponse{}, dto.ErrUserNotFound
 	}
 
-	data := entity.User{
-		ID:       user.ID,
-		Name:     req.Name,
-		Role:     user.Role,
-		Email:    req.Email,
-		Password: req.Password,
+	set := func(field *string, value *string) {
+		if value != nil {
+			*field = strings.TrimSpace(*value)
+		}
+	}
+
+	user.Name = req.Name
+	if req.Handphone != nil {
+		user.Handphone = utils.NormalizeHandphone(*req.Handphone)
+	}
+	if req.Birthdate != nil {
+		user.Birthdate = req.Birthdate
+	}
+	set(&user.Institute, req.Institute)
+	set(&user.Department, req.Department)
+	set(&user.StudentID, req.StudentID)
+	set(&user.Batch, req.Batch)
+
+	if err := validateProfile(user); err != nil {
+		return dto.UserResponse{}, err
+	}
+
+	// one write, so the name is never saved without the profile it came with
+	if err := s.userRepo.UpdateProfile(user); err != nil {
+		return dto.UserResponse{}, dto.ErrUpdateUser
 	}
 
-	userUpdate, err := s.userRepo.UpdateUser(data)
+	role, err := s.roleRepo.GetRolebyId(user.RoleID)
 	if err != nil {
 		return dto.UserResponse{}, dto.ErrUpdateUser
 	}
 
 	return dto.UserResponse{
-		ID:         userUpdate.ID.String(),
-		Name:       userUpdate.Name,
-		Role:       ctx.Value("user_role").(string),
-		Email:      userUpdate.Email,
-		IsVerified: userUpdate.Verified,
+		ID:         user.ID.String(),
+		Name:       user.Name,
+		Role:       role.Name,
+		Email:      user.Email,
+		IsVerified: user.Verified,
+		Profile:    toUserProfile(user),
 	}, nil
 }
 
+func (s *userService) ChangePassword(ctx context.Context, userId string, req dto.UserChangePasswordRequest) (entity.User, error) {
+	user, err := s.userRepo.GetUserById(userId)
+	if err != nil {
+		return entity.User{}, dto.ErrUserNotFound
+	}
+
+	checkPassword, err := helpers.CheckPassword(user.Password, []byte(req.CurrentPassword))
+	if err != nil || !checkPassword {
+		return entity.User{}, dto.ErrPasswordNotMatched
+	}
+
+	hashedPassword, err := helpers.HashPassword(req.NewPassword)
+	if err != nil {
+		return entity.User{}, dto.ErrHashPassword
+	}
+
+	if _, err := s.userRepo.UpdateUser(entity.User{ID: user.ID, Password: hashedPassword}); err != nil {
+		return entity.User{}, dto.ErrUpdateUser
+	}
+
+	// whoever knew the old password may already hold a session
+	if err := s.authService.RevokeUserSessions(ctx, userId); err != nil {
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// nothing changes until the link sent to the new address is opened
+func (s *userService) RequestEmailChange(ctx context.Context, userId string, req dto.UserChangeEmailRequest) error {
+	user, err := s.userRepo.GetUserById(userId)
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	checkPassword, err := helpers.CheckPassword(user.Password, []byte(req.Password))
+	if err != nil || !checkPassword {
+		return dto.ErrPasswordNotMatched
+	}
+
+	email := strings.TrimSpace(req.Email)
+	if strings.EqualFold(email, user.Email) {
+		return dto.ErrSameEmail
+	}
+
+	if !utils.ValidateEmail(email) {
+		return dto.ErrEmailFormatInvalid
+	}
+
+	if exist, _ := s.userRepo.CheckEmailExist(email); exist {
+		return dto.ErrEmailAlreadyExists
+	}
+
+	emailData, err := s.generateChangeEmail(user, email)
+	if err != nil {
+		return dto.ErrGenerateChangeEmail
+	}
+
+	if err := utils.SendMail(emailData); err != nil {
+		return dto.ErrSendEmail
+	}
+
+	return nil
+}
+
+func (s *userService) ConfirmEmailChange(ctx context.Context, token string) error {
+	userToken, err := consumeUserToken(s.userTokenRepo, token, constants.ENUM_TOKEN_PURPOSE_CHANGE_EMAIL)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetUserById(userToken.UserID.String())
+	if err != nil {
+		return dto.ErrUserNotFound
+	}
+
+	newEmail := userToken.Payload
+	if exist, _ := s.userRepo.CheckEmailExist(newEmail); exist {
+		return dto.ErrEmailAlreadyExists
+	}
+
+	if _, err := s.userRepo.UpdateUser(entity.User{ID: user.ID, Email: newEmail}); err != nil {
+		return dto.ErrUpdateUser
+	}
+
+	// the old address is told in case the change was not theirs
+	if emailData, err := generateEmailChangedNotice(user, newEmail); err == nil {
+		utils.SendMail(emailData)
+	}
+
+	return nil
+}
+
 func (s *userService) Me(ctx context.Context, userId string, userRole string) (dto.UserResponse, error) {
 	user, err := s.userRepo.GetUserById(userId)
 	if err != nil {
@@ -303,6 +444,7 @@ func (s *userService) Me(ctx context.Context, userId string, userRole string) (d
 		Role:       userRole,
 		Email:      user.Email,
 		IsVerified: user.Verified,
+		Profile:    toUserProfile(user),
 	}, nil
 }
 
@@ -328,11 +470,12 @@ func (s *userService) GetAllPagination(ctx context.Context, req dto.PaginationQu
 	var result []dto.UserResponse
 	for _, user := range users {
 		result = append(result, dto.UserResponse{
-			ID:         user.ID.String(),
-			Name:       user.Name,
-			Email:      user.Email,
-			RoleID:     user.RoleID,
-			IsVerified: user.Verified,
+			ID:          user.ID.String(),
+			Name:        user.Name,
+			Email:       user.Email,
+			RoleID:      user.RoleID,
+			IsVerified:  user.Verified,
+			IsSuspended: user.SuspendedAt != nil,
 		})
 	}
 
@@ -348,24 +491,12 @@ func (s *userService) GetAllPagination(ctx context.Context, req dto.PaginationQu
 }
 
 func (s *userService) ResetPassword(ctx context.Context, token string, req dto.UserResetPasswordRequest) error {
-	decrypted, err := utils.AESDecrypt(token)
+	userToken, err := consumeUserToken(s.userTokenRepo, token, constants.ENUM_TOKEN_PURPOSE_RESET_PASSWORD)
 	if err != nil {
-		return dto.ErrDecryptToken
-	}
-
-	split := strings.Split(decrypted, "||")
-	if len(split) != 2 {
-		return dto.ErrInvalidToken
-	}
-
-	email := split[0]
-	expired := split[1]
-	expiredTime, _ := time.Parse("2006-01-02 15:04:05", expired)
-	if time.Now().After(expiredTime) {
-		return dto.ErrTokenExpired
+		return err
 	}
 
-	user, err := s.userRepo.GetUserByEmail(email)
+	user, err := s.userRepo.GetUserById(userToken.UserID.String())
 	if err != nil {
 		return dto.ErrUserNotFound
 	}
@@ -384,11 +515,122 @@ func (s *userService) ResetPassword(ctx context.Context, token string, req dto.U
 		return dto.ErrHashPassword
 	}
 
-	user.Password = hashedPassword
-	_, err = s.userRepo.UpdateUser(user)
+	_, err = s.userRepo.UpdateUser(entity.User{ID: user.ID, Password: hashedPassword})
 	if err != nil {
 		return dto.ErrUpdateUser
 	}
 
-	return nil
+	// a reset usually means the password leaked, so the sessions it opened go too
+	return s.authService.RevokeUserSessions(ctx, user.ID.String())
+}
+
+func (s *userService) UnlockAccount(ctx context.Context, token string) error {
+	return s.lockoutService.Unlock(ctx, token)
+}
+
+func (s *userService) generateChangeEmail(user entity.User, newEmail string) (utils.Email, error) {
+	token, err := issueUserToken(s.userTokenRepo, user, constants.ENUM_TOKEN_PURPOSE_CHANGE_EMAIL, newEmail)
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	readHtml, err := os.ReadFile("./utils/template/mail_change_email.html")
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	tmpl, err := template.New("custom").Parse(string(readHtml))
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	var strMail bytes.Buffer
+	if err := tmpl.Execute(&strMail, struct {
+		Name        string
+		Email       string
+		ConfirmLink string
+	}{
+		Name:        user.Name,
+		Email:       newEmail,
+		ConfirmLink: constants.BASE_URL + "/auth/confirm-email?token=" + token,
+	}); err != nil {
+		return utils.Email{}, err
+	}
+
+	return utils.Email{
+		Email:   newEmail,
+		Subject: "Confirm Your New Email - TEDxITS",
+		Body:    strMail.String(),
+	}, nil
+}
+
+func generateEmailChangedNotice(user entity.User, newEmail string) (utils.Email, error) {
+	readHtml, err := os.ReadFile("./utils/template/mail_email_changed.html")
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	tmpl, err := template.New("custom").Parse(string(readHtml))
+	if err != nil {
+		return utils.Email{}, err
+	}
+
+	var strMail bytes.Buffer
+	if err := tmpl.Execute(&strMail, struct {
+		Name     string
+		NewEmail string
+	}{
+		Name:     user.Name,
+		NewEmail: newEmail,
+	}); err != nil {
+		return utils.Email{}, err
+	}
+
+	return utils.Email{
+		Email:   user.Email,
+		Subject: "Your Account Email Was Changed - TEDxITS",
+		Body:    strMail.String(),
+	}, nil
+}
+
+func (s *userService) PurgeExpiredTokens(ctx context.Context) error {
+	return s.userTokenRepo.PurgeExpired(time.Now())
+}
+
+// the raw token only ever leaves in the email, issuing one voids earlier links of the same purpose.
+// Shared by every service that sends a single-use link.
+func issueUserToken(repo repository.UserTokenRepository, user entity.User, purpose string, payload string) (string, error) {
+	token, err := utils.GenRandomToken()
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := repo.Create(entity.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: utils.HashToken(token),
+		Payload:   payload,
+		ExpiresAt: time.Now().Add(constants.USER_TOKEN_EXPIRE_TIME),
+	}); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func consumeUserToken(repo repository.UserTokenRepository, token string, purpose string) (entity.UserToken, error) {
+	userToken, err := repo.GetByHash(utils.HashToken(token))
+	if err != nil || userToken.Purpose != purpose || userToken.ConsumedAt != nil {
+		return entity.UserToken{}, dto.ErrInvalidToken
+	}
+
+	if time.Now().After(userToken.ExpiresAt) {
+		return entity.UserToken{}, dto.ErrTokenExpired
+	}
+
+	if err := repo.Consume(userToken); err != nil {
+		return entity.UserToken{}, dto.ErrInvalidToken
+	}
+
+	return userToken, nil
 }
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TEDxITS/website-backend-2024/constants"
+	"github.com/TEDxITS/website-backend-2024/dto"
+	"github.com/TEDxITS/website-backend-2024/entity"
+	"github.com/TEDxITS/website-backend-2024/helpers"
+	"github.com/TEDxITS/website-backend-2024/utils"
+	"github.com/google/uuid"
+)
+
+func newTestUserService(t *testing.T, password string) (*userService, entity.User, *stubAuthService) {
+	t.Helper()
+
+	hashed, err := helpers.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	role := entity.Role{ID: uuid.New(), Name: constants.ENUM_ROLE_USER}
+	user := entity.User{ID: uuid.New(), RoleID: role.ID.String(), Email: "user@example.com", Password: hashed, Verified: true}
+	auth := &stubAuthService{}
+
+	return &userService{
+		userRepo:      stubUserRepository{users: map[string]entity.User{user.ID.String(): user}},
+		roleRepo:      stubRoleRepository{roles: map[string]entity.Role{role.ID.String(): role}},
+		userTokenRepo: stubUserTokenRepository{tokens: map[string]entity.UserToken{}},
+		authService:   auth,
+	}, user, auth
+}
+
+func TestResetPasswordRevokesSessions(t *testing.T) {
+	s, user, auth := newTestUserService(t, "old-password")
+
+	token := "reset-token"
+	s.userTokenRepo.(stubUserTokenRepository).tokens[utils.HashToken(token)] = entity.UserToken{
+		UserID:    user.ID,
+		Purpose:   constants.ENUM_TOKEN_PURPOSE_RESET_PASSWORD,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	if err := s.ResetPassword(context.Background(), token, dto.UserResetPasswordRequest{Password: "new-password"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(auth.revoked) != 1 || auth.revoked[0] != user.ID.String() {
+		t.Fatalf("revoked %v, want the sessions of %s", auth.revoked, user.ID)
+	}
+
+	updated, _ := s.userRepo.GetUserById(user.ID.String())
+	if ok, _ := helpers.CheckPassword(updated.Password, []byte("new-password")); !ok {
+		t.Fatal("password was not changed")
+	}
+
+	// the link only works once
+	if err := s.ResetPassword(context.Background(), token, dto.UserResetPasswordRequest{Password: "again"}); err != dto.ErrInvalidToken {
+		t.Fatalf("reused link: err = %v, want %v", err, dto.ErrInvalidToken)
+	}
+}
+
+func TestChangePasswordRevokesSessions(t *testing.T) {
+	s, user, auth := newTestUserService(t, "old-password")
+
+	_, err := s.ChangePassword(context.Background(), user.ID.String(), dto.UserChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "new-password"})
+	if err != dto.ErrPasswordNotMatched || len(auth.revoked) != 0 {
+		t.Fatalf("wrong password: err = %v, revoked %v", err, auth.revoked)
+	}
+
+	if _, err := s.ChangePassword(context.Background(), user.ID.String(), dto.UserChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(auth.revoked) != 1 || auth.revoked[0] != user.ID.String() {
+		t.Fatalf("revoked %v, want the sessions of %s", auth.revoked, user.ID)
+	}
+}
+
+// fails the test when the name is written apart from the profile
+type profileOnlyUserRepository struct {
+	stubUserRepository
+	t *testing.T
+}
+
+func (r profileOnlyUserRepository) UpdateUser(user entity.User) (entity.User, error) {
+	r.t.Fatal("UpdateUser called, the name has to be saved with the profile")
+	return entity.User{}, nil
+}
+
+func TestUpdateUserWritesNameWithProfile(t *testing.T) {
+	s, user, _ := newTestUserService(t, "password")
+	users := s.userRepo.(stubUserRepository)
+	s.userRepo = profileOnlyUserRepository{stubUserRepository: users, t: t}
+
+	institute := "ITS"
+	res, err := s.UpdateUser(context.Background(), dto.UserUpdateRequest{Name: "Renamed", Institute: &institute}, user.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored := users.users[user.ID.String()]
+	if res.Name != "Renamed" || stored.Name != "Renamed" || stored.Institute != "ITS" {
+		t.Fatalf("got response %+v, stored %+v", res, stored)
+	}
+}
@@ -8,20 +8,34 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"os"
-
-	_ "github.com/joho/godotenv/autoload"
 )
 
 // https://www.melvinvivas.com/how-to-encrypt-and-decrypt-data-using-aes
 
+// set once on startup, kept apart from the keys used to sign tokens
+var aesKey []byte
+
+func SetAESKey(hexKey string) error {
+	key, err := hex.DecodeString(hexKey)
+	if err != nil {
+		return err
+	}
+
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return errors.New("aes key must be 16, 24 or 32 bytes")
+	}
+
+	aesKey = key
+	return nil
+}
+
 func AESEncrypt(stringToEncrypt string) (encryptedString string, err error) {
-	//Since the key is in string, we need to convert decode it to bytes
-	key, _ := hex.DecodeString(os.Getenv("JWT_SECRET"))
 	plaintext := []byte(stringToEncrypt)
 
 	//Create a new Cipher Block from the key
-	block, err := aes.NewCipher(key)
+	block, err := aes.NewCipher(aesKey)
 	if err != nil {
 		return "", err
 	}
@@ -53,11 +67,13 @@ func AESDecrypt(encryptedString string) (decryptedString string, err error) {
 		}
 	}()
 
-	key, _ := hex.DecodeString(os.Getenv("JWT_SECRET"))
-	enc, _ := hex.DecodeString(encryptedString)
+	enc, err := hex.DecodeString(encryptedString)
+	if err != nil {
+		return "", err
+	}
 
 	//Create a new Cipher Block from the key
-	block, err := aes.NewCipher(key)
+	block, err := aes.NewCipher(aesKey)
 	if err != nil {
 		return "", err
 	}
@@ -70,6 +86,9 @@ func AESDecrypt(encryptedString string) (decryptedString string, err error) {
 
 	//Get the nonce size
 	nonceSize := aesGCM.NonceSize()
+	if len(enc) < nonceSize {
+		return "", errors.New("error in decrypting")
+	}
 
 	//Extract the nonce from the encrypted data
 	nonce, ciphertext := enc[:nonceSize], enc[nonceSize:]
@@ -77,7 +96,7 @@ func AESDecrypt(encryptedString string) (decryptedString string, err error) {
 	//Decrypt the data
 	plaintext, err := aesGCM.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
-		return "", nil
+		return "", err
 	}
 
 	return string(plaintext), nil
@@ -1,8 +1,12 @@
 package utils
 
 import (
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"math/rand"
+	"strings"
 )
 
 // generate a length of 4 random character and number
@@ -19,3 +23,42 @@ func GenUniqueCode() string {
 
 	return fmt.Sprintf("%c%c%c%c", letter1, number1, letter2, number2)
 }
+
+// generate a verification code in the form of TEDX-XXXX-XXXX
+// using crypto/rand since it is publicly verifiable
+func GenCertificateCode() (string, error) {
+	b := make([]byte, 4)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := strings.ToUpper(hex.EncodeToString(b))
+	return fmt.Sprintf("TEDX-%s-%s", code[:4], code[4:]), nil
+}
+
+// generate a random url safe token, only the hash
+// of the token should be persisted in the database
+func GenRandomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// generate a one-time recovery code in the form of XXXXX-XXXXX
+func GenRecoveryCode() (string, error) {
+	b := make([]byte, 5)
+	if _, err := cryptorand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := strings.ToUpper(hex.EncodeToString(b))
+	return fmt.Sprintf("%s-%s", code[:5], code[5:]), nil
+}
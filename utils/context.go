@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+// keeps the values of a request context without being cancelled with it,
+// for work that carries on after the response was sent
+func DetachContext(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (d detachedContext) Value(key any) any {
+	return d.parent.Value(key)
+}
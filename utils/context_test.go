@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"context"
+	"testing"
+)
+
+type contextKey struct{}
+
+func TestDetachContext(t *testing.T) {
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), contextKey{}, "value"))
+	detached := DetachContext(parent)
+	cancel()
+
+	if detached.Err() != nil || detached.Done() != nil {
+		t.Fatal("detached context was cancelled with its parent")
+	}
+	if detached.Value(contextKey{}) != "value" {
+		t.Fatal("detached context lost the parent's values")
+	}
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEscapeExportCell(t *testing.T) {
+	tests := map[string]string{
+		"=HYPERLINK(\"http://evil.test\")": "'=HYPERLINK(\"http://evil.test\")",
+		"+1+1":                             "'+1+1",
+		"-2+3":                             "'-2+3",
+		"@SUM(A1)":                         "'@SUM(A1)",
+		"\t=1":                             "'\t=1",
+		"\r=1":                             "'\r=1",
+		"Jane Doe":                         "Jane Doe",
+		"a=b":                              "a=b",
+		"":                                 "",
+	}
+
+	for cell, want := range tests {
+		if got := EscapeExportCell(cell); got != want {
+			t.Errorf("EscapeExportCell(%q) = %q, want %q", cell, got, want)
+		}
+	}
+}
+
+func TestCSVExportWriterEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVExportWriter(&buf)
+	if err := w.WriteRow([]string{"name", "=1+1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := buf.String(); got != "name,'=1+1\n" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestXLSXExportWriterKeepsValues(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewXLSXExportWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]string{"+6281234567890", "<b>"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range zr.File {
+		if f.Name != "xl/worksheets/sheet1.xml" {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		sheet, _ := io.ReadAll(rc)
+		rc.Close()
+
+		// inline strings are never run as formulas, so the value is written as is
+		if !strings.Contains(string(sheet), ">+6281234567890<") || !strings.Contains(string(sheet), "&lt;b&gt;") {
+			t.Fatalf("unexpected cells: %s", sheet)
+		}
+		return
+	}
+
+	t.Fatal("worksheet missing")
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ICS_CONTENT_TYPE = "text/calendar; charset=utf-8"
+	ICS_TIMEZONE     = "Asia/Jakarta"
+	ICS_TIME_FORMAT  = "20060102T150405"
+)
+
+type ICSEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+	Start       time.Time
+	End         time.Time
+	Updated     time.Time
+}
+
+// event times are stored as Jakarta wall clock, so they are written with
+// the TZID as is instead of being converted to UTC
+func BuildICS(name string, events []ICSEvent) []byte {
+	var b strings.Builder
+
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//TEDxITS//Schedule//EN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText(name))
+	writeICSLine(&b, "X-WR-TIMEZONE:"+ICS_TIMEZONE)
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	writeICSLine(&b, "X-PUBLISHED-TTL:PT1H")
+
+	writeICSLine(&b, "BEGIN:VTIMEZONE")
+	writeICSLine(&b, "TZID:"+ICS_TIMEZONE)
+	writeICSLine(&b, "BEGIN:STANDARD")
+	writeICSLine(&b, "DTSTART:19700101T000000")
+	writeICSLine(&b, "TZOFFSETFROM:+0700")
+	writeICSLine(&b, "TZOFFSETTO:+0700")
+	writeICSLine(&b, "TZNAME:WIB")
+	writeICSLine(&b, "END:STANDARD")
+	writeICSLine(&b, "END:VTIMEZONE")
+
+	for _, event := range events {
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+event.UID)
+		writeICSLine(&b, "DTSTAMP:"+event.Updated.UTC().Format(ICS_TIME_FORMAT)+"Z")
+		writeICSLine(&b, "LAST-MODIFIED:"+event.Updated.UTC().Format(ICS_TIME_FORMAT)+"Z")
+		// calendar clients only replace an event when the sequence goes up
+		writeICSLine(&b, "SEQUENCE:"+strconv.FormatInt(event.Updated.Unix(), 10))
+		writeICSLine(&b, "DTSTART;TZID="+ICS_TIMEZONE+":"+event.Start.Format(ICS_TIME_FORMAT))
+		writeICSLine(&b, "DTEND;TZID="+ICS_TIMEZONE+":"+event.End.Format(ICS_TIME_FORMAT))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(event.Summary))
+		if event.Description != "" {
+			writeICSLine(&b, "DESCRIPTION:"+escapeICSText(event.Description))
+		}
+		if event.Location != "" {
+			writeICSLine(&b, "LOCATION:"+escapeICSText(event.Location))
+		}
+		if event.URL != "" {
+			writeICSLine(&b, "URL:"+event.URL)
+		}
+		writeICSLine(&b, "END:VEVENT")
+	}
+
+	writeICSLine(&b, "END:VCALENDAR")
+
+	return []byte(b.String())
+}
+
+func escapeICSText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// lines longer than 75 octets are folded, without splitting a utf-8 character
+func writeICSLine(b *strings.Builder, line string) {
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74
+	}
+
+	b.WriteString(line + "\r\n")
+}
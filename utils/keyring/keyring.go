@@ -0,0 +1,234 @@
+package keyring
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// RSA keys below this size are refused outright
+const minRSABits = 2048
+
+var ErrNoActiveKey = errors.New("keyring has no active signing key")
+
+type (
+	Key struct {
+		ID     string
+		Method jwt.SigningMethod
+
+		// empty for retired keys that are only kept to verify tokens still in flight
+		Private crypto.PrivateKey
+		Public  crypto.PublicKey
+	}
+
+	// every key is published and accepted, only the active one signs. Rotating
+	// means adding the new key, switching the active id once it is published,
+	// and removing the old one after the last token signed with it expired.
+	Keyring struct {
+		active string
+		keys   map[string]Key
+	}
+
+	JWK struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		Alg string `json:"alg"`
+		N   string `json:"n,omitempty"`
+		E   string `json:"e,omitempty"`
+		Crv string `json:"crv,omitempty"`
+		X   string `json:"x,omitempty"`
+	}
+
+	JWKSet struct {
+		Keys []JWK `json:"keys"`
+	}
+)
+
+func New() *Keyring {
+	return &Keyring{
+		keys: make(map[string]Key),
+	}
+}
+
+// every *.pem file in the directory is a key named after the file,
+// a private key (PKCS#8 or PKCS#1) or a public key (PKIX) for a retired one
+func LoadDir(dir string) (*Keyring, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	r := New()
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		id := strings.TrimSuffix(filepath.Base(path), ".pem")
+		if err := r.Add(id, data); err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+	}
+
+	return r, nil
+}
+
+func (r *Keyring) Add(id string, data []byte) error {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return errors.New("no PEM block found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return fmt.Errorf("unsupported PEM type %q", block.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	key, err := newKey(id, parsed)
+	if err != nil {
+		return err
+	}
+
+	r.keys[id] = key
+	return nil
+}
+
+// an Ed25519 key that only lives as long as the process, for development
+func (r *Keyring) Generate(id string) error {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	key, err := newKey(id, private)
+	if err != nil {
+		return err
+	}
+
+	r.keys[id] = key
+	return nil
+}
+
+func (r *Keyring) SetActive(id string) error {
+	key, ok := r.keys[id]
+	if !ok {
+		return fmt.Errorf("key %q not found", id)
+	}
+
+	if key.Private == nil {
+		return fmt.Errorf("key %q has no private part to sign with", id)
+	}
+
+	r.active = id
+	return nil
+}
+
+func (r *Keyring) Active() (Key, error) {
+	key, ok := r.keys[r.active]
+	if !ok {
+		return Key{}, ErrNoActiveKey
+	}
+	return key, nil
+}
+
+func (r *Keyring) Get(id string) (Key, bool) {
+	key, ok := r.keys[id]
+	return key, ok
+}
+
+// ids of the keys that can sign, in a stable order
+func (r *Keyring) SigningIDs() []string {
+	ids := []string{}
+	for id, key := range r.keys {
+		if key.Private != nil {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+func (r *Keyring) JWKS() JWKSet {
+	ids := make([]string, 0, len(r.keys))
+	for id := range r.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	set := JWKSet{Keys: []JWK{}}
+	for _, id := range ids {
+		key := r.keys[id]
+		jwk := JWK{
+			Kid: key.ID,
+			Use: "sig",
+			Alg: key.Method.Alg(),
+		}
+
+		switch public := key.Public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+func newKey(id string, parsed any) (Key, error) {
+	if id == "" {
+		return Key{}, errors.New("key id is empty")
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSABits {
+			return Key{}, fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+		}
+		return Key{ID: id, Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSABits {
+			return Key{}, fmt.Errorf("RSA key must be at least %d bits", minRSABits)
+		}
+		return Key{ID: id, Method: jwt.SigningMethodRS256, Public: k}, nil
+	case ed25519.PrivateKey:
+		return Key{ID: id, Method: jwt.SigningMethodEdDSA, Private: k, Public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return Key{ID: id, Method: jwt.SigningMethodEdDSA, Public: k}, nil
+	}
+
+	return Key{}, fmt.Errorf("unsupported key type %T", parsed)
+}
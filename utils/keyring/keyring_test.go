@@ -0,0 +1,132 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writePEM(t *testing.T, dir string, name string, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name+".pem"), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newRSAKey(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// a current RSA key next to a retired Ed25519 key that only verifies
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+
+	current := newRSAKey(t, 2048)
+	der, err := x509.MarshalPKCS8PrivateKey(current)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, dir, "2024-02", "PRIVATE KEY", der)
+
+	retired, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err = x509.MarshalPKIXPublicKey(retired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writePEM(t, dir, "2024-01", "PUBLIC KEY", der)
+
+	ring, err := LoadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := ring.SigningIDs(); !reflect.DeepEqual(ids, []string{"2024-02"}) {
+		t.Fatalf("signing ids = %v", ids)
+	}
+	if err := ring.SetActive("2024-01"); err == nil {
+		t.Fatal("a public key was made the signing key")
+	}
+	if err := ring.SetActive("2024-02"); err != nil {
+		t.Fatal(err)
+	}
+	if key, err := ring.Active(); err != nil || key.ID != "2024-02" {
+		t.Fatalf("active = %+v, %v", key, err)
+	}
+}
+
+func TestAddRejectsWeakRSA(t *testing.T) {
+	ring := New()
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(newRSAKey(t, 1024))})
+
+	if err := ring.Add("weak", data); err == nil {
+		t.Fatal("a 1024 bit key was accepted")
+	}
+}
+
+func TestActiveWithoutKey(t *testing.T) {
+	if _, err := New().Active(); err != ErrNoActiveKey {
+		t.Fatalf("err = %v, want %v", err, ErrNoActiveKey)
+	}
+}
+
+// every key is published, so tokens signed before a rotation still verify
+func TestJWKS(t *testing.T) {
+	ring := New()
+
+	rsaKey := newRSAKey(t, 2048)
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ring.Add("old", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})); err != nil {
+		t.Fatal(err)
+	}
+	if err := ring.Generate("new"); err != nil {
+		t.Fatal(err)
+	}
+
+	set := ring.JWKS()
+	if len(set.Keys) != 2 || set.Keys[0].Kid != "new" || set.Keys[1].Kid != "old" {
+		t.Fatalf("unexpected key set %+v", set)
+	}
+
+	okp := set.Keys[0]
+	newKey, _ := ring.Get("new")
+	if okp.Kty != "OKP" || okp.Crv != "Ed25519" || okp.Alg != "EdDSA" || okp.Use != "sig" ||
+		okp.X != base64.RawURLEncoding.EncodeToString(newKey.Public.(ed25519.PublicKey)) {
+		t.Fatalf("unexpected Ed25519 jwk %+v", okp)
+	}
+
+	jwk := set.Keys[1]
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jwk.Kty != "RSA" || jwk.Alg != "RS256" || new(big.Int).SetBytes(n).Cmp(rsaKey.N) != 0 ||
+		new(big.Int).SetBytes(e).Int64() != int64(rsaKey.E) {
+		t.Fatalf("unexpected RSA jwk %+v", jwk)
+	}
+}
@@ -0,0 +1,296 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+var (
+	ErrDiscovery      = errors.New("failed to load the provider configuration")
+	ErrExchange       = errors.New("failed to exchange the authorization code")
+	ErrIDTokenInvalid = errors.New("id token invalid")
+	ErrNonceMismatch  = errors.New("id token nonce mismatch")
+)
+
+type (
+	// anything that speaks OpenID Connect discovery can be plugged in,
+	// Google in production or a local mock server while developing
+	Provider interface {
+		Name() string
+		AuthCodeURL(redirectURI string, state string, nonce string, codeChallenge string) (string, error)
+		Exchange(ctx context.Context, redirectURI string, code string, codeVerifier string, nonce string) (Claims, error)
+	}
+
+	Config struct {
+		Name         string
+		Issuer       string
+		ClientID     string
+		ClientSecret string
+		Scopes       []string
+		HTTPClient   *http.Client
+	}
+
+	Claims struct {
+		Subject       string
+		Email         string
+		EmailVerified bool
+		Name          string
+	}
+
+	provider struct {
+		config Config
+
+		mu        sync.Mutex
+		discovery *discoveryDocument
+		keys      map[string]*rsa.PublicKey
+	}
+
+	discoveryDocument struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+
+	tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+
+	jsonWebKeySet struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+)
+
+func NewProvider(config Config) Provider {
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &provider{
+		config: config,
+		keys:   make(map[string]*rsa.PublicKey),
+	}
+}
+
+func (p *provider) Name() string {
+	return p.config.Name
+}
+
+func (p *provider) AuthCodeURL(redirectURI string, state string, nonce string, codeChallenge string) (string, error) {
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {strings.Join(p.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge},
+		"code_challenge_method": {"S256"},
+	}
+
+	return discovery.AuthorizationEndpoint + "?" + query.Encode(), nil
+}
+
+func (p *provider) Exchange(ctx context.Context, redirectURI string, code string, codeVerifier string, nonce string) (Claims, error) {
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		return Claims{}, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {p.config.ClientID},
+		"client_secret": {p.config.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Claims{}, ErrExchange
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var token tokenResponse
+	if err := p.doJSON(req, &token); err != nil || token.IDToken == "" {
+		return Claims{}, ErrExchange
+	}
+
+	return p.verifyIDToken(token.IDToken, nonce)
+}
+
+// the id token came straight from the token endpoint, but its signature
+// is still checked so a misbehaving proxy can not forge an identity
+func (p *provider) verifyIDToken(idToken string, nonce string) (Claims, error) {
+	discovery, err := p.getDiscovery()
+	if err != nil {
+		return Claims{}, err
+	}
+
+	token, err := jwt.Parse(idToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		return p.getKey(discovery.JWKSURI, kid)
+	})
+	if err != nil || !token.Valid {
+		return Claims{}, ErrIDTokenInvalid
+	}
+
+	claims := token.Claims.(jwt.MapClaims)
+
+	// Google may leave the scheme out of the issuer claim
+	issuer, _ := claims["iss"].(string)
+	if issuer != discovery.Issuer && "https://"+issuer != discovery.Issuer {
+		return Claims{}, ErrIDTokenInvalid
+	}
+
+	if !claims.VerifyAudience(p.config.ClientID, true) {
+		return Claims{}, ErrIDTokenInvalid
+	}
+
+	if claimNonce, _ := claims["nonce"].(string); claimNonce != nonce {
+		return Claims{}, ErrNonceMismatch
+	}
+
+	result := Claims{}
+	result.Subject, _ = claims["sub"].(string)
+	result.Email, _ = claims["email"].(string)
+	result.Name, _ = claims["name"].(string)
+
+	// some providers send the flag as a string
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		result.EmailVerified = verified
+	case string:
+		result.EmailVerified = verified == "true"
+	}
+
+	if result.Subject == "" {
+		return Claims{}, ErrIDTokenInvalid
+	}
+
+	return result, nil
+}
+
+func (p *provider) getDiscovery() (*discoveryDocument, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(p.config.Issuer, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, ErrDiscovery
+	}
+
+	var discovery discoveryDocument
+	if err := p.doJSON(req, &discovery); err != nil {
+		return nil, ErrDiscovery
+	}
+
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, ErrDiscovery
+	}
+
+	p.discovery = &discovery
+	return p.discovery, nil
+}
+
+// keys are cached by id and fetched again when an unknown id shows up,
+// which is how providers roll their signing keys
+func (p *provider) getKey(jwksURI string, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set jsonWebKeySet
+	if err := p.doJSON(req, &set); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			continue
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			continue
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	p.keys = keys
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	return key, nil
+}
+
+func (p *provider) doJSON(req *http.Request, v interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	res, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d from %s", res.StatusCode, req.URL.Host)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
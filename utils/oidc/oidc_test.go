@@ -0,0 +1,157 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// a minimal provider serving discovery, its signing key and a token endpoint
+type mockServer struct {
+	*httptest.Server
+	key      *rsa.PrivateKey
+	claims   jwt.MapClaims
+	verifier string
+}
+
+func newMockServer(t *testing.T) *mockServer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := &mockServer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.URL,
+			"authorization_endpoint": m.URL + "/auth",
+			"token_endpoint":         m.URL + "/token",
+			"jwks_uri":               m.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "mock",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		m.verifier = r.PostForm.Get("code_verifier")
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, m.claims)
+		token.Header["kid"] = "mock"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed})
+	})
+
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *mockServer) provider() Provider {
+	return NewProvider(Config{
+		Name:     "mock",
+		Issuer:   m.URL,
+		ClientID: "client",
+	})
+}
+
+func (m *mockServer) validClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            m.URL,
+		"aud":            "client",
+		"sub":            "subject",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"name":           "User",
+		"nonce":          nonce,
+		"exp":            time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	m := newMockServer(t)
+
+	raw, err := m.provider().AuthCodeURL("https://app.test/callback", "state", "nonce", "challenge")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := parsed.Query()
+	if parsed.Path != "/auth" || query.Get("state") != "state" || query.Get("nonce") != "nonce" ||
+		query.Get("code_challenge") != "challenge" || query.Get("code_challenge_method") != "S256" {
+		t.Fatalf("unexpected auth url %s", raw)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	m := newMockServer(t)
+	m.claims = m.validClaims("nonce")
+
+	claims, err := m.provider().Exchange(context.Background(), "https://app.test/callback", "code", "verifier", "nonce")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if claims.Subject != "subject" || claims.Email != "user@example.com" || !claims.EmailVerified {
+		t.Fatalf("unexpected claims %+v", claims)
+	}
+
+	if m.verifier != "verifier" {
+		t.Fatalf("code verifier %q was not sent", m.verifier)
+	}
+}
+
+func TestExchangeRejectsInvalidIDToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		err    error
+	}{
+		{"nonce mismatch", func(c jwt.MapClaims) { c["nonce"] = "other" }, ErrNonceMismatch},
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "someone-else" }, ErrIDTokenInvalid},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://evil.test" }, ErrIDTokenInvalid},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }, ErrIDTokenInvalid},
+		{"no subject", func(c jwt.MapClaims) { delete(c, "sub") }, ErrIDTokenInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newMockServer(t)
+			m.claims = m.validClaims("nonce")
+			tt.mutate(m.claims)
+
+			if _, err := m.provider().Exchange(context.Background(), "https://app.test/callback", "code", "verifier", "nonce"); err != tt.err {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// minimal single page PDF writer, only supports the standard
+// Helvetica fonts so no font embedding is needed
+// https://opensource.adobe.com/dc-acrobat-sdk-docs/pdfstandards/PDF32000_2008.pdf
+
+const (
+	PDF_A4_LANDSCAPE_WIDTH  = 842
+	PDF_A4_LANDSCAPE_HEIGHT = 595
+)
+
+// RenderPDFTemplate executes a content stream template and wraps
+// the result into a single page PDF document. Values rendered inside
+// the template should go through the "pdf" function so parentheses
+// and backslashes are escaped properly, "center" gives an approximate
+// x position to horizontally center a text of the given font size.
+func RenderPDFTemplate(path string, data any, width int, height int) ([]byte, error) {
+	tmpl, err := template.New(filepath.Base(path)).Funcs(template.FuncMap{
+		"pdf": PDFEscape,
+		"center": func(size int, text string) int {
+			// Helvetica averages roughly half of the font size per glyph
+			return (width - len(text)*size/2) / 2
+		},
+	}).ParseFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var content bytes.Buffer
+	if err := tmpl.Execute(&content, data); err != nil {
+		return nil, err
+	}
+
+	return BuildPDF(content.Bytes(), width, height), nil
+}
+
+func BuildPDF(content []byte, width int, height int) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Contents 4 0 R /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> >>", width, height),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return buf.Bytes()
+}
+
+func PDFEscape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", "", "\n", " ")
+	return replacer.Replace(s)
+}
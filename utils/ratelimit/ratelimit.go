@@ -0,0 +1,81 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Store decides whether one more request fits under limit requests per period
+// for the key. Implementations must be safe for concurrent use, the in-memory
+// one only works for a single instance, a shared backend such as redis can be
+// plugged in for more.
+type Store interface {
+	Take(key string, limit int, period time.Duration) (allowed bool, retryAfter time.Duration, err error)
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	period  time.Duration
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+// NewMemoryStore returns a token bucket store kept in process memory,
+// buckets that have refilled completely are dropped periodically.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: make(map[string]*bucket),
+		swept:   time.Now(),
+	}
+}
+
+const sweepInterval = time.Minute
+
+func (s *memoryStore) Take(key string, limit int, period time.Duration) (bool, time.Duration, error) {
+	if limit <= 0 || period <= 0 {
+		return false, period, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.swept) > sweepInterval {
+		s.sweep(now)
+	}
+
+	// the bucket holds at most limit tokens and refills limit tokens per period
+	rate := float64(limit) / period.Seconds()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit), updated: now, period: period}
+		s.buckets[key] = b
+	}
+
+	b.tokens = math.Min(float64(limit), b.tokens+now.Sub(b.updated).Seconds()*rate)
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0, nil
+	}
+
+	retryAfter := time.Duration((1 - b.tokens) / rate * float64(time.Second))
+	return false, retryAfter, nil
+}
+
+// a bucket idle for a whole period is full again, forgetting it changes nothing
+func (s *memoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= b.period {
+			delete(s.buckets, key)
+		}
+	}
+	s.swept = now
+}
@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTakeLimitsPerKey(t *testing.T) {
+	store := NewMemoryStore()
+
+	for i := 0; i < 3; i++ {
+		if allowed, _, _ := store.Take("a", 3, time.Hour); !allowed {
+			t.Fatalf("request %d refused", i)
+		}
+	}
+
+	allowed, retryAfter, err := store.Take("a", 3, time.Hour)
+	if err != nil || allowed {
+		t.Fatalf("fourth request allowed=%v err=%v", allowed, err)
+	}
+
+	// one token refills every period / limit
+	if retryAfter <= 0 || retryAfter > 20*time.Minute {
+		t.Fatalf("retryAfter = %v, want up to 20m", retryAfter)
+	}
+
+	if allowed, _, _ := store.Take("b", 3, time.Hour); !allowed {
+		t.Fatal("other key shares the bucket")
+	}
+}
+
+func TestTakeRefills(t *testing.T) {
+	store := NewMemoryStore().(*memoryStore)
+
+	store.Take("a", 2, time.Minute)
+	store.Take("a", 2, time.Minute)
+	if allowed, _, _ := store.Take("a", 2, time.Minute); allowed {
+		t.Fatal("bucket not empty")
+	}
+
+	// pretend half the period went by, which is worth one token
+	store.buckets["a"].updated = store.buckets["a"].updated.Add(-30 * time.Second)
+	if allowed, _, _ := store.Take("a", 2, time.Minute); !allowed {
+		t.Fatal("bucket did not refill")
+	}
+	if allowed, _, _ := store.Take("a", 2, time.Minute); allowed {
+		t.Fatal("bucket refilled more than elapsed")
+	}
+}
+
+func TestTakeRejectsInvalidPolicy(t *testing.T) {
+	if allowed, _, _ := NewMemoryStore().Take("a", 0, time.Minute); allowed {
+		t.Fatal("zero limit allowed a request")
+	}
+}
+
+func TestSweepDropsFullBuckets(t *testing.T) {
+	store := NewMemoryStore().(*memoryStore)
+	store.Take("idle", 1, time.Minute)
+	store.Take("busy", 1, time.Hour)
+
+	store.sweep(time.Now().Add(2 * time.Minute))
+	if _, ok := store.buckets["idle"]; ok {
+		t.Fatal("refilled bucket kept")
+	}
+	if _, ok := store.buckets["busy"]; !ok {
+		t.Fatal("bucket still refilling was dropped")
+	}
+}
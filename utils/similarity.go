@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+const SIMILARITY_SHINGLE_SIZE = 5
+
+type (
+	// a document broken down into word shingles, keeping the byte offset
+	// of every word so overlaps can be mapped back to the original text
+	ShingledText struct {
+		Text     string
+		words    []wordSpan
+		shingles map[string][]int
+	}
+
+	TextSegment struct {
+		Text    string
+		Overlap bool
+	}
+
+	wordSpan struct {
+		start int
+		end   int
+		word  string
+	}
+)
+
+func Shingle(text string, size int) ShingledText {
+	doc := ShingledText{
+		Text:     text,
+		shingles: make(map[string][]int),
+	}
+
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		}
+
+		if !isWord && start >= 0 {
+			doc.words = append(doc.words, wordSpan{start, i, strings.ToLower(text[start:i])})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		doc.words = append(doc.words, wordSpan{start, len(text), strings.ToLower(text[start:])})
+	}
+
+	for i := 0; i+size <= len(doc.words); i++ {
+		parts := make([]string, size)
+		for k := 0; k < size; k++ {
+			parts[k] = doc.words[i+k].word
+		}
+
+		key := strings.Join(parts, " ")
+		doc.shingles[key] = append(doc.shingles[key], i)
+	}
+
+	return doc
+}
+
+// jaccard similarity of both shingle sets, 0 when either text is too short
+func (a ShingledText) Similarity(b ShingledText) float64 {
+	if len(a.shingles) == 0 || len(b.shingles) == 0 {
+		return 0
+	}
+
+	var shared int
+	for key := range a.shingles {
+		if _, ok := b.shingles[key]; ok {
+			shared++
+		}
+	}
+
+	union := len(a.shingles) + len(b.shingles) - shared
+	return float64(shared) / float64(union)
+}
+
+// split both texts into segments, marking the words covered by shared shingles
+func (a ShingledText) Overlaps(b ShingledText, size int) ([]TextSegment, []TextSegment) {
+	matchedA := make([]bool, len(a.words))
+	matchedB := make([]bool, len(b.words))
+
+	for key, startsA := range a.shingles {
+		startsB, ok := b.shingles[key]
+		if !ok {
+			continue
+		}
+
+		for _, i := range startsA {
+			for k := 0; k < size; k++ {
+				matchedA[i+k] = true
+			}
+		}
+
+		for _, i := range startsB {
+			for k := 0; k < size; k++ {
+				matchedB[i+k] = true
+			}
+		}
+	}
+
+	return a.segments(matchedA), b.segments(matchedB)
+}
+
+func (a ShingledText) segments(matched []bool) []TextSegment {
+	var segments []TextSegment
+	cursor := 0
+
+	for i := 0; i < len(a.words); i++ {
+		if !matched[i] {
+			continue
+		}
+
+		j := i
+		for j+1 < len(a.words) && matched[j+1] {
+			j++
+		}
+
+		start, end := a.words[i].start, a.words[j].end
+		if start > cursor {
+			segments = append(segments, TextSegment{Text: a.Text[cursor:start]})
+		}
+
+		segments = append(segments, TextSegment{Text: a.Text[start:end], Overlap: true})
+		cursor = end
+		i = j
+	}
+
+	if cursor < len(a.Text) {
+		segments = append(segments, TextSegment{Text: a.Text[cursor:]})
+	}
+
+	return segments
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// the RFC 6238 SHA1 secret, "12345678901234567890" in base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTPVectors(t *testing.T) {
+	// the last six digits of the RFC 6238 appendix B values
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		step, ok := ValidateTOTP(rfcSecret, tt.code, time.Unix(tt.unix, 0))
+		if !ok || step != tt.unix/totpPeriod {
+			t.Fatalf("%d: got step %d ok %v, want step %d", tt.unix, step, ok, tt.unix/totpPeriod)
+		}
+	}
+}
+
+func TestValidateTOTPDrift(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfcSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / totpPeriod
+
+	tests := []struct {
+		name string
+		step int64
+		ok   bool
+	}{
+		{"previous step", step - 1, true},
+		{"next step", step + 1, true},
+		{"two steps behind", step - 2, false},
+		{"two steps ahead", step + 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ValidateTOTP(rfcSecret, totpCode(key, tt.step), now)
+			if ok != tt.ok || ok && got != tt.step {
+				t.Fatalf("got step %d ok %v, want step %d ok %v", got, ok, tt.step, tt.ok)
+			}
+		})
+	}
+}
+
+func TestValidateTOTPRejectsMalformed(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	if _, ok := ValidateTOTP(rfcSecret, "28708", now); ok {
+		t.Fatal("accepted a short code")
+	}
+	if _, ok := ValidateTOTP("not base32!", "287082", now); ok {
+		t.Fatal("accepted an invalid secret")
+	}
+	// lower case and surrounding spaces, as pasted from an app, still decode
+	if _, ok := ValidateTOTP(" "+strings.ToLower(rfcSecret)+" ", "287082", now); !ok {
+		t.Fatal("rejected a lower case secret")
+	}
+}
@@ -13,3 +13,37 @@ func ValidateEmail(email string) bool {
 	_, err := mail.ParseAddress(email)
 	return err == nil
 }
+
+// separators people type between digit groups are dropped
+func NormalizeHandphone(handphone string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(handphone))
+}
+
+func ValidateHandphone(handphone string, minDigits int, maxDigits int) bool {
+	digits := strings.TrimPrefix(handphone, "+")
+	if len(digits) < minDigits || len(digits) > maxDigits {
+		return false
+	}
+
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func ValidateAlphanumeric(s string) bool {
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}